@@ -7,9 +7,18 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -18,6 +27,9 @@ const (
 
 	// APIPrefix is the API version path prefix for Radarr/Sonarr.
 	APIPrefix = "/api/v3"
+
+	// APIPrefixV1 is the API version path prefix for Lidarr/Readarr.
+	APIPrefixV1 = "/api/v1"
 )
 
 // DebugLogger is an interface for debug logging to avoid circular dependencies.
@@ -25,13 +37,18 @@ type DebugLogger interface {
 	Debug(msg string, keyvals ...interface{})
 }
 
-// Client is a base HTTP client for Radarr/Sonarr APIs.
+// Client is a base HTTP client for Radarr/Sonarr/Lidarr/Readarr APIs.
 type Client struct {
 	baseURL    string
 	apiKey     string
+	apiPrefix  string
 	httpClient *http.Client
 	logger     DebugLogger
 	serverName string // For logging context
+	retry      RetryPolicy
+
+	pageSize        int // Records per page when reading wanted lists
+	pageConcurrency int // Pages fetched at once after the first
 }
 
 // RateLimitError is returned when the server returns HTTP 429 Too Many Requests.
@@ -66,11 +83,15 @@ func NewClient(url, apiKey string) *Client {
 // NewClientWithTimeout creates a new API client with a custom timeout.
 func NewClientWithTimeout(url, apiKey string, timeout time.Duration) *Client {
 	return &Client{
-		baseURL: NormalizeURL(url),
-		apiKey:  apiKey,
+		baseURL:   NormalizeURL(url),
+		apiKey:    apiKey,
+		apiPrefix: APIPrefix,
 		httpClient: &http.Client{
 			Timeout: timeout,
 		},
+		retry:           DefaultRetryPolicy(),
+		pageSize:        DefaultPageSize,
+		pageConcurrency: DefaultPageConcurrency,
 	}
 }
 
@@ -84,6 +105,161 @@ func (c *Client) Post(ctx context.Context, endpoint string, body, result any) er
 	return c.request(ctx, http.MethodPost, endpoint, body, result)
 }
 
+// Put performs a PUT request to the specified endpoint.
+func (c *Client) Put(ctx context.Context, endpoint string, body, result any) error {
+	return c.request(ctx, http.MethodPut, endpoint, body, result)
+}
+
+// GetCommand returns the current state of a command started with TriggerSearch.
+func (c *Client) GetCommand(ctx context.Context, id int) (*CommandResponse, error) {
+	var result CommandResponse
+	if err := c.Get(ctx, fmt.Sprintf("/command/%d", id), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetTags returns the server's tags.
+func (c *Client) GetTags(ctx context.Context) ([]Tag, error) {
+	var tags []Tag
+	if err := c.Get(ctx, "/tag", &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// EnsureTag returns the ID of the tag with the given label, creating the tag if the server doesn't have it.
+func (c *Client) EnsureTag(ctx context.Context, label string) (int, error) {
+	tags, err := c.GetTags(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tags: %w", err)
+	}
+	for _, tag := range tags {
+		if strings.EqualFold(tag.Label, label) {
+			return tag.ID, nil
+		}
+	}
+
+	var created Tag
+	if err := c.Post(ctx, "/tag", Tag{Label: label}, &created); err != nil {
+		return 0, fmt.Errorf("failed to create tag %q: %w", label, err)
+	}
+	return created.ID, nil
+}
+
+// tagLabels returns the server's tag labels by ID. Tags are only fetched if the records use any.
+func (c *Client) tagLabels(ctx context.Context, tagged bool) (map[int]string, error) {
+	labels := make(map[int]string)
+	if !tagged {
+		return labels, nil
+	}
+	tags, err := c.GetTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	for _, tag := range tags {
+		labels[tag.ID] = tag.Label
+	}
+	return labels, nil
+}
+
+// applyTags returns the editor endpoints' applyTags mode for adding or removing tags.
+func applyTags(add bool) string {
+	if add {
+		return "add"
+	}
+	return "remove"
+}
+
+// tagNames converts tag IDs to their labels, skipping tags the server didn't return.
+func tagNames(ids []int, labels map[int]string) []string {
+	var names []string
+	for _, id := range ids {
+		if label, ok := labels[id]; ok {
+			names = append(names, label)
+		}
+	}
+	return names
+}
+
+// GetGrabsSince returns the releases grabbed since the given time.
+func (c *Client) GetGrabsSince(ctx context.Context, since time.Time) ([]HistoryRecord, error) {
+	var records []HistoryRecord
+	endpoint := fmt.Sprintf("/history/since?date=%s&eventType=1", url.QueryEscape(since.UTC().Format(time.RFC3339)))
+	if err := c.Get(ctx, endpoint, &records); err != nil {
+		return nil, err
+	}
+
+	// eventType=1 is "grabbed" on every *arr; filter again in case a server ignores it
+	grabs := records[:0]
+	for _, record := range records {
+		if record.EventType == "grabbed" {
+			grabs = append(grabs, record)
+		}
+	}
+	return grabs, nil
+}
+
+// GetHistorySince returns every history event recorded since the given time.
+func (c *Client) GetHistorySince(ctx context.Context, since time.Time) ([]HistoryRecord, error) {
+	var records []HistoryRecord
+	endpoint := fmt.Sprintf("/history/since?date=%s", url.QueryEscape(since.UTC().Format(time.RFC3339)))
+	if err := c.Get(ctx, endpoint, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// GetWantedTotals returns the number of missing and cutoff unmet items without reading the lists.
+func (c *Client) GetWantedTotals(ctx context.Context) (*WantedTotals, error) {
+	var missing, cutoff PagedResponse[struct{}]
+	if err := c.Get(ctx, "/wanted/missing?page=1&pageSize=1", &missing); err != nil {
+		return nil, err
+	}
+	if err := c.Get(ctx, "/wanted/cutoff?page=1&pageSize=1", &cutoff); err != nil {
+		return nil, err
+	}
+	return &WantedTotals{Missing: missing.TotalRecords, Cutoff: cutoff.TotalRecords}, nil
+}
+
+// maxIDsPerRequest limits how many IDs are sent in one query string.
+const maxIDsPerRequest = 50
+
+// getByIDs reads the records with the given IDs from an endpoint that filters on a repeated query
+// parameter, e.g. "/episode?episodeIds=1&episodeIds=2". Long ID lists are split across requests.
+func getByIDs[T any](ctx context.Context, c *Client, endpoint, param string, ids []int) ([]T, error) {
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+
+	records := make([]T, 0, len(ids))
+	for chunk := range slices.Chunk(ids, maxIDsPerRequest) {
+		query := url.Values{}
+		for _, id := range chunk {
+			query.Add(param, strconv.Itoa(id))
+		}
+		var page []T
+		if err := c.Get(ctx, endpoint+separator+query.Encode(), &page); err != nil {
+			return nil, err
+		}
+		records = append(records, page...)
+	}
+	return records, nil
+}
+
+// GetQueue returns every download in the server's queue.
+func (c *Client) GetQueue(ctx context.Context) ([]QueueItem, error) {
+	fetch := func(ctx context.Context, page, pageSize int) (*PagedResponse[QueueItem], error) {
+		var result PagedResponse[QueueItem]
+		if err := c.Get(ctx, fmt.Sprintf("/queue?page=%d&pageSize=%d", page, pageSize), &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+	return fetchAllPages(ctx, c.pageSize, c.pageConcurrency, fetch, func(q QueueItem) int { return q.ID })
+}
+
 // BaseURL returns the client's base URL.
 func (c *Client) BaseURL() string {
 	return c.baseURL
@@ -96,37 +272,105 @@ func (c *Client) WithLogger(logger DebugLogger, serverName string) *Client {
 	return c
 }
 
-// request performs an HTTP request to the API.
-func (c *Client) request(ctx context.Context, method, endpoint string, body, result any) error {
-	url := c.baseURL + APIPrefix + endpoint
-	start := time.Now()
+// WithRetryPolicy replaces the client's retry policy.
+func (c *Client) WithRetryPolicy(policy RetryPolicy) *Client {
+	c.retry = policy
+	return c
+}
 
-	var bodyReader io.Reader
+// request performs an HTTP request to the API, retrying transient failures according to the client's retry policy.
+func (c *Client) request(ctx context.Context, method, endpoint string, body, result any) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "api.Client.request",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(c.requestAttributes(method, endpoint)...),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	var bodyBytes []byte
 	if body != nil {
-		bodyBytes, err := json.Marshal(body)
+		bodyBytes, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("marshaling request body: %w", err)
 		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		status, retryAfter, err := c.attempt(ctx, method, endpoint, bodyBytes, result)
+		if status != 0 {
+			span.SetAttributes(attribute.Int("http.response.status_code", status))
+		}
+		if err == nil || ctx.Err() != nil || !c.retry.shouldRetry(method, status, attempt) {
+			if err != nil && attempt > 1 {
+				return fmt.Errorf("%w (after %d attempts)", err, attempt)
+			}
+			return err
+		}
+
+		delay := c.retry.backoff(attempt)
+		if retryAfter > 0 {
+			if retryAfter > c.retry.MaxRetryAfter {
+				return err
+			}
+			delay = retryAfter
+		}
+
+		if c.logger != nil {
+			logFields := []interface{}{
+				"endpoint", endpoint,
+				"attempt", attempt,
+				"delay", delay.String(),
+				"error", err.Error(),
+			}
+			if c.serverName != "" {
+				logFields = append([]interface{}{"server", c.serverName}, logFields...)
+			}
+			c.logger.Debug("Retrying API request", logFields...)
+		}
+		span.AddEvent("retry", trace.WithAttributes(
+			attribute.Int("janitarr.attempt", attempt),
+			attribute.String("janitarr.retry_delay", delay.String()),
+			attribute.String("error.message", err.Error()),
+		))
+		span.SetAttributes(attribute.Int("http.request.resend_count", attempt))
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return fmt.Errorf("request cancelled: %w", err)
+		}
+	}
+}
+
+// attempt makes a single HTTP request. It returns the response status code (zero if no
+// response was received) and any Retry-After delay the server asked for.
+func (c *Client) attempt(ctx context.Context, method, endpoint string, bodyBytes []byte, result any) (int, time.Duration, error) {
+	url := c.baseURL + c.apiPrefix + endpoint
+	start := time.Now()
+
+	var bodyReader io.Reader
+	if bodyBytes != nil {
 		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
+		return 0, 0, fmt.Errorf("creating request: %w", err)
 	}
 
 	req.Header.Set("X-Api-Key", c.apiKey)
 	req.Header.Set("Content-Type", "application/json")
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		if ctx.Err() != nil {
-			return fmt.Errorf("request cancelled: %w", ctx.Err())
+			return 0, 0, fmt.Errorf("request cancelled: %w", ctx.Err())
 		}
 		if strings.Contains(err.Error(), "timeout") || strings.Contains(err.Error(), "deadline") {
-			return fmt.Errorf("request timeout: %w", err)
+			return 0, 0, fmt.Errorf("request timeout: %w", err)
 		}
-		return fmt.Errorf("request failed: %w", err)
+		return 0, 0, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -146,16 +390,38 @@ func (c *Client) request(ctx context.Context, method, endpoint string, body, res
 	}
 
 	if err := c.checkStatusCode(resp); err != nil {
-		return err
+		retryAfter, _ := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		return resp.StatusCode, retryAfter, err
 	}
 
 	if result != nil {
 		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-			return fmt.Errorf("decoding response: %w", err)
+			return resp.StatusCode, 0, fmt.Errorf("decoding response: %w", err)
 		}
 	}
 
-	return nil
+	return resp.StatusCode, 0, nil
+}
+
+// requestAttributes describes a request for its trace span, including the page of paginated endpoints.
+func (c *Client) requestAttributes(method, endpoint string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", method),
+		attribute.String("server.address", strings.TrimPrefix(strings.TrimPrefix(c.baseURL, "https://"), "http://")),
+	}
+	if c.serverName != "" {
+		attrs = append(attrs, attribute.String("janitarr.server.name", c.serverName))
+	}
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return append(attrs, attribute.String("url.path", c.apiPrefix+endpoint))
+	}
+	attrs = append(attrs, attribute.String("url.path", c.apiPrefix+parsed.Path))
+	if page, err := strconv.Atoi(parsed.Query().Get("page")); err == nil {
+		attrs = append(attrs, attribute.Int("janitarr.page", page))
+	}
+	return attrs
 }
 
 // checkStatusCode returns an error for non-success status codes.
@@ -169,12 +435,10 @@ func (c *Client) checkStatusCode(resp *http.Response) error {
 	case http.StatusNotFound:
 		return fmt.Errorf("not found: check server URL")
 	case http.StatusTooManyRequests:
-		// Parse Retry-After header (in seconds)
-		retryAfter := 30 * time.Second // Default
-		if retryAfterStr := resp.Header.Get("Retry-After"); retryAfterStr != "" {
-			if seconds, err := time.ParseDuration(retryAfterStr + "s"); err == nil {
-				retryAfter = seconds
-			}
+		// Retry-After may be given in seconds or as an HTTP date
+		retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if !ok {
+			retryAfter = 30 * time.Second // Default
 		}
 		return &RateLimitError{RetryAfter: retryAfter}
 	default:
@@ -9,6 +9,12 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestNormalizeURL(t *testing.T) {
@@ -338,3 +344,191 @@ func TestClientGet_TooManyRequests_DefaultRetryAfter(t *testing.T) {
 		t.Errorf("RetryAfter = %v, want %v (default)", rateLimitErr.RetryAfter, expectedRetryAfter)
 	}
 }
+
+func TestClientGetCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/command/42" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":42,"name":"MoviesSearch","status":"completed","result":"successful","message":"Completed"}`))
+	}))
+	defer server.Close()
+
+	cmd, err := NewClient(server.URL, "testapikey").GetCommand(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmd.Finished() || !cmd.Succeeded() {
+		t.Errorf("expected finished successful command, got %+v", cmd)
+	}
+
+	tests := []struct {
+		cmd       CommandResponse
+		finished  bool
+		succeeded bool
+	}{
+		{CommandResponse{Status: CommandStatusQueued}, false, false},
+		{CommandResponse{Status: CommandStatusStarted}, false, false},
+		{CommandResponse{Status: CommandStatusCompleted, Result: "unsuccessful"}, true, false},
+		{CommandResponse{Status: CommandStatusFailed}, true, false},
+		{CommandResponse{Status: CommandStatusAborted}, true, false},
+	}
+	for _, tt := range tests {
+		if tt.cmd.Finished() != tt.finished || tt.cmd.Succeeded() != tt.succeeded {
+			t.Errorf("%+v: Finished() = %v, Succeeded() = %v", tt.cmd, tt.cmd.Finished(), tt.cmd.Succeeded())
+		}
+	}
+}
+
+func TestClientGetGrabsSince(t *testing.T) {
+	since := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/history/since" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("date"); got != "2024-01-15T12:00:00Z" {
+			t.Errorf("date = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"id":1,"eventType":"grabbed","date":"2024-01-15T13:00:00Z","episodeId":7},
+			{"id":2,"eventType":"downloadFolderImported","date":"2024-01-15T14:00:00Z","episodeId":7}
+		]`))
+	}))
+	defer server.Close()
+
+	grabs, err := NewClient(server.URL, "testapikey").GetGrabsSince(context.Background(), since)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grabs) != 1 || grabs[0].ItemID() != 7 {
+		t.Errorf("expected one grab of item 7, got %+v", grabs)
+	}
+}
+
+func TestClientGetHistorySince(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("eventType") {
+			t.Errorf("expected every event type, got %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"id":1,"eventType":"grabbed","movieId":7},
+			{"id":2,"eventType":"downloadFolderImported","movieId":7},
+			{"id":3,"eventType":"movieFileDeleted","movieId":8}
+		]`))
+	}))
+	defer server.Close()
+
+	records, err := NewClient(server.URL, "testapikey").GetHistorySince(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %+v", records)
+	}
+	if records[0].Imported() || records[0].Deleted() || !records[1].Imported() || !records[2].Deleted() {
+		t.Errorf("unexpected event classification for %+v", records)
+	}
+}
+
+func TestClientGetWantedTotals(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pageSize") != "1" {
+			t.Errorf("expected a single record per page, got %q", r.URL.RawQuery)
+		}
+		total := 42
+		if r.URL.Path == "/api/v3/wanted/cutoff" {
+			total = 7
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(PagedResponse[Movie]{Page: 1, PageSize: 1, TotalRecords: total, Records: []Movie{{ID: 1}}})
+	}))
+	defer server.Close()
+
+	totals, err := NewClient(server.URL, "testapikey").GetWantedTotals(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if totals.Missing != 42 || totals.Cutoff != 7 {
+		t.Errorf("expected 42 missing and 7 cutoff, got %+v", totals)
+	}
+}
+
+func TestClientGetQueue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/queue" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		records := []QueueItem{{ID: 1, Status: "downloading", EpisodeID: 11}, {ID: 2, Status: "failed", EpisodeID: 12}}
+		if r.URL.Query().Get("page") == "2" {
+			records = []QueueItem{{ID: 3, Status: "completed", TrackedDownloadState: "failedPending", EpisodeID: 13}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(PagedResponse[QueueItem]{TotalRecords: 3, Records: records})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "testapikey")
+	client.WithPagination(2, 1)
+	queue, err := client.GetQueue(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(queue) != 3 || queue[2].ItemID() != 13 {
+		t.Fatalf("expected both pages of the queue, got %+v", queue)
+	}
+	if !queue[0].Active() || queue[1].Active() || queue[2].Active() {
+		t.Errorf("expected only the first download to be active, got %+v", queue)
+	}
+}
+
+func TestClientRequest_Spans(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(PagedResponse[Movie]{Page: 1, PageSize: 1, TotalRecords: 2, Records: []Movie{{ID: 1}}})
+	}))
+	defer server.Close()
+
+	client := NewRadarrClient(server.URL, "testapikey")
+	if _, err := client.GetMissing(context.Background(), 1, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetMissing(context.Background(), 2, 1); err == nil {
+		t.Fatal("expected error for page 2")
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(ended))
+	}
+	for i, span := range ended {
+		attrs := make(map[attribute.Key]attribute.Value)
+		for _, kv := range span.Attributes() {
+			attrs[kv.Key] = kv.Value
+		}
+		if span.Name() != "api.Client.request" {
+			t.Errorf("span name = %q, want api.Client.request", span.Name())
+		}
+		if got := attrs["url.path"].AsString(); got != "/api/v3/wanted/missing" {
+			t.Errorf("url.path = %q, want /api/v3/wanted/missing", got)
+		}
+		if got := attrs["janitarr.page"].AsInt64(); got != int64(i+1) {
+			t.Errorf("janitarr.page = %d, want %d", got, i+1)
+		}
+	}
+	if ended[0].Status().Code != codes.Unset || ended[1].Status().Code != codes.Error {
+		t.Errorf("expected only the failed page to be marked as an error, got %v and %v", ended[0].Status(), ended[1].Status())
+	}
+}
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// LidarrClient is an API client for Lidarr servers.
+type LidarrClient struct {
+	*Client
+}
+
+// NewLidarrClient creates a new Lidarr API client with default timeout.
+func NewLidarrClient(url, apiKey string) *LidarrClient {
+	return NewLidarrClientWithTimeout(url, apiKey, DefaultTimeout)
+}
+
+// NewLidarrClientWithTimeout creates a new Lidarr API client with a custom timeout.
+func NewLidarrClientWithTimeout(url, apiKey string, timeout time.Duration) *LidarrClient {
+	client := NewClientWithTimeout(url, apiKey, timeout)
+	client.apiPrefix = APIPrefixV1
+	return &LidarrClient{Client: client}
+}
+
+// TestConnection tests the connection to the Lidarr server.
+func (c *LidarrClient) TestConnection(ctx context.Context) (*SystemStatus, error) {
+	var result SystemStatus
+	if err := c.Get(ctx, "/system/status", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetQualityProfiles returns all quality profiles from Lidarr.
+func (c *LidarrClient) GetQualityProfiles(ctx context.Context) ([]QualityProfile, error) {
+	var profiles []QualityProfile
+	if err := c.Get(ctx, "/qualityprofile", &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// GetMissing returns a paginated list of missing albums.
+func (c *LidarrClient) GetMissing(ctx context.Context, page, pageSize int) (*PagedResponse[Album], error) {
+	var result PagedResponse[Album]
+	endpoint := fmt.Sprintf("/wanted/missing?page=%d&pageSize=%d&sortKey=id&sortDirection=ascending&includeArtist=true", page, pageSize)
+	if err := c.Get(ctx, endpoint, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetCutoffUnmet returns a paginated list of albums not meeting quality cutoff.
+func (c *LidarrClient) GetCutoffUnmet(ctx context.Context, page, pageSize int) (*PagedResponse[Album], error) {
+	var result PagedResponse[Album]
+	endpoint := fmt.Sprintf("/wanted/cutoff?page=%d&pageSize=%d&sortKey=id&sortDirection=ascending&includeArtist=true", page, pageSize)
+	if err := c.Get(ctx, endpoint, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// TriggerSearch triggers a search for the specified album IDs.
+// The returned command can be polled with GetCommand to follow the search.
+func (c *LidarrClient) TriggerSearch(ctx context.Context, albumIDs []int) (*CommandResponse, error) {
+	body := map[string]any{
+		"name":     "AlbumSearch",
+		"albumIds": albumIDs,
+	}
+	var result CommandResponse
+	if err := c.Post(ctx, "/command", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAllMissing retrieves all missing albums across all pages.
+func (c *LidarrClient) GetAllMissing(ctx context.Context) ([]MediaItem, error) {
+	return c.getAllItems(ctx, c.GetMissing)
+}
+
+// GetAllCutoffUnmet retrieves all cutoff unmet albums across all pages.
+func (c *LidarrClient) GetAllCutoffUnmet(ctx context.Context) ([]MediaItem, error) {
+	return c.getAllItems(ctx, c.GetCutoffUnmet)
+}
+
+// GetItems returns the current details of the specified albums, for refreshing cached wanted items.
+func (c *LidarrClient) GetItems(ctx context.Context, albumIDs []int) ([]MediaItem, error) {
+	records, err := getByIDs[Album](ctx, c.Client, "/album", "albumIds", albumIDs)
+	if err != nil {
+		return nil, err
+	}
+	return c.toItems(ctx, records)
+}
+
+// getAllItems fetches every page of a wanted list and converts the records to media items.
+func (c *LidarrClient) getAllItems(ctx context.Context, fetcher pageFetcher[Album]) ([]MediaItem, error) {
+	records, err := fetchAllPages(ctx, c.pageSize, c.pageConcurrency, fetcher, func(a Album) int { return a.ID })
+	if err != nil {
+		return nil, err
+	}
+	return c.toItems(ctx, records)
+}
+
+// toItems converts albums to media items, resolving their quality profile and tag names.
+func (c *LidarrClient) toItems(ctx context.Context, records []Album) ([]MediaItem, error) {
+	// Fetch quality profiles once
+	profiles, err := c.GetQualityProfiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quality profiles: %w", err)
+	}
+
+	// Build ID-to-name map
+	qualityProfiles := make(map[int]string)
+	for _, profile := range profiles {
+		qualityProfiles[profile.ID] = profile.Name
+	}
+
+	tags, err := c.tagLabels(ctx, slices.ContainsFunc(records, func(a Album) bool { return a.Artist != nil && len(a.Artist.Tags) > 0 }))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]MediaItem, 0, len(records))
+	for _, album := range records {
+		item := MediaItem{
+			ID:          album.ID,
+			Title:       album.Title,
+			Type:        "album",
+			Year:        releaseYear(album.ReleaseDate),
+			Monitored:   album.Monitored,
+			ReleaseDate: album.ReleaseDate,
+		}
+		if artist := album.Artist; artist != nil {
+			item.ArtistName = artist.ArtistName
+			item.QualityProfile = qualityProfiles[artist.QualityProfileId]
+			item.Tags = tagNames(artist.Tags, tags)
+			item.Path = artist.Path
+			item.Status = artist.Status
+			item.Added = artist.Added
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// releaseYear returns the year of a release date, or 0 if the date is unknown.
+func releaseYear(date time.Time) int {
+	if date.IsZero() {
+		return 0
+	}
+	return date.Year()
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLidarrClient_UsesV1API(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/system/status" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SystemStatus{AppName: "Lidarr", Version: "2.0.0"})
+	}))
+	defer server.Close()
+
+	client := NewLidarrClient(server.URL, "testapikey")
+	result, err := client.TestConnection(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.AppName != "Lidarr" {
+		t.Errorf("AppName = %q, want %q", result.AppName, "Lidarr")
+	}
+}
+
+func TestLidarrClient_GetAllMissing(t *testing.T) {
+	released := time.Date(2019, 5, 17, 0, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/qualityprofile":
+			json.NewEncoder(w).Encode([]QualityProfile{{ID: 1, Name: "Lossless"}})
+		case "/api/v1/wanted/missing":
+			if r.URL.Query().Get("includeArtist") != "true" {
+				t.Errorf("expected includeArtist=true")
+			}
+			json.NewEncoder(w).Encode(PagedResponse[Album]{
+				Page:         1,
+				PageSize:     100,
+				TotalRecords: 1,
+				Records: []Album{
+					{ID: 7, Title: "Father of the Bride", ReleaseDate: released, Artist: &Artist{ArtistName: "Vampire Weekend", QualityProfileId: 1}},
+				},
+			})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewLidarrClient(server.URL, "testapikey")
+	items, err := client.GetAllMissing(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(items))
+	}
+
+	item := items[0]
+	if item.Type != "album" || item.Title != "Father of the Bride" || item.ArtistName != "Vampire Weekend" {
+		t.Errorf("unexpected item: %+v", item)
+	}
+	if item.Year != 2019 || item.QualityProfile != "Lossless" {
+		t.Errorf("expected year 2019 and Lossless profile, got %d and %q", item.Year, item.QualityProfile)
+	}
+}
+
+func TestLidarrClient_TriggerSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/command" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "AlbumSearch" {
+			t.Errorf("expected AlbumSearch command, got %v", body["name"])
+		}
+		if ids, ok := body["albumIds"].([]any); !ok || len(ids) != 2 {
+			t.Errorf("expected 2 albumIds, got %v", body["albumIds"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(CommandResponse{ID: 1, Name: "AlbumSearch", Status: "queued"})
+	}))
+	defer server.Close()
+
+	client := NewLidarrClient(server.URL, "testapikey")
+	if _, err := client.TriggerSearch(context.Background(), []int{1, 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
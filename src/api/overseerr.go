@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// requestPageSize is the number of requests read per page from Overseerr or Jellyseerr.
+const requestPageSize = 100
+
+// OverseerrClient is an API client for Overseerr and Jellyseerr, which share the same API.
+type OverseerrClient struct {
+	*Client
+}
+
+// NewOverseerrClient creates a new Overseerr or Jellyseerr API client with default timeout.
+func NewOverseerrClient(url, apiKey string) *OverseerrClient {
+	return NewOverseerrClientWithTimeout(url, apiKey, DefaultTimeout)
+}
+
+// NewOverseerrClientWithTimeout creates a new Overseerr or Jellyseerr API client with a custom timeout.
+func NewOverseerrClientWithTimeout(url, apiKey string, timeout time.Duration) *OverseerrClient {
+	client := NewClientWithTimeout(url, apiKey, timeout)
+	client.apiPrefix = APIPrefixV1
+	return &OverseerrClient{Client: client}
+}
+
+// GetOutstandingRequests returns the approved requests whose media is not available yet, newest first.
+func (c *OverseerrClient) GetOutstandingRequests(ctx context.Context) ([]MediaRequest, error) {
+	var requests []MediaRequest
+	for skip := 0; ; skip += requestPageSize {
+		var page RequestPage
+		endpoint := fmt.Sprintf("/request?take=%d&skip=%d&filter=approved&sort=added", requestPageSize, skip)
+		if err := c.Get(ctx, endpoint, &page); err != nil {
+			return nil, err
+		}
+
+		for _, request := range page.Results {
+			if request.Outstanding() {
+				requests = append(requests, request)
+			}
+		}
+		if len(page.Results) < requestPageSize || skip+len(page.Results) >= page.PageInfo.Results {
+			return requests, nil
+		}
+	}
+}
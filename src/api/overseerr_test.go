@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestOverseerrClient_GetOutstandingRequests(t *testing.T) {
+	var all []MediaRequest
+	for id := 1; id <= 101; id++ {
+		all = append(all, MediaRequest{ID: id, Status: RequestStatusApproved, Media: RequestedMedia{MediaType: "movie", TmdbID: 1000 + id, Status: 3}})
+	}
+	all[1].Media.Status = MediaStatusAvailable                      // Already available
+	all[2].Is4K, all[2].Media.Status4K = true, MediaStatusAvailable // 4K copy available
+	all[3].Is4K, all[3].Media.Status = true, MediaStatusAvailable   // Only the regular copy is available
+
+	var skips []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/request" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("X-Api-Key") != "seerr-key" {
+			t.Errorf("expected API key header, got %q", r.Header.Get("X-Api-Key"))
+		}
+		if r.URL.Query().Get("filter") != "approved" {
+			t.Errorf("expected filter=approved, got %s", r.URL.Query().Get("filter"))
+		}
+		skips = append(skips, r.URL.Query().Get("skip"))
+
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		take, _ := strconv.Atoi(r.URL.Query().Get("take"))
+		var page RequestPage
+		page.PageInfo.Results = len(all)
+		page.Results = all[min(skip, len(all)):min(skip+take, len(all))]
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := NewOverseerrClient(server.URL, "seerr-key")
+	requests, err := client.GetOutstandingRequests(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(skips) != 2 || skips[1] != "100" {
+		t.Errorf("expected two pages, got skips %v", skips)
+	}
+	if len(requests) != 99 {
+		t.Fatalf("expected 99 outstanding requests, got %d", len(requests))
+	}
+	if requests[1].ID != 4 || requests[98].ID != 101 {
+		t.Errorf("expected requests 2 and 3 to be left out, got %d then %d", requests[0].ID, requests[1].ID)
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	// DefaultPageSize is the number of records requested per page of a wanted list.
+	DefaultPageSize = 100
+
+	// DefaultPageConcurrency is the number of pages fetched at once after the first.
+	DefaultPageConcurrency = 4
+
+	// MaxPageSize and MaxPageConcurrency bound per-server overrides so a typo can't overload a server.
+	MaxPageSize        = 1000
+	MaxPageConcurrency = 16
+)
+
+// pageFetcher fetches a single page of a paginated endpoint.
+type pageFetcher[T any] func(ctx context.Context, page, pageSize int) (*PagedResponse[T], error)
+
+// WithPagination sets how many records are requested per page and how many pages are fetched
+// at once when reading a wanted list. Values of zero or less keep the defaults.
+func (c *Client) WithPagination(pageSize, concurrency int) *Client {
+	if pageSize > 0 {
+		c.pageSize = min(pageSize, MaxPageSize)
+	}
+	if concurrency > 0 {
+		c.pageConcurrency = min(concurrency, MaxPageConcurrency)
+	}
+	return c
+}
+
+// fetchAllPages reads every record of a paginated endpoint. The first page reveals the total
+// number of records; the remaining pages are then fetched concurrently, at most concurrency at
+// a time. Records are returned in page order, and records that moved between pages while they
+// were being fetched are only returned once.
+func fetchAllPages[T any](ctx context.Context, pageSize, concurrency int, fetch pageFetcher[T], id func(T) int) ([]T, error) {
+	first, err := fetch(ctx, 1, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Servers may cap the page size; follow what they actually returned
+	if n := len(first.Records); n > 0 && n < pageSize && n < first.TotalRecords {
+		pageSize = n
+	}
+
+	pages := make([][]T, 1, max((first.TotalRecords+pageSize-1)/pageSize, 1))
+	pages[0] = first.Records
+	if len(first.Records) > 0 {
+		pages = pages[:cap(pages)]
+	}
+
+	if len(pages) > 1 {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			wg       sync.WaitGroup
+			mu       sync.Mutex
+			firstErr error
+		)
+		sem := make(chan struct{}, max(concurrency, 1))
+
+		for page := 2; page <= len(pages); page++ {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+			if ctx.Err() != nil {
+				break
+			}
+
+			wg.Add(1)
+			go func(page int) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				result, err := fetch(ctx, page, pageSize)
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+						cancel() // Stop fetching the remaining pages
+					}
+					mu.Unlock()
+					return
+				}
+				pages[page-1] = result.Records
+			}(page)
+		}
+
+		wg.Wait()
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[int]struct{}, first.TotalRecords)
+	records := make([]T, 0, first.TotalRecords)
+	for _, page := range pages {
+		for _, record := range page {
+			if _, ok := seen[id(record)]; ok {
+				continue
+			}
+			seen[id(record)] = struct{}{}
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakePages serves total records with IDs 1..total, in pages of at most maxPageSize records.
+func fakePages(total, maxPageSize int) pageFetcher[Movie] {
+	return func(ctx context.Context, page, pageSize int) (*PagedResponse[Movie], error) {
+		pageSize = min(pageSize, maxPageSize)
+		result := &PagedResponse[Movie]{Page: page, PageSize: pageSize, TotalRecords: total}
+		for id := (page-1)*pageSize + 1; id <= min(page*pageSize, total); id++ {
+			result.Records = append(result.Records, Movie{ID: id})
+		}
+		return result, nil
+	}
+}
+
+func movieID(m Movie) int { return m.ID }
+
+func assertSequentialIDs(t *testing.T, movies []Movie, total int) {
+	t.Helper()
+	if len(movies) != total {
+		t.Fatalf("expected %d records, got %d", total, len(movies))
+	}
+	for i, m := range movies {
+		if m.ID != i+1 {
+			t.Fatalf("record %d has ID %d, want %d", i, m.ID, i+1)
+		}
+	}
+}
+
+func TestFetchAllPages(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int
+		pageSize    int
+		maxPageSize int
+	}{
+		{"empty", 0, 100, 100},
+		{"single page", 42, 100, 100},
+		{"exact pages", 300, 100, 100},
+		{"partial last page", 1234, 100, 100},
+		{"server caps page size", 250, 100, 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movies, err := fetchAllPages(context.Background(), tt.pageSize, 4, fakePages(tt.total, tt.maxPageSize), movieID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertSequentialIDs(t, movies, tt.total)
+		})
+	}
+}
+
+func TestFetchAllPages_DeduplicatesShiftedRecords(t *testing.T) {
+	// A record was removed after page 1 was read, so page 2 starts with the last record of page 1
+	fetch := func(ctx context.Context, page, pageSize int) (*PagedResponse[Movie], error) {
+		switch page {
+		case 1:
+			return &PagedResponse[Movie]{TotalRecords: 6, Records: []Movie{{ID: 1}, {ID: 2}, {ID: 3}}}, nil
+		default:
+			return &PagedResponse[Movie]{TotalRecords: 5, Records: []Movie{{ID: 3}, {ID: 5}}}, nil
+		}
+	}
+
+	movies, err := fetchAllPages(context.Background(), 3, 2, fetch, movieID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []int{1, 2, 3, 5}
+	if len(movies) != len(want) {
+		t.Fatalf("expected IDs %v, got %v", want, movies)
+	}
+	for i, id := range want {
+		if movies[i].ID != id {
+			t.Errorf("expected IDs %v, got %v", want, movies)
+			break
+		}
+	}
+}
+
+func TestFetchAllPages_BoundsConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	pages := fakePages(2000, 100)
+	fetch := func(ctx context.Context, page, pageSize int) (*PagedResponse[Movie], error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return pages(ctx, page, pageSize)
+	}
+
+	movies, err := fetchAllPages(context.Background(), 100, 3, fetch, movieID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertSequentialIDs(t, movies, 2000)
+	if peak.Load() > 3 {
+		t.Errorf("expected at most 3 concurrent requests, saw %d", peak.Load())
+	}
+	if peak.Load() < 2 {
+		t.Errorf("expected pages to be fetched concurrently, saw %d at once", peak.Load())
+	}
+}
+
+func TestFetchAllPages_StopsOnError(t *testing.T) {
+	var calls atomic.Int32
+	pages := fakePages(5000, 100)
+	fetch := func(ctx context.Context, page, pageSize int) (*PagedResponse[Movie], error) {
+		calls.Add(1)
+		if page == 3 {
+			return nil, errors.New("server error: status 500")
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		time.Sleep(time.Millisecond)
+		return pages(ctx, page, pageSize)
+	}
+
+	if _, err := fetchAllPages(context.Background(), 100, 2, fetch, movieID); err == nil || err.Error() != "server error: status 500" {
+		t.Fatalf("expected the page error, got %v", err)
+	}
+	if calls.Load() >= 50 {
+		t.Errorf("expected remaining pages to be abandoned, made %d requests", calls.Load())
+	}
+}
+
+func TestRadarrClient_WithPagination(t *testing.T) {
+	const total = 230
+	var pageSizes []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v3/qualityprofile" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		if page == 1 {
+			pageSizes = append(pageSizes, r.URL.Query().Get("pageSize"))
+		}
+		result, _ := fakePages(total, 1000)(r.Context(), page, pageSize)
+		json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	client := NewRadarrClient(server.URL, "testapikey")
+	client.WithPagination(50, 2)
+
+	items, err := client.GetAllMissing(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != total || items[0].ID != 1 || items[total-1].ID != total {
+		t.Errorf("expected %d items in ID order, got %d", total, len(items))
+	}
+	if len(pageSizes) != 1 || pageSizes[0] != "50" {
+		t.Errorf("expected pageSize=50, got %v", pageSizes)
+	}
+}
+
+// BenchmarkGetAllMissing compares sequential and concurrent fetching of a 40,000 episode
+// backlog from a stand-in server that takes 2ms to answer each page.
+func BenchmarkGetAllMissing(b *testing.B) {
+	const total = 40000
+	pages := fakePages(total, 1000)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v3/qualityprofile" {
+			w.Write([]byte(`[{"id":1,"name":"HD-1080p"}]`))
+			return
+		}
+		time.Sleep(2 * time.Millisecond)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		movies, _ := pages(r.Context(), page, pageSize)
+		episodes := PagedResponse[Episode]{Page: page, PageSize: pageSize, TotalRecords: total}
+		for _, m := range movies.Records {
+			episodes.Records = append(episodes.Records, Episode{ID: m.ID, SeasonNumber: 1, EpisodeNumber: m.ID})
+		}
+		json.NewEncoder(w).Encode(episodes)
+	}))
+	defer server.Close()
+
+	for _, concurrency := range []int{1, 4, 8} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			client := NewSonarrClient(server.URL, "testapikey")
+			client.WithPagination(DefaultPageSize, concurrency)
+
+			for b.Loop() {
+				items, err := client.GetAllMissing(context.Background())
+				if err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+				if len(items) != total {
+					b.Fatalf("expected %d items, got %d", total, len(items))
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 )
 
@@ -60,13 +61,41 @@ func (c *RadarrClient) GetCutoffUnmet(ctx context.Context, page, pageSize int) (
 }
 
 // TriggerSearch triggers a search for the specified movie IDs.
-func (c *RadarrClient) TriggerSearch(ctx context.Context, movieIDs []int) error {
+// The returned command can be polled with GetCommand to follow the search.
+func (c *RadarrClient) TriggerSearch(ctx context.Context, movieIDs []int) (*CommandResponse, error) {
 	body := map[string]any{
 		"name":     "MoviesSearch",
 		"movieIds": movieIDs,
 	}
 	var result CommandResponse
-	return c.Post(ctx, "/command", body, &result)
+	if err := c.Post(ctx, "/command", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SetMonitored monitors or unmonitors the specified movies.
+func (c *RadarrClient) SetMonitored(ctx context.Context, movieIDs []int, monitored bool) error {
+	body := map[string]any{
+		"movieIds":  movieIDs,
+		"monitored": monitored,
+	}
+	return c.Put(ctx, "/movie/editor", body, nil)
+}
+
+// SetTag adds the tag with the given label to the specified movies, or removes it.
+// The tag is created if the server doesn't have it.
+func (c *RadarrClient) SetTag(ctx context.Context, movieIDs []int, label string, add bool) error {
+	tagID, err := c.EnsureTag(ctx, label)
+	if err != nil {
+		return err
+	}
+	body := map[string]any{
+		"movieIds":  movieIDs,
+		"tags":      []int{tagID},
+		"applyTags": applyTags(add),
+	}
+	return c.Put(ctx, "/movie/editor", body, nil)
 }
 
 // GetAllMissing retrieves all missing movies across all pages.
@@ -79,8 +108,30 @@ func (c *RadarrClient) GetAllCutoffUnmet(ctx context.Context) ([]MediaItem, erro
 	return c.getAllItems(ctx, c.GetCutoffUnmet)
 }
 
-// getAllItems is a helper to paginate through all items.
-func (c *RadarrClient) getAllItems(ctx context.Context, fetcher func(context.Context, int, int) (*PagedResponse[Movie], error)) ([]MediaItem, error) {
+// GetItems returns the current details of the specified movies, for refreshing cached wanted items.
+func (c *RadarrClient) GetItems(ctx context.Context, movieIDs []int) ([]MediaItem, error) {
+	records := make([]Movie, 0, len(movieIDs))
+	for _, id := range movieIDs {
+		var movie Movie
+		if err := c.Get(ctx, fmt.Sprintf("/movie/%d", id), &movie); err != nil {
+			return nil, err
+		}
+		records = append(records, movie)
+	}
+	return c.toItems(ctx, records)
+}
+
+// getAllItems fetches every page of a wanted list and converts the records to media items.
+func (c *RadarrClient) getAllItems(ctx context.Context, fetcher pageFetcher[Movie]) ([]MediaItem, error) {
+	records, err := fetchAllPages(ctx, c.pageSize, c.pageConcurrency, fetcher, func(m Movie) int { return m.ID })
+	if err != nil {
+		return nil, err
+	}
+	return c.toItems(ctx, records)
+}
+
+// toItems converts movies to media items, resolving their quality profile and tag names.
+func (c *RadarrClient) toItems(ctx context.Context, records []Movie) ([]MediaItem, error) {
 	// Fetch quality profiles once
 	profiles, err := c.GetQualityProfiles(ctx)
 	if err != nil {
@@ -93,32 +144,43 @@ func (c *RadarrClient) getAllItems(ctx context.Context, fetcher func(context.Con
 		qualityProfiles[profile.ID] = profile.Name
 	}
 
-	var items []MediaItem
-	page := 1
-	pageSize := 100
-
-	for {
-		result, err := fetcher(ctx, page, pageSize)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, movie := range result.Records {
-			qualityProfile := qualityProfiles[movie.QualityProfileId]
-			items = append(items, MediaItem{
-				ID:             movie.ID,
-				Title:          movie.Title,
-				Type:           "movie",
-				Year:           movie.Year,
-				QualityProfile: qualityProfile,
-			})
-		}
+	tags, err := c.tagLabels(ctx, slices.ContainsFunc(records, func(m Movie) bool { return len(m.Tags) > 0 }))
+	if err != nil {
+		return nil, err
+	}
 
-		if len(items) >= result.TotalRecords {
-			break
-		}
-		page++
+	items := make([]MediaItem, 0, len(records))
+	for _, movie := range records {
+		qualityProfile := qualityProfiles[movie.QualityProfileId]
+		items = append(items, MediaItem{
+			ID:             movie.ID,
+			Title:          movie.Title,
+			Type:           "movie",
+			Year:           movie.Year,
+			TmdbID:         movie.TmdbID,
+			ImdbID:         movie.ImdbID,
+			QualityProfile: qualityProfile,
+			Monitored:      movie.Monitored,
+			Tags:           tagNames(movie.Tags, tags),
+			Path:           movie.Path,
+			ReleaseDate:    movieReleaseDate(movie),
+			Unavailable:    !movie.IsAvailable,
+			Added:          movie.Added,
+		})
 	}
 
 	return items, nil
 }
+
+// movieReleaseDate returns the date a movie became obtainable, preferring the
+// digital release, then the physical release, then the cinema release.
+func movieReleaseDate(movie Movie) time.Time {
+	switch {
+	case !movie.DigitalRelease.IsZero():
+		return movie.DigitalRelease
+	case !movie.PhysicalRelease.IsZero():
+		return movie.PhysicalRelease
+	default:
+		return movie.InCinemas
+	}
+}
@@ -123,11 +123,14 @@ func TestRadarrClient_TriggerSearch(t *testing.T) {
 	defer server.Close()
 
 	client := NewRadarrClient(server.URL, "testapikey")
-	err := client.TriggerSearch(context.Background(), []int{1, 2, 3})
+	cmd, err := client.TriggerSearch(context.Background(), []int{1, 2, 3})
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	if cmd.ID != 1 {
+		t.Errorf("command ID = %d, want 1", cmd.ID)
+	}
 	if receivedBody["name"] != "MoviesSearch" {
 		t.Errorf("command name = %q, want MoviesSearch", receivedBody["name"])
 	}
@@ -185,8 +188,8 @@ func TestRadarrClient_GetAllMissing_SinglePage(t *testing.T) {
 			PageSize:     100,
 			TotalRecords: 2,
 			Records: []Movie{
-				{ID: 1, Title: "Movie One", Monitored: true, QualityProfileId: 1},
-				{ID: 2, Title: "Movie Two", Monitored: true, QualityProfileId: 1},
+				{ID: 1, Title: "Movie One", TmdbID: 603, Monitored: true, QualityProfileId: 1, Status: "released", IsAvailable: true},
+				{ID: 2, Title: "Movie Two", ImdbID: "tt0133093", Monitored: true, QualityProfileId: 1, Status: "inCinemas"},
 			},
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -209,6 +212,56 @@ func TestRadarrClient_GetAllMissing_SinglePage(t *testing.T) {
 	if items[0].QualityProfile != "HD-1080p" {
 		t.Errorf("quality profile = %q, want HD-1080p", items[0].QualityProfile)
 	}
+	if items[0].Unavailable || !items[1].Unavailable {
+		t.Errorf("expected only the movie in cinemas to be unavailable, got %v and %v", items[0].Unavailable, items[1].Unavailable)
+	}
+	if items[0].ExternalID() != "tmdb:603" || items[1].ExternalID() != "imdb:tt0133093" {
+		t.Errorf("external IDs = %q and %q, want tmdb:603 and imdb:tt0133093", items[0].ExternalID(), items[1].ExternalID())
+	}
+}
+
+func TestRadarrClient_GetAllMissing_ResolvesTags(t *testing.T) {
+	tagRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v3/qualityprofile":
+			json.NewEncoder(w).Encode([]QualityProfile{{ID: 1, Name: "HD-1080p"}})
+		case "/api/v3/tag":
+			tagRequests++
+			json.NewEncoder(w).Encode([]Tag{{ID: 1, Label: "4k"}, {ID: 2, Label: "kids"}})
+		default:
+			json.NewEncoder(w).Encode(PagedResponse[Movie]{
+				Page:         1,
+				PageSize:     100,
+				TotalRecords: 2,
+				Records: []Movie{
+					{ID: 1, Title: "Movie One", Monitored: true, QualityProfileId: 1, Tags: []int{1, 2}, Path: "/movies/Movie One"},
+					{ID: 2, Title: "Movie Two", QualityProfileId: 1},
+				},
+			})
+		}
+	}))
+	defer server.Close()
+
+	client := NewRadarrClient(server.URL, "testapikey")
+	items, err := client.GetAllMissing(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tagRequests != 1 {
+		t.Errorf("expected tags to be fetched once, got %d requests", tagRequests)
+	}
+	if got := items[0].Tags; len(got) != 2 || got[0] != "4k" || got[1] != "kids" {
+		t.Errorf("tags = %v, want [4k kids]", got)
+	}
+	if items[0].Path != "/movies/Movie One" || !items[0].Monitored {
+		t.Errorf("expected path and monitored status, got %+v", items[0])
+	}
+	if items[1].Tags != nil || items[1].Monitored {
+		t.Errorf("expected an untagged, unmonitored movie, got %+v", items[1])
+	}
 }
 
 func TestRadarrClient_GetAllMissing_MultiplePages(t *testing.T) {
@@ -317,3 +370,79 @@ func TestRadarrClient_GetMissing_Error(t *testing.T) {
 		t.Fatal("expected error for 500 response")
 	}
 }
+
+func TestRadarrClient_SetTag(t *testing.T) {
+	var edits []map[string]any
+	created := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/tag":
+			tags := []Tag{{ID: 1, Label: "4k"}}
+			if created > 0 {
+				tags = append(tags, Tag{ID: 7, Label: "janitarr-gave-up"})
+			}
+			json.NewEncoder(w).Encode(tags)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v3/tag":
+			created++
+			json.NewEncoder(w).Encode(Tag{ID: 7, Label: "janitarr-gave-up"})
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v3/movie/editor":
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			edits = append(edits, body)
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewRadarrClient(server.URL, "testapikey")
+	if err := client.SetTag(context.Background(), []int{3, 4}, "janitarr-gave-up", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The tag exists now, so removing it doesn't create it again
+	if err := client.SetTag(context.Background(), []int{3}, "Janitarr-Gave-Up", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if created != 1 {
+		t.Errorf("expected the tag to be created once, got %d", created)
+	}
+	if len(edits) != 2 || edits[0]["applyTags"] != "add" || edits[1]["applyTags"] != "remove" {
+		t.Fatalf("expected the tag to be added then removed, got %v", edits)
+	}
+	if tags, _ := edits[0]["tags"].([]any); len(tags) != 1 || tags[0] != float64(7) {
+		t.Errorf("expected tag 7 to be applied, got %v", edits[0]["tags"])
+	}
+}
+
+func TestRadarrClient_GetItems(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v3/qualityprofile":
+			json.NewEncoder(w).Encode([]QualityProfile{{ID: 1, Name: "HD-1080p"}})
+		case "/api/v3/tag":
+			json.NewEncoder(w).Encode([]Tag{{ID: 1, Label: "4k"}})
+		default:
+			paths = append(paths, r.URL.Path)
+			json.NewEncoder(w).Encode(Movie{ID: 7, Title: "Movie Seven", Monitored: true, QualityProfileId: 1, Tags: []int{1}, IsAvailable: true})
+		}
+	}))
+	defer server.Close()
+
+	client := NewRadarrClient(server.URL, "testapikey")
+	items, err := client.GetItems(context.Background(), []int{7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(paths) != 1 || paths[0] != "/api/v3/movie/7" {
+		t.Errorf("expected the movie to be read by ID, got %v", paths)
+	}
+	if len(items) != 1 || items[0].QualityProfile != "HD-1080p" || len(items[0].Tags) != 1 || items[0].Unavailable {
+		t.Errorf("unexpected items: %+v", items)
+	}
+}
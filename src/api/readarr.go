@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ReadarrClient is an API client for Readarr servers.
+type ReadarrClient struct {
+	*Client
+}
+
+// NewReadarrClient creates a new Readarr API client with default timeout.
+func NewReadarrClient(url, apiKey string) *ReadarrClient {
+	return NewReadarrClientWithTimeout(url, apiKey, DefaultTimeout)
+}
+
+// NewReadarrClientWithTimeout creates a new Readarr API client with a custom timeout.
+func NewReadarrClientWithTimeout(url, apiKey string, timeout time.Duration) *ReadarrClient {
+	client := NewClientWithTimeout(url, apiKey, timeout)
+	client.apiPrefix = APIPrefixV1
+	return &ReadarrClient{Client: client}
+}
+
+// TestConnection tests the connection to the Readarr server.
+func (c *ReadarrClient) TestConnection(ctx context.Context) (*SystemStatus, error) {
+	var result SystemStatus
+	if err := c.Get(ctx, "/system/status", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetQualityProfiles returns all quality profiles from Readarr.
+func (c *ReadarrClient) GetQualityProfiles(ctx context.Context) ([]QualityProfile, error) {
+	var profiles []QualityProfile
+	if err := c.Get(ctx, "/qualityprofile", &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// GetMissing returns a paginated list of missing books.
+func (c *ReadarrClient) GetMissing(ctx context.Context, page, pageSize int) (*PagedResponse[Book], error) {
+	var result PagedResponse[Book]
+	endpoint := fmt.Sprintf("/wanted/missing?page=%d&pageSize=%d&sortKey=id&sortDirection=ascending&includeAuthor=true", page, pageSize)
+	if err := c.Get(ctx, endpoint, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetCutoffUnmet returns a paginated list of books not meeting quality cutoff.
+func (c *ReadarrClient) GetCutoffUnmet(ctx context.Context, page, pageSize int) (*PagedResponse[Book], error) {
+	var result PagedResponse[Book]
+	endpoint := fmt.Sprintf("/wanted/cutoff?page=%d&pageSize=%d&sortKey=id&sortDirection=ascending&includeAuthor=true", page, pageSize)
+	if err := c.Get(ctx, endpoint, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// TriggerSearch triggers a search for the specified book IDs.
+// The returned command can be polled with GetCommand to follow the search.
+func (c *ReadarrClient) TriggerSearch(ctx context.Context, bookIDs []int) (*CommandResponse, error) {
+	body := map[string]any{
+		"name":    "BookSearch",
+		"bookIds": bookIDs,
+	}
+	var result CommandResponse
+	if err := c.Post(ctx, "/command", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAllMissing retrieves all missing books across all pages.
+func (c *ReadarrClient) GetAllMissing(ctx context.Context) ([]MediaItem, error) {
+	return c.getAllItems(ctx, c.GetMissing)
+}
+
+// GetAllCutoffUnmet retrieves all cutoff unmet books across all pages.
+func (c *ReadarrClient) GetAllCutoffUnmet(ctx context.Context) ([]MediaItem, error) {
+	return c.getAllItems(ctx, c.GetCutoffUnmet)
+}
+
+// GetItems returns the current details of the specified books, for refreshing cached wanted items.
+func (c *ReadarrClient) GetItems(ctx context.Context, bookIDs []int) ([]MediaItem, error) {
+	records, err := getByIDs[Book](ctx, c.Client, "/book", "bookIds", bookIDs)
+	if err != nil {
+		return nil, err
+	}
+	return c.toItems(ctx, records)
+}
+
+// getAllItems fetches every page of a wanted list and converts the records to media items.
+func (c *ReadarrClient) getAllItems(ctx context.Context, fetcher pageFetcher[Book]) ([]MediaItem, error) {
+	records, err := fetchAllPages(ctx, c.pageSize, c.pageConcurrency, fetcher, func(b Book) int { return b.ID })
+	if err != nil {
+		return nil, err
+	}
+	return c.toItems(ctx, records)
+}
+
+// toItems converts books to media items, resolving their quality profile and tag names.
+func (c *ReadarrClient) toItems(ctx context.Context, records []Book) ([]MediaItem, error) {
+	// Fetch quality profiles once
+	profiles, err := c.GetQualityProfiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quality profiles: %w", err)
+	}
+
+	// Build ID-to-name map
+	qualityProfiles := make(map[int]string)
+	for _, profile := range profiles {
+		qualityProfiles[profile.ID] = profile.Name
+	}
+
+	tags, err := c.tagLabels(ctx, slices.ContainsFunc(records, func(b Book) bool { return b.Author != nil && len(b.Author.Tags) > 0 }))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]MediaItem, 0, len(records))
+	for _, book := range records {
+		item := MediaItem{
+			ID:          book.ID,
+			Title:       book.Title,
+			Type:        "book",
+			Year:        releaseYear(book.ReleaseDate),
+			Monitored:   book.Monitored,
+			ReleaseDate: book.ReleaseDate,
+		}
+		if author := book.Author; author != nil {
+			item.AuthorName = author.AuthorName
+			item.QualityProfile = qualityProfiles[author.QualityProfileId]
+			item.Tags = tagNames(author.Tags, tags)
+			item.Path = author.Path
+			item.Status = author.Status
+			item.Added = author.Added
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
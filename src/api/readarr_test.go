@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadarrClient_GetAllCutoffUnmet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/qualityprofile":
+			json.NewEncoder(w).Encode([]QualityProfile{{ID: 2, Name: "eBook"}})
+		case "/api/v1/wanted/cutoff":
+			if r.URL.Query().Get("includeAuthor") != "true" {
+				t.Errorf("expected includeAuthor=true")
+			}
+			json.NewEncoder(w).Encode(PagedResponse[Book]{
+				Page:         1,
+				PageSize:     100,
+				TotalRecords: 1,
+				Records: []Book{
+					{ID: 3, Title: "Dune", Author: &Author{AuthorName: "Frank Herbert", QualityProfileId: 2}},
+				},
+			})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewReadarrClient(server.URL, "testapikey")
+	items, err := client.GetAllCutoffUnmet(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(items))
+	}
+
+	item := items[0]
+	if item.Type != "book" || item.Title != "Dune" || item.AuthorName != "Frank Herbert" || item.QualityProfile != "eBook" {
+		t.Errorf("unexpected item: %+v", item)
+	}
+	if item.Year != 0 {
+		t.Errorf("expected year 0 for unknown release date, got %d", item.Year)
+	}
+}
+
+func TestReadarrClient_TriggerSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/command" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "BookSearch" {
+			t.Errorf("expected BookSearch command, got %v", body["name"])
+		}
+		if ids, ok := body["bookIds"].([]any); !ok || len(ids) != 1 {
+			t.Errorf("expected 1 bookId, got %v", body["bookIds"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(CommandResponse{ID: 1, Name: "BookSearch", Status: "queued"})
+	}))
+	defer server.Close()
+
+	client := NewReadarrClient(server.URL, "testapikey")
+	if _, err := client.TriggerSearch(context.Background(), []int{3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
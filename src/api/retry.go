@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how the client retries failed requests.
+//
+// GET requests are retried after connection errors, timeouts and any of the retryable status
+// codes. Other methods are only retried on 429 and 503, where the server refused the request
+// without acting on it, so a search command is never started twice.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first; 1 disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry. It is multiplied by Multiplier
+	// for each further retry, up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+
+	// Jitter randomly varies each backoff by up to this fraction (0.2 = ±20%) so that
+	// servers failing together are not retried in lockstep.
+	Jitter float64
+
+	// RetryableStatusCodes lists the response codes worth retrying.
+	RetryableStatusCodes []int
+
+	// MaxRetryAfter is the longest Retry-After the client will wait for. Longer waits are
+	// returned to the caller as a RateLimitError instead.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used by new clients.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		MaxRetryAfter: 30 * time.Second,
+	}
+}
+
+// NoRetry returns a policy that makes a single attempt.
+func NoRetry() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// shouldRetry reports whether a failed attempt may be retried.
+// A status of zero means no response was received.
+func (p RetryPolicy) shouldRetry(method string, status, attempt int) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	if method == http.MethodGet {
+		return status == 0 || slices.Contains(p.RetryableStatusCodes, status)
+	}
+	return (status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable) &&
+		slices.Contains(p.RetryableStatusCodes, status)
+}
+
+// backoff returns the wait before retrying after the given attempt (starting at 1).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		delay *= p.Multiplier
+		if p.MaxBackoff > 0 && delay >= float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		delay *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(delay)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the given duration or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,256 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetryPolicy retries quickly so tests don't wait on real backoff delays.
+func fastRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	policy.Jitter = 0
+	return policy
+}
+
+type recordingDebugLogger struct {
+	messages []string
+}
+
+func (l *recordingDebugLogger) Debug(msg string, keyvals ...interface{}) {
+	l.messages = append(l.messages, msg)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"seconds", "120", 2 * time.Minute, true},
+		{"zero", "0", 0, true},
+		{"http date", "Mon, 15 Jan 2024 12:00:45 GMT", 45 * time.Second, true},
+		{"date in the past", "Mon, 15 Jan 2024 11:00:00 GMT", 0, true},
+		{"empty", "", 0, false},
+		{"negative", "-5", 0, false},
+		{"garbage", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 2}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, expected := range want {
+		if got := policy.backoff(i + 1); got != expected {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, expected)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for range 100 {
+		if got := policy.backoff(1); got < 500*time.Millisecond || got > 1500*time.Millisecond {
+			t.Fatalf("backoff with jitter = %v, want within ±50%% of 1s", got)
+		}
+	}
+}
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	tests := []struct {
+		method  string
+		status  int
+		attempt int
+		want    bool
+	}{
+		{http.MethodGet, 0, 1, true},
+		{http.MethodGet, http.StatusBadGateway, 1, true},
+		{http.MethodGet, http.StatusTooManyRequests, 2, true},
+		{http.MethodGet, http.StatusServiceUnavailable, 3, false}, // out of attempts
+		{http.MethodGet, http.StatusUnauthorized, 1, false},
+		{http.MethodGet, http.StatusNotFound, 1, false},
+		{http.MethodPost, 0, 1, false},
+		{http.MethodPost, http.StatusInternalServerError, 1, false},
+		{http.MethodPost, http.StatusTooManyRequests, 1, true},
+		{http.MethodPost, http.StatusServiceUnavailable, 1, true},
+	}
+
+	for _, tt := range tests {
+		if got := policy.shouldRetry(tt.method, tt.status, tt.attempt); got != tt.want {
+			t.Errorf("shouldRetry(%s, %d, %d) = %v, want %v", tt.method, tt.status, tt.attempt, got, tt.want)
+		}
+	}
+
+	if NoRetry().shouldRetry(http.MethodGet, 0, 1) {
+		t.Error("NoRetry should never retry")
+	}
+}
+
+func TestClientRequest_RetriesTransientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"appName": "Radarr", "version": "5.0.0"}`))
+	}))
+	defer server.Close()
+
+	logger := &recordingDebugLogger{}
+	client := NewClient(server.URL, "testapikey").WithRetryPolicy(fastRetryPolicy()).WithLogger(logger, "Movies")
+
+	var result SystemStatus
+	if err := client.Get(context.Background(), "/system/status", &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+	if result.AppName != "Radarr" {
+		t.Errorf("expected decoded response, got %+v", result)
+	}
+
+	retries := 0
+	for _, msg := range logger.messages {
+		if msg == "Retrying API request" {
+			retries++
+		}
+	}
+	if retries != 2 {
+		t.Errorf("expected 2 retry log messages, got %v", logger.messages)
+	}
+}
+
+func TestClientRequest_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "testapikey").WithRetryPolicy(fastRetryPolicy())
+	err := client.Get(context.Background(), "/system/status", nil)
+
+	if err == nil || !strings.Contains(err.Error(), "502") || !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Errorf("expected 502 error after 3 attempts, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestClientRequest_DoesNotRetryPostOnServerError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "testapikey").WithRetryPolicy(fastRetryPolicy())
+	if err := client.Post(context.Background(), "/command", map[string]string{"name": "MoviesSearch"}, nil); err == nil {
+		t.Fatal("expected error for 500 response")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected a single attempt, got %d", calls.Load())
+	}
+}
+
+func TestClientRequest_HonoursRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "testapikey").WithRetryPolicy(fastRetryPolicy())
+
+	start := time.Now()
+	if err := client.Post(context.Background(), "/command", map[string]string{"name": "MoviesSearch"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected to wait for Retry-After, returned after %v", elapsed)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] == "" {
+		t.Errorf("expected the same body to be resent, got %q", bodies)
+	}
+}
+
+func TestClientRequest_LongRetryAfterIsReturned(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "testapikey").WithRetryPolicy(fastRetryPolicy())
+	err := client.Get(context.Background(), "/system/status", nil)
+
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.RetryAfter != time.Hour {
+		t.Fatalf("expected RateLimitError with 1h RetryAfter, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected a single attempt, got %d", calls.Load())
+	}
+}
+
+func TestClientRequest_CancelledDuringBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := fastRetryPolicy()
+	policy.InitialBackoff = time.Hour
+	policy.MaxBackoff = time.Hour
+	client := NewClient(server.URL, "testapikey").WithRetryPolicy(policy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.Get(ctx, "/system/status", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected backoff to stop on cancellation, took %v", elapsed)
+	}
+}
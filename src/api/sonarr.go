@@ -3,9 +3,17 @@ package api
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 )
 
+// Sonarr search commands.
+const (
+	CommandEpisodeSearch = "EpisodeSearch"
+	CommandSeasonSearch  = "SeasonSearch"
+	CommandSeriesSearch  = "SeriesSearch"
+)
+
 // SonarrClient is an API client for Sonarr servers.
 type SonarrClient struct {
 	*Client
@@ -42,7 +50,7 @@ func (c *SonarrClient) GetQualityProfiles(ctx context.Context) ([]QualityProfile
 // GetMissing returns a paginated list of missing episodes.
 func (c *SonarrClient) GetMissing(ctx context.Context, page, pageSize int) (*PagedResponse[Episode], error) {
 	var result PagedResponse[Episode]
-	endpoint := fmt.Sprintf("/wanted/missing?page=%d&pageSize=%d&sortKey=id&sortDirection=ascending", page, pageSize)
+	endpoint := fmt.Sprintf("/wanted/missing?page=%d&pageSize=%d&sortKey=id&sortDirection=ascending&includeSeries=true", page, pageSize)
 	if err := c.Get(ctx, endpoint, &result); err != nil {
 		return nil, err
 	}
@@ -52,7 +60,7 @@ func (c *SonarrClient) GetMissing(ctx context.Context, page, pageSize int) (*Pag
 // GetCutoffUnmet returns a paginated list of episodes not meeting quality cutoff.
 func (c *SonarrClient) GetCutoffUnmet(ctx context.Context, page, pageSize int) (*PagedResponse[Episode], error) {
 	var result PagedResponse[Episode]
-	endpoint := fmt.Sprintf("/wanted/cutoff?page=%d&pageSize=%d&sortKey=id&sortDirection=ascending", page, pageSize)
+	endpoint := fmt.Sprintf("/wanted/cutoff?page=%d&pageSize=%d&sortKey=id&sortDirection=ascending&includeSeries=true", page, pageSize)
 	if err := c.Get(ctx, endpoint, &result); err != nil {
 		return nil, err
 	}
@@ -60,13 +68,79 @@ func (c *SonarrClient) GetCutoffUnmet(ctx context.Context, page, pageSize int) (
 }
 
 // TriggerSearch triggers a search for the specified episode IDs.
-func (c *SonarrClient) TriggerSearch(ctx context.Context, episodeIDs []int) error {
+// The returned command can be polled with GetCommand to follow the search.
+func (c *SonarrClient) TriggerSearch(ctx context.Context, episodeIDs []int) (*CommandResponse, error) {
 	body := map[string]any{
-		"name":       "EpisodeSearch",
+		"name":       CommandEpisodeSearch,
 		"episodeIds": episodeIDs,
 	}
 	var result CommandResponse
-	return c.Post(ctx, "/command", body, &result)
+	if err := c.Post(ctx, "/command", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// TriggerSeasonSearch triggers a search for every monitored episode of a season, which lets
+// Sonarr grab a season pack. The returned command can be polled with GetCommand.
+func (c *SonarrClient) TriggerSeasonSearch(ctx context.Context, seriesID, seasonNumber int) (*CommandResponse, error) {
+	body := map[string]any{
+		"name":         CommandSeasonSearch,
+		"seriesId":     seriesID,
+		"seasonNumber": seasonNumber,
+	}
+	var result CommandResponse
+	if err := c.Post(ctx, "/command", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// TriggerSeriesSearch triggers a search for every monitored episode of a series.
+// The returned command can be polled with GetCommand.
+func (c *SonarrClient) TriggerSeriesSearch(ctx context.Context, seriesID int) (*CommandResponse, error) {
+	body := map[string]any{
+		"name":     CommandSeriesSearch,
+		"seriesId": seriesID,
+	}
+	var result CommandResponse
+	if err := c.Post(ctx, "/command", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SetMonitored monitors or unmonitors the specified episodes.
+func (c *SonarrClient) SetMonitored(ctx context.Context, episodeIDs []int, monitored bool) error {
+	body := map[string]any{
+		"episodeIds": episodeIDs,
+		"monitored":  monitored,
+	}
+	return c.Put(ctx, "/episode/monitor", body, nil)
+}
+
+// SetTag adds the tag with the given label to the specified series, or removes it.
+// Sonarr tags whole series, not episodes. The tag is created if the server doesn't have it.
+func (c *SonarrClient) SetTag(ctx context.Context, seriesIDs []int, label string, add bool) error {
+	tagID, err := c.EnsureTag(ctx, label)
+	if err != nil {
+		return err
+	}
+	body := map[string]any{
+		"seriesIds": seriesIDs,
+		"tags":      []int{tagID},
+		"applyTags": applyTags(add),
+	}
+	return c.Put(ctx, "/series/editor", body, nil)
+}
+
+// GetSeries returns every series on the server, including per-season episode counts.
+func (c *SonarrClient) GetSeries(ctx context.Context) ([]Series, error) {
+	var series []Series
+	if err := c.Get(ctx, "/series", &series); err != nil {
+		return nil, err
+	}
+	return series, nil
 }
 
 // GetAllMissing retrieves all missing episodes across all pages.
@@ -79,8 +153,26 @@ func (c *SonarrClient) GetAllCutoffUnmet(ctx context.Context) ([]MediaItem, erro
 	return c.getAllItems(ctx, c.GetCutoffUnmet)
 }
 
-// getAllItems is a helper to paginate through all items.
-func (c *SonarrClient) getAllItems(ctx context.Context, fetcher func(context.Context, int, int) (*PagedResponse[Episode], error)) ([]MediaItem, error) {
+// GetItems returns the current details of the specified episodes, for refreshing cached wanted items.
+func (c *SonarrClient) GetItems(ctx context.Context, episodeIDs []int) ([]MediaItem, error) {
+	records, err := getByIDs[Episode](ctx, c.Client, "/episode?includeSeries=true", "episodeIds", episodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	return c.toItems(ctx, records)
+}
+
+// getAllItems fetches every page of a wanted list and converts the records to media items.
+func (c *SonarrClient) getAllItems(ctx context.Context, fetcher pageFetcher[Episode]) ([]MediaItem, error) {
+	records, err := fetchAllPages(ctx, c.pageSize, c.pageConcurrency, fetcher, func(e Episode) int { return e.ID })
+	if err != nil {
+		return nil, err
+	}
+	return c.toItems(ctx, records)
+}
+
+// toItems converts episodes to media items, resolving their quality profile and tag names.
+func (c *SonarrClient) toItems(ctx context.Context, records []Episode) ([]MediaItem, error) {
 	// Fetch quality profiles once
 	profiles, err := c.GetQualityProfiles(ctx)
 	if err != nil {
@@ -93,42 +185,40 @@ func (c *SonarrClient) getAllItems(ctx context.Context, fetcher func(context.Con
 		qualityProfiles[profile.ID] = profile.Name
 	}
 
-	var items []MediaItem
-	page := 1
-	pageSize := 100
+	tags, err := c.tagLabels(ctx, slices.ContainsFunc(records, func(e Episode) bool { return e.Series != nil && len(e.Series.Tags) > 0 }))
+	if err != nil {
+		return nil, err
+	}
 
-	for {
-		result, err := fetcher(ctx, page, pageSize)
-		if err != nil {
-			return nil, err
+	items := make([]MediaItem, 0, len(records))
+	for _, episode := range records {
+		item := MediaItem{
+			ID:            episode.ID,
+			Title:         formatEpisodeTitle(episode),
+			EpisodeTitle:  episode.Title, // Raw episode title for logging
+			Type:          "episode",
+			SeriesID:      episode.SeriesID,
+			SeriesTitle:   episode.SeriesTitle,
+			SeasonNumber:  episode.SeasonNumber,
+			EpisodeNumber: episode.EpisodeNumber,
+			TvdbID:        episode.TvdbID,
+			Monitored:     episode.Monitored,
+			ReleaseDate:   episode.AirDateUtc,
 		}
-
-		for _, episode := range result.Records {
-			seriesTitle := episode.SeriesTitle
-			if episode.Series != nil && episode.Series.Title != "" {
-				seriesTitle = episode.Series.Title
+		if series := episode.Series; series != nil {
+			if series.Title != "" {
+				item.SeriesTitle = series.Title
 			}
-			qualityProfile := ""
-			if episode.Series != nil {
-				qualityProfile = qualityProfiles[episode.Series.QualityProfileId]
-			}
-
-			items = append(items, MediaItem{
-				ID:             episode.ID,
-				Title:          formatEpisodeTitle(episode),
-				EpisodeTitle:   episode.Title, // Raw episode title for logging
-				Type:           "episode",
-				SeriesTitle:    seriesTitle,
-				SeasonNumber:   episode.SeasonNumber,
-				EpisodeNumber:  episode.EpisodeNumber,
-				QualityProfile: qualityProfile,
-			})
+			item.Year = series.Year
+			item.SeriesTvdbID = series.TvdbID
+			item.QualityProfile = qualityProfiles[series.QualityProfileId]
+			item.Tags = tagNames(series.Tags, tags)
+			item.Path = series.Path
+			item.Status = series.Status
+			item.Added = series.Added
 		}
 
-		if len(items) >= result.TotalRecords {
-			break
-		}
-		page++
+		items = append(items, item)
 	}
 
 	return items, nil
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -114,7 +117,7 @@ func TestSonarrClient_TriggerSearch(t *testing.T) {
 	defer server.Close()
 
 	client := NewSonarrClient(server.URL, "testapikey")
-	err := client.TriggerSearch(context.Background(), []int{1, 2, 3})
+	_, err := client.TriggerSearch(context.Background(), []int{1, 2, 3})
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -131,6 +134,67 @@ func TestSonarrClient_TriggerSearch(t *testing.T) {
 	}
 }
 
+func TestSonarrClient_SeasonAndSeriesSearch(t *testing.T) {
+	var bodies []map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/command" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(CommandResponse{ID: len(bodies), Name: body["name"].(string), Status: "queued"})
+	}))
+	defer server.Close()
+
+	client := NewSonarrClient(server.URL, "testapikey")
+	if _, err := client.TriggerSeasonSearch(context.Background(), 7, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	command, err := client.TriggerSeriesSearch(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if bodies[0]["name"] != CommandSeasonSearch || bodies[0]["seriesId"] != float64(7) || bodies[0]["seasonNumber"] != float64(2) {
+		t.Errorf("unexpected season search body: %v", bodies[0])
+	}
+	if bodies[1]["name"] != CommandSeriesSearch || bodies[1]["seriesId"] != float64(7) {
+		t.Errorf("unexpected series search body: %v", bodies[1])
+	}
+	if command.ID != 2 {
+		t.Errorf("command ID = %d, want 2", command.ID)
+	}
+}
+
+func TestSonarrClient_GetSeries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/series" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id": 7, "title": "The Wire", "seasons": [
+			{"seasonNumber": 1, "monitored": true, "statistics": {"episodeCount": 13, "episodeFileCount": 2, "totalEpisodeCount": 13}}
+		]}]`))
+	}))
+	defer server.Close()
+
+	client := NewSonarrClient(server.URL, "testapikey")
+	series, err := client.GetSeries(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(series) != 1 || series[0].ID != 7 || len(series[0].Seasons) != 1 {
+		t.Fatalf("unexpected series: %+v", series)
+	}
+	if stats := series[0].Seasons[0].Statistics; stats == nil || stats.EpisodeCount != 13 || stats.EpisodeFileCount != 2 {
+		t.Errorf("unexpected season statistics: %+v", stats)
+	}
+}
+
 func TestSonarrClient_GetQualityProfiles(t *testing.T) {
 	expected := []QualityProfile{
 		{ID: 1, Name: "HD-1080p"},
@@ -176,8 +240,8 @@ func TestSonarrClient_GetAllMissing_SinglePage(t *testing.T) {
 			PageSize:     100,
 			TotalRecords: 2,
 			Records: []Episode{
-				{ID: 1, Title: "Pilot", SeriesTitle: "Show One", Series: &Series{Title: "Show One", QualityProfileId: 1}, SeasonNumber: 1, EpisodeNumber: 1, Monitored: true},
-				{ID: 2, Title: "Episode 2", SeriesTitle: "Show One", Series: &Series{Title: "Show One", QualityProfileId: 1}, SeasonNumber: 1, EpisodeNumber: 2, Monitored: true},
+				{ID: 1, Title: "Pilot", TvdbID: 349232, SeriesTitle: "Show One", Series: &Series{Title: "Show One", TvdbID: 81189, QualityProfileId: 1}, SeasonNumber: 1, EpisodeNumber: 1, Monitored: true},
+				{ID: 2, Title: "Episode 2", SeriesTitle: "Show One", Series: &Series{Title: "Show One", TvdbID: 81189, QualityProfileId: 1}, SeasonNumber: 1, EpisodeNumber: 2, Monitored: true},
 			},
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -200,6 +264,10 @@ func TestSonarrClient_GetAllMissing_SinglePage(t *testing.T) {
 	if items[0].QualityProfile != "HD-1080p" {
 		t.Errorf("quality profile = %q, want HD-1080p", items[0].QualityProfile)
 	}
+	// Episodes Sonarr has no TVDB ID for are identified by their series and number
+	if items[0].ExternalID() != "tvdb:349232" || items[1].ExternalID() != "tvdb:81189:S01E02" {
+		t.Errorf("external IDs = %q and %q, want tvdb:349232 and tvdb:81189:S01E02", items[0].ExternalID(), items[1].ExternalID())
+	}
 }
 
 func TestSonarrClient_GetAllMissing_FormatsTitle(t *testing.T) {
@@ -274,6 +342,46 @@ func TestSonarrClient_GetAllMissing_UsesSeriesObject(t *testing.T) {
 	}
 }
 
+func TestSonarrClient_GetAllMissing_SeriesDetails(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v3/qualityprofile":
+			json.NewEncoder(w).Encode([]QualityProfile{{ID: 1, Name: "HD-1080p"}})
+		case "/api/v3/tag":
+			json.NewEncoder(w).Encode([]Tag{{ID: 1, Label: "anime"}, {ID: 2, Label: "kids"}})
+		default:
+			query = r.URL.RawQuery
+			series := &Series{Title: "The Wire", Year: 2002, Status: "ended", QualityProfileId: 1, Tags: []int{2, 9}, Path: "/tv/The Wire"}
+			json.NewEncoder(w).Encode(PagedResponse[Episode]{
+				Page:         1,
+				PageSize:     100,
+				TotalRecords: 1,
+				Records:      []Episode{{ID: 1, Title: "Pilot", Series: series, SeasonNumber: 1, EpisodeNumber: 1, Monitored: true}},
+			})
+		}
+	}))
+	defer server.Close()
+
+	client := NewSonarrClient(server.URL, "testapikey")
+	items, err := client.GetAllMissing(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(query, "includeSeries=true") {
+		t.Errorf("expected series to be requested, got query %q", query)
+	}
+	item := items[0]
+	if item.Year != 2002 || item.Status != "ended" || item.Path != "/tv/The Wire" || !item.Monitored {
+		t.Errorf("expected series details on the episode, got %+v", item)
+	}
+	if len(item.Tags) != 1 || item.Tags[0] != "kids" {
+		t.Errorf("tags = %v, want [kids]", item.Tags)
+	}
+}
+
 func TestSonarrClient_GetAllMissing_MultiplePages(t *testing.T) {
 	requestCount := 0
 
@@ -380,3 +488,42 @@ func TestSonarrClient_GetMissing_Error(t *testing.T) {
 		t.Fatal("expected error for 500 response")
 	}
 }
+
+func TestSonarrClient_GetItems(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v3/qualityprofile":
+			json.NewEncoder(w).Encode([]QualityProfile{{ID: 1, Name: "HD-1080p"}})
+		case "/api/v3/episode":
+			queries = append(queries, r.URL.Query())
+			var episodes []Episode
+			for _, id := range r.URL.Query()["episodeIds"] {
+				n, _ := strconv.Atoi(id)
+				episodes = append(episodes, Episode{ID: n, Title: "Episode", Series: &Series{Title: "Show", QualityProfileId: 1}})
+			}
+			json.NewEncoder(w).Encode(episodes)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	ids := make([]int, maxIDsPerRequest+1)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	client := NewSonarrClient(server.URL, "testapikey")
+	items, err := client.GetItems(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(queries) != 2 || len(queries[0]["episodeIds"]) != maxIDsPerRequest || queries[0].Get("includeSeries") != "true" {
+		t.Errorf("expected the IDs to be split across two requests including series, got %v", queries)
+	}
+	if len(items) != len(ids) || items[0].SeriesTitle != "Show" || items[0].QualityProfile != "HD-1080p" {
+		t.Errorf("unexpected items: %d %+v", len(items), items[0])
+	}
+}
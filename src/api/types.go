@@ -1,6 +1,12 @@
-// Package api provides clients for interacting with Radarr and Sonarr APIs.
+// Package api provides clients for interacting with Radarr, Sonarr, Lidarr and Readarr APIs.
 package api
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
 // SystemStatus represents the system status response from Radarr/Sonarr.
 type SystemStatus struct {
 	AppName      string `json:"appName"`
@@ -14,32 +20,111 @@ type QualityProfile struct {
 	Name string `json:"name"`
 }
 
+// Tag represents a tag that can be applied to movies, series, artists and authors.
+type Tag struct {
+	ID    int    `json:"id"`
+	Label string `json:"label"`
+}
+
 // Movie represents a movie item from Radarr's wanted/missing or cutoff unmet endpoints.
 type Movie struct {
-	ID               int    `json:"id"`
-	Title            string `json:"title"`
-	Year             int    `json:"year"`
-	HasFile          bool   `json:"hasFile"`
-	Monitored        bool   `json:"monitored"`
-	QualityProfileId int    `json:"qualityProfileId"`
+	ID               int       `json:"id"`
+	Title            string    `json:"title"`
+	Year             int       `json:"year"`
+	TmdbID           int       `json:"tmdbId"`
+	ImdbID           string    `json:"imdbId,omitempty"`
+	HasFile          bool      `json:"hasFile"`
+	Monitored        bool      `json:"monitored"`
+	QualityProfileId int       `json:"qualityProfileId"`
+	Tags             []int     `json:"tags,omitempty"`
+	Path             string    `json:"path,omitempty"`
+	Status           string    `json:"status,omitempty"` // "tba", "announced", "inCinemas" or "released"
+	IsAvailable      bool      `json:"isAvailable"`      // Whether the movie has reached its minimum availability
+	Added            time.Time `json:"added"`
+	InCinemas        time.Time `json:"inCinemas"`
+	DigitalRelease   time.Time `json:"digitalRelease"`
+	PhysicalRelease  time.Time `json:"physicalRelease"`
 }
 
-// Series represents series info nested in Sonarr episode responses.
+// Series represents a Sonarr series, as nested in episode responses or listed by the series endpoint.
 type Series struct {
-	Title            string `json:"title"`
-	QualityProfileId int    `json:"qualityProfileId"`
+	ID               int       `json:"id"`
+	Title            string    `json:"title"`
+	Year             int       `json:"year"`
+	TvdbID           int       `json:"tvdbId"`
+	Status           string    `json:"status,omitempty"` // "continuing", "ended", "upcoming" or "deleted"
+	QualityProfileId int       `json:"qualityProfileId"`
+	Tags             []int     `json:"tags,omitempty"`
+	Path             string    `json:"path,omitempty"`
+	Added            time.Time `json:"added"`
+	Seasons          []Season  `json:"seasons,omitempty"`
+}
+
+// Season represents a season of a Sonarr series.
+type Season struct {
+	SeasonNumber int               `json:"seasonNumber"`
+	Monitored    bool              `json:"monitored"`
+	Statistics   *SeasonStatistics `json:"statistics,omitempty"`
+}
+
+// SeasonStatistics counts a season's episodes.
+type SeasonStatistics struct {
+	EpisodeCount      int `json:"episodeCount"`      // Monitored episodes that have aired, plus any with a file
+	EpisodeFileCount  int `json:"episodeFileCount"`  // Episodes with a file
+	TotalEpisodeCount int `json:"totalEpisodeCount"` // All episodes, including unaired and unmonitored ones
 }
 
 // Episode represents an episode item from Sonarr's wanted/missing or cutoff unmet endpoints.
 type Episode struct {
-	ID            int     `json:"id"`
-	Title         string  `json:"title"`
-	HasFile       bool    `json:"hasFile"`
-	Monitored     bool    `json:"monitored"`
-	SeriesTitle   string  `json:"seriesTitle,omitempty"`
-	Series        *Series `json:"series,omitempty"`
-	SeasonNumber  int     `json:"seasonNumber"`
-	EpisodeNumber int     `json:"episodeNumber"`
+	ID            int       `json:"id"`
+	Title         string    `json:"title"`
+	TvdbID        int       `json:"tvdbId"`
+	HasFile       bool      `json:"hasFile"`
+	Monitored     bool      `json:"monitored"`
+	SeriesID      int       `json:"seriesId"`
+	SeriesTitle   string    `json:"seriesTitle,omitempty"`
+	Series        *Series   `json:"series,omitempty"`
+	SeasonNumber  int       `json:"seasonNumber"`
+	EpisodeNumber int       `json:"episodeNumber"`
+	AirDateUtc    time.Time `json:"airDateUtc"`
+}
+
+// Artist represents artist info nested in Lidarr album responses.
+type Artist struct {
+	ArtistName       string    `json:"artistName"`
+	Status           string    `json:"status,omitempty"` // "continuing" or "ended"
+	QualityProfileId int       `json:"qualityProfileId"`
+	Tags             []int     `json:"tags,omitempty"`
+	Path             string    `json:"path,omitempty"`
+	Added            time.Time `json:"added"`
+}
+
+// Album represents an album item from Lidarr's wanted/missing or cutoff unmet endpoints.
+type Album struct {
+	ID          int       `json:"id"`
+	Title       string    `json:"title"`
+	Monitored   bool      `json:"monitored"`
+	ReleaseDate time.Time `json:"releaseDate"`
+	Artist      *Artist   `json:"artist,omitempty"`
+}
+
+// Author represents author info nested in Readarr book responses.
+type Author struct {
+	AuthorName       string    `json:"authorName"`
+	Status           string    `json:"status,omitempty"` // "continuing" or "ended"
+	QualityProfileId int       `json:"qualityProfileId"`
+	Tags             []int     `json:"tags,omitempty"`
+	Path             string    `json:"path,omitempty"`
+	Added            time.Time `json:"added"`
+}
+
+// Book represents a book item from Readarr's wanted/missing or cutoff unmet endpoints.
+type Book struct {
+	ID          int       `json:"id"`
+	Title       string    `json:"title"`
+	Monitored   bool      `json:"monitored"`
+	ReleaseDate time.Time `json:"releaseDate"`
+	Author      *Author   `json:"author,omitempty"`
 }
 
 // PagedResponse wraps paginated API responses.
@@ -50,22 +135,195 @@ type PagedResponse[T any] struct {
 	Records      []T `json:"records"`
 }
 
+// WantedTotals counts the items on a server's wanted lists.
+type WantedTotals struct {
+	Missing int `json:"missing"`
+	Cutoff  int `json:"cutoff"`
+}
+
+// Command statuses reported by the command endpoints.
+const (
+	CommandStatusQueued    = "queued"
+	CommandStatusStarted   = "started"
+	CommandStatusCompleted = "completed"
+	CommandStatusFailed    = "failed"
+	CommandStatusAborted   = "aborted"
+	CommandStatusCancelled = "cancelled"
+	CommandStatusOrphaned  = "orphaned"
+)
+
 // CommandResponse represents the response from command endpoints.
 type CommandResponse struct {
-	ID     int    `json:"id"`
-	Name   string `json:"name"`
-	Status string `json:"status"`
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Result  string `json:"result,omitempty"`  // "successful" or "unsuccessful" once finished
+	Message string `json:"message,omitempty"` // Last status message, e.g. the failure reason
+}
+
+// Finished reports whether the command has stopped running.
+func (c CommandResponse) Finished() bool {
+	return c.Status != CommandStatusQueued && c.Status != CommandStatusStarted
+}
+
+// Succeeded reports whether the command finished without failing.
+func (c CommandResponse) Succeeded() bool {
+	return c.Status == CommandStatusCompleted && c.Result != "unsuccessful"
+}
+
+// HistoryRecord is a history event. Only the ID field matching the server type is set.
+type HistoryRecord struct {
+	ID        int       `json:"id"`
+	EventType string    `json:"eventType"`
+	Date      time.Time `json:"date"`
+	MovieID   int       `json:"movieId,omitempty"`
+	EpisodeID int       `json:"episodeId,omitempty"`
+	AlbumID   int       `json:"albumId,omitempty"`
+	BookID    int       `json:"bookId,omitempty"`
+}
+
+// Imported reports whether the event imported a file, e.g. "downloadFolderImported" or "trackFileImported".
+func (h HistoryRecord) Imported() bool {
+	return strings.HasSuffix(h.EventType, "Imported")
+}
+
+// Deleted reports whether the event deleted a file, e.g. "movieFileDeleted" or "episodeFileDeleted".
+func (h HistoryRecord) Deleted() bool {
+	return strings.HasSuffix(h.EventType, "Deleted")
+}
+
+// ItemID returns the ID of the movie, episode, album or book the event is about.
+func (h HistoryRecord) ItemID() int {
+	for _, id := range []int{h.MovieID, h.EpisodeID, h.AlbumID, h.BookID} {
+		if id != 0 {
+			return id
+		}
+	}
+	return 0
+}
+
+// QueueItem is a download in a server's queue. Only the ID field matching the server type is set.
+type QueueItem struct {
+	ID                   int    `json:"id"`
+	Title                string `json:"title"`
+	Status               string `json:"status"`                         // e.g. "queued", "downloading", "completed" or "failed"
+	TrackedDownloadState string `json:"trackedDownloadState,omitempty"` // e.g. "downloading", "importPending" or "failedPending"
+	MovieID              int    `json:"movieId,omitempty"`
+	EpisodeID            int    `json:"episodeId,omitempty"`
+	AlbumID              int    `json:"albumId,omitempty"`
+	BookID               int    `json:"bookId,omitempty"`
+}
+
+// ItemID returns the ID of the movie, episode, album or book being downloaded.
+func (q QueueItem) ItemID() int {
+	for _, id := range []int{q.MovieID, q.EpisodeID, q.AlbumID, q.BookID} {
+		if id != 0 {
+			return id
+		}
+	}
+	return 0
+}
+
+// Active reports whether the download is still on its way, i.e. it hasn't failed.
+func (q QueueItem) Active() bool {
+	return q.Status != "failed" && q.TrackedDownloadState != "failed" && q.TrackedDownloadState != "failedPending"
 }
 
 // MediaItem is a simplified representation of a media item for search operations.
 type MediaItem struct {
-	ID             int    `json:"id"`
-	Title          string `json:"title"`                  // Formatted display title (for backwards compatibility)
-	EpisodeTitle   string `json:"episodeTitle,omitempty"` // Raw episode title (for logging)
-	Type           string `json:"type"`                   // "movie" or "episode"
-	Year           int    `json:"year,omitempty"`
-	SeriesTitle    string `json:"seriesTitle,omitempty"`
-	SeasonNumber   int    `json:"seasonNumber,omitempty"`
-	EpisodeNumber  int    `json:"episodeNumber,omitempty"`
-	QualityProfile string `json:"qualityProfile,omitempty"`
+	ID             int       `json:"id"`
+	Title          string    `json:"title"`                  // Formatted display title (for backwards compatibility)
+	EpisodeTitle   string    `json:"episodeTitle,omitempty"` // Raw episode title (for logging)
+	Type           string    `json:"type"`                   // "movie", "episode", "album" or "book"
+	Year           int       `json:"year,omitempty"`         // Release year; the series' first year for episodes
+	SeriesID       int       `json:"seriesId,omitempty"`
+	SeriesTitle    string    `json:"seriesTitle,omitempty"`
+	SeriesTvdbID   int       `json:"seriesTvdbId,omitempty"`
+	SeasonNumber   int       `json:"seasonNumber,omitempty"`
+	EpisodeNumber  int       `json:"episodeNumber,omitempty"`
+	TmdbID         int       `json:"tmdbId,omitempty"`     // For movies
+	ImdbID         string    `json:"imdbId,omitempty"`     // For movies
+	TvdbID         int       `json:"tvdbId,omitempty"`     // For episodes
+	ArtistName     string    `json:"artistName,omitempty"` // For albums
+	AuthorName     string    `json:"authorName,omitempty"` // For books
+	QualityProfile string    `json:"qualityProfile,omitempty"`
+	Monitored      bool      `json:"monitored"`
+	Tags           []string  `json:"tags,omitempty"`        // Labels of the movie's, series', artist's or author's tags
+	Path           string    `json:"path,omitempty"`        // Folder of the movie, series, artist or author
+	Status         string    `json:"status,omitempty"`      // Status of the series, artist or author, e.g. "continuing" or "ended"
+	ReleaseDate    time.Time `json:"releaseDate"`           // Digital/physical release for movies, air date for episodes, release date for albums and books (zero if unknown)
+	Unavailable    bool      `json:"unavailable,omitempty"` // Movies Radarr doesn't consider available yet, e.g. only announced or in cinemas
+	Added          time.Time `json:"added"`                 // When the movie, series, artist or author was added to the server (zero if unknown)
+}
+
+// ExternalID identifies the item independently of the server it was read from, using its TMDB or
+// IMDb ID for movies and its TVDB ID for episodes, e.g. "tmdb:603" or "tvdb:349232".
+// Episodes without their own TVDB ID fall back to the series' ID and the episode number.
+// It returns "" if the server didn't report any, as for albums and books.
+func (m MediaItem) ExternalID() string {
+	switch {
+	case m.TmdbID != 0:
+		return fmt.Sprintf("tmdb:%d", m.TmdbID)
+	case m.ImdbID != "":
+		return "imdb:" + m.ImdbID
+	case m.TvdbID != 0:
+		return fmt.Sprintf("tvdb:%d", m.TvdbID)
+	case m.SeriesTvdbID != 0:
+		return fmt.Sprintf("tvdb:%d:S%02dE%02d", m.SeriesTvdbID, m.SeasonNumber, m.EpisodeNumber)
+	}
+	return ""
+}
+
+// Overseerr and Jellyseerr request and media statuses.
+const (
+	RequestStatusApproved = 2 // The request was approved and sent to Radarr or Sonarr
+	MediaStatusAvailable  = 5 // Every requested file is available
+)
+
+// MediaRequest is a request for a movie or series made in Overseerr or Jellyseerr.
+type MediaRequest struct {
+	ID          int               `json:"id"`
+	Status      int               `json:"status"` // 1 pending approval, 2 approved, 3 declined
+	Is4K        bool              `json:"is4k"`
+	Media       RequestedMedia    `json:"media"`
+	Seasons     []RequestedSeason `json:"seasons,omitempty"` // Seasons requested of a series
+	RequestedBy RequestUser       `json:"requestedBy"`
+}
+
+// RequestedMedia is the movie or series a request is for.
+type RequestedMedia struct {
+	MediaType string `json:"mediaType"` // "movie" or "tv"
+	TmdbID    int    `json:"tmdbId"`
+	TvdbID    int    `json:"tvdbId,omitempty"` // For series
+	Status    int    `json:"status"`
+	Status4K  int    `json:"status4k"`
+}
+
+// RequestedSeason is one season of a series request.
+type RequestedSeason struct {
+	SeasonNumber int `json:"seasonNumber"`
+}
+
+// RequestUser is the user who made a request.
+type RequestUser struct {
+	DisplayName string `json:"displayName"`
+}
+
+// Outstanding reports whether the request was approved but its media is not available yet.
+func (r MediaRequest) Outstanding() bool {
+	status := r.Media.Status
+	if r.Is4K {
+		status = r.Media.Status4K
+	}
+	return r.Status == RequestStatusApproved && status != MediaStatusAvailable
+}
+
+// RequestPage is a page of Overseerr or Jellyseerr requests.
+type RequestPage struct {
+	PageInfo struct {
+		Pages   int `json:"pages"`
+		Page    int `json:"page"`
+		Results int `json:"results"` // Total number of requests matching the filter
+	} `json:"pageInfo"`
+	Results []MediaRequest `json:"results"`
 }
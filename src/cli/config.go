@@ -8,6 +8,7 @@ import (
 
 	"github.com/edrobertsrayne/janitarr/src/cli/forms"
 	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/services"
 	"github.com/spf13/cobra"
 )
 
@@ -55,6 +56,7 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Println(formatConfigTable(&appConfig))
+	fmt.Println(keyValue("API Key", db.GetAPIKey()))
 	return nil
 }
 
@@ -68,6 +70,39 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 	}
 	defer db.Close()
 
+	// Credentials are stored outside the application config
+	switch strings.ToLower(key) {
+	case "auth.password":
+		if err := db.SetAuthPassword(value); err != nil {
+			return fmt.Errorf("invalid value for auth.password: %w", err)
+		}
+		if err := db.DeleteAllSessions(); err != nil {
+			return fmt.Errorf("failed to log out existing sessions: %w", err)
+		}
+		fmt.Println(success("Password updated. Existing sessions have been logged out."))
+		return nil
+	case "auth.apikey":
+		if value != "regenerate" {
+			return fmt.Errorf("invalid value for auth.apikey: only 'regenerate' is supported")
+		}
+		apiKey, err := db.RegenerateAPIKey()
+		if err != nil {
+			return fmt.Errorf("failed to regenerate API key: %w", err)
+		}
+		fmt.Println(success("New API key: " + apiKey))
+		return nil
+	case "requests.apikey":
+		if err := db.SetRequestsAPIKey(strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("failed to set requests API key: %w", err)
+		}
+		if strings.TrimSpace(value) == "" {
+			fmt.Println(success("Requests API key removed."))
+		} else {
+			fmt.Println(success("Requests API key updated."))
+		}
+		return nil
+	}
+
 	appConfig := db.GetAppConfig()
 
 	switch strings.ToLower(key) {
@@ -83,6 +118,17 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("invalid value for schedule.enabled: must be 'true' or 'false'")
 		}
 		appConfig.Schedule.Enabled = boolVal
+	case "schedule.cron":
+		appConfig.Schedule.Cron = strings.TrimSpace(value)
+	case "schedule.windows":
+		appConfig.Schedule.Windows = strings.TrimSpace(value)
+	case "schedule.blackouts":
+		appConfig.Schedule.Blackouts = strings.TrimSpace(value)
+	case "schedule.catchup":
+		if !database.IsValidCatchUpPolicy(value) {
+			return fmt.Errorf("invalid value for schedule.catchup: must be 'run' or 'skip'")
+		}
+		appConfig.Schedule.CatchUp = database.CatchUpPolicy(value)
 	case "limits.missing.movies":
 		intVal, parseErr := strconv.Atoi(value)
 		if parseErr != nil || intVal < 0 {
@@ -107,10 +153,99 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("invalid value for limits.cutoff.episodes: must be a non-negative integer")
 		}
 		appConfig.SearchLimits.CutoffEpisodesLimit = intVal
+	case "search.cooldown":
+		intVal, parseErr := strconv.Atoi(value)
+		if parseErr != nil || intVal < 0 {
+			return fmt.Errorf("invalid value for search.cooldown: must be a non-negative integer")
+		}
+		appConfig.Search.CooldownHours = intVal
+	case "search.strategy":
+		if !database.IsValidSelectionMode(value) {
+			return fmt.Errorf("invalid value for search.strategy: must be one of %s", selectionModeList())
+		}
+		appConfig.Search.Strategy = database.SelectionMode(value)
+	case "search.seasonpack":
+		intVal, parseErr := strconv.Atoi(value)
+		if parseErr != nil || intVal < 0 || intVal > 100 {
+			return fmt.Errorf("invalid value for search.seasonPack: must be a percentage from 0 to 100")
+		}
+		appConfig.Search.SeasonPackPercent = intVal
+	case "search.maxqueue":
+		intVal, parseErr := strconv.Atoi(value)
+		if parseErr != nil || intVal < 0 {
+			return fmt.Errorf("invalid value for search.maxQueue: must be a non-negative integer")
+		}
+		appConfig.Search.MaxQueueSize = intVal
+	case "search.giveupattempts":
+		intVal, parseErr := strconv.Atoi(value)
+		if parseErr != nil || intVal < 0 {
+			return fmt.Errorf("invalid value for search.giveUpAttempts: must be a non-negative integer")
+		}
+		appConfig.Search.GiveUpAttempts = intVal
+	case "search.giveuppolicy":
+		if !database.IsValidGiveUpPolicy(value) {
+			return fmt.Errorf("invalid value for search.giveUpPolicy: must be one of %s", giveUpPolicyList())
+		}
+		appConfig.Search.GiveUpPolicy = database.GiveUpPolicy(value)
+	case "search.giveupdays":
+		intVal, parseErr := strconv.Atoi(value)
+		if parseErr != nil || intVal < 1 {
+			return fmt.Errorf("invalid value for search.giveUpDays: must be a positive integer")
+		}
+		appConfig.Search.GiveUpDays = intVal
+	case "search.giveuptag":
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("invalid value for search.giveUpTag: must not be empty")
+		}
+		appConfig.Search.GiveUpTagLabel = strings.TrimSpace(value)
+	case "detection.fullrefresh":
+		intVal, parseErr := strconv.Atoi(value)
+		if parseErr != nil || intVal < 0 {
+			return fmt.Errorf("invalid value for detection.fullRefresh: must be a non-negative integer")
+		}
+		appConfig.Detection.FullRefreshHours = intVal
+	case "detection.skipunavailable":
+		boolVal, parseErr := strconv.ParseBool(value)
+		if parseErr != nil {
+			return fmt.Errorf("invalid value for detection.skipUnavailable: must be 'true' or 'false'")
+		}
+		appConfig.Detection.SkipUnavailable = boolVal
+	case "detection.releasegrace":
+		intVal, parseErr := strconv.Atoi(value)
+		if parseErr != nil || intVal < 0 {
+			return fmt.Errorf("invalid value for detection.releaseGrace: must be a non-negative integer")
+		}
+		appConfig.Detection.ReleaseGraceHours = intVal
+	case "auth.mode":
+		if !database.IsValidAuthMode(value) {
+			return fmt.Errorf("invalid value for auth.mode: must be 'disabled', 'enabled' or 'disabled-for-local'")
+		}
+		if database.AuthMode(value) != database.AuthDisabled && !db.HasAuthPassword() {
+			return fmt.Errorf("set a password with 'janitarr config set auth.password <password>' before enabling authentication")
+		}
+		appConfig.Auth.Mode = database.AuthMode(value)
+	case "auth.username":
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("invalid value for auth.username: must not be empty")
+		}
+		appConfig.Auth.Username = strings.TrimSpace(value)
+	case "tracing.exporter":
+		if !database.IsValidTracingExporter(value) {
+			return fmt.Errorf("invalid value for tracing.exporter: must be 'none', 'otlp', 'stdout' or 'file'")
+		}
+		appConfig.Tracing.Exporter = database.TracingExporter(value)
+	case "tracing.endpoint":
+		appConfig.Tracing.Endpoint = strings.TrimSpace(value)
+	case "requests.url":
+		appConfig.Requests.URL = strings.TrimSpace(value)
 	default:
 		return fmt.Errorf("unknown configuration key: %s", key)
 	}
 
+	if err := services.ValidateScheduleConfig(appConfig.Schedule); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
 	if err := db.SetAppConfig(appConfig); err != nil {
 		return fmt.Errorf("failed to set app config: %w", err)
 	}
@@ -147,6 +282,10 @@ func runConfigInteractive(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if updatedConfig.Auth.Mode != database.AuthDisabled && !db.HasAuthPassword() {
+		return fmt.Errorf("set a password with 'janitarr config set auth.password <password>' before enabling authentication")
+	}
+
 	// Save updated configuration
 	if err := db.SetAppConfig(*updatedConfig); err != nil {
 		return fmt.Errorf("failed to save configuration: %w", err)
@@ -160,3 +299,21 @@ func runConfigInteractive(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// selectionModeList returns the supported selection modes as a comma-separated string.
+func selectionModeList() string {
+	modes := make([]string, len(database.SelectionModes))
+	for i, m := range database.SelectionModes {
+		modes[i] = string(m)
+	}
+	return strings.Join(modes, ", ")
+}
+
+// giveUpPolicyList returns the supported give-up policies as a comma-separated string.
+func giveUpPolicyList() string {
+	policies := make([]string, len(database.GiveUpPolicies))
+	for i, p := range database.GiveUpPolicies {
+		policies[i] = string(p)
+	}
+	return strings.Join(policies, ", ")
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/services"
+	"github.com/spf13/cobra"
+)
+
+var excludeCmd = &cobra.Command{
+	Use:   "exclude",
+	Short: "Manage items that are never searched automatically",
+	Long: `Manage exclusions: items kept monitored on a server that Janitarr must never search,
+for example while waiting on a specific release group.`,
+}
+
+var excludeAddCmd = &cobra.Command{
+	Use:   "add <server> <id>",
+	Short: "Exclude an item, series, TMDB or TVDB ID on a server",
+	Long: `Exclude an item from automatic searches on a server, given by name or ID. Excluding something
+that is already excluded updates its title, reason and expiry.
+
+Kinds:
+  item    A movie, episode, album or book by its ID on the server (default)
+  series  Every episode of a Sonarr series by its series ID
+  tmdb    A Radarr movie by its TMDB ID
+  tvdb    Every episode of a Sonarr series by its TVDB ID`,
+	Args: cobra.ExactArgs(2),
+	RunE: runExcludeAdd,
+}
+
+var excludeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List exclusions",
+	RunE:  runExcludeList,
+}
+
+var excludeRemoveCmd = &cobra.Command{
+	Use:   "remove <exclusion-id>",
+	Short: "Remove an exclusion",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runExcludeRemove,
+}
+
+func init() {
+	excludeCmd.AddCommand(excludeAddCmd)
+	excludeCmd.AddCommand(excludeListCmd)
+	excludeCmd.AddCommand(excludeRemoveCmd)
+
+	excludeAddCmd.Flags().String("kind", string(database.ExcludeItem), "What the ID identifies (item/series/tmdb/tvdb)")
+	excludeAddCmd.Flags().String("title", "", "Title to show in the exclusion list")
+	excludeAddCmd.Flags().String("reason", "", "Why the item is excluded")
+	excludeAddCmd.Flags().String("expires", "", "When the exclusion ends: a number of days (30d), a duration (12h) or a date (2025-06-01)")
+	excludeListCmd.Flags().Bool("json", false, "Output list as JSON")
+}
+
+func runExcludeAdd(cmd *cobra.Command, args []string) error {
+	targetID, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid ID: %s", args[1])
+	}
+	expires, _ := cmd.Flags().GetString("expires")
+	expiresAt, err := parseExpiry(expires, time.Now())
+	if err != nil {
+		return err
+	}
+
+	db, err := database.New(dbPath, "./data/.janitarr.key")
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	kind, _ := cmd.Flags().GetString("kind")
+	title, _ := cmd.Flags().GetString("title")
+	reason, _ := cmd.Flags().GetString("reason")
+	exclusion, err := services.NewExclusionManager(db).Add(args[0], strings.ToLower(kind), targetID, title, reason, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to add exclusion: %w", err)
+	}
+
+	fmt.Println(success(fmt.Sprintf("Excluded %s %d on '%s' %s (ID: %s)", exclusion.Kind, exclusion.TargetID, exclusion.ServerName,
+		formatExclusionExpiry(exclusion.ExpiresAt), exclusion.ID)))
+	return nil
+}
+
+func runExcludeList(cmd *cobra.Command, args []string) error {
+	db, err := database.New(dbPath, "./data/.janitarr.key")
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	exclusions, err := services.NewExclusionManager(db).List()
+	if err != nil {
+		return fmt.Errorf("failed to list exclusions: %w", err)
+	}
+
+	outputJSON, _ := cmd.Flags().GetBool("json")
+	if outputJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(exclusions)
+	}
+
+	fmt.Println(formatExclusionTable(exclusions))
+	return nil
+}
+
+func runExcludeRemove(cmd *cobra.Command, args []string) error {
+	db, err := database.New(dbPath, "./data/.janitarr.key")
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	if err := services.NewExclusionManager(db).Remove(args[0]); err != nil {
+		return fmt.Errorf("failed to remove exclusion: %w", err)
+	}
+
+	fmt.Println(success(fmt.Sprintf("Removed exclusion %s", args[0])))
+	return nil
+}
+
+// parseExpiry parses when an exclusion ends: a number of days such as "30d", a duration such as
+// "12h", or a local date such as "2025-06-01". An empty value never expires.
+func parseExpiry(value string, now time.Time) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var expiresAt time.Time
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid expiry %q: days must be a positive number", value)
+		}
+		expiresAt = now.AddDate(0, 0, n)
+	} else if d, err := time.ParseDuration(value); err == nil {
+		expiresAt = now.Add(d)
+	} else if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		expiresAt = date
+	} else {
+		return nil, fmt.Errorf("invalid expiry %q: use days (30d), a duration (12h) or a date (2025-06-01)", value)
+	}
+	return &expiresAt, nil
+}
@@ -76,26 +76,162 @@ func formatServerTable(servers []services.ServerInfo) string {
 		}
 	}
 
+	limitsWidth := 6 // "Limits"
+	for _, s := range servers {
+		if l := len(formatServerLimits(s.Limits)); l > limitsWidth {
+			limitsWidth = l
+		}
+	}
+
 	// Header
-	sb.WriteString(fmt.Sprintf("% -*s  %-6s  %-*s  %s\n", nameWidth, "Name", "Type", urlWidth, "URL", "Enabled"))
-	sb.WriteString(fmt.Sprintf("%s  %s  %s  %s\n", strings.Repeat("-", nameWidth), strings.Repeat("-", 6), strings.Repeat("-", urlWidth), strings.Repeat("-", 7)))
+	sb.WriteString(fmt.Sprintf("% -*s  %-7s  %-*s  %-*s  %-9s  %s\n", nameWidth, "Name", "Type", urlWidth, "URL", limitsWidth, "Limits", "Enabled", "Health"))
+	sb.WriteString(fmt.Sprintf("%s  %s  %s  %s  %s  %s\n", strings.Repeat("-", nameWidth), strings.Repeat("-", 7), strings.Repeat("-", urlWidth), strings.Repeat("-", limitsWidth), strings.Repeat("-", 9), strings.Repeat("-", 6)))
 
 	// Rows
 	for _, s := range servers {
 		name := s.Name
 		serverType := strings.Title(s.Type) // Capitalize type for display
 		url := s.URL
+		// Pad before colouring so the escape codes don't upset the column widths
 		enabledText := ""
 		if s.Enabled {
-			enabledText = success("Yes")
+			enabledText = success(fmt.Sprintf("%-7s", "Yes"))
 		} else {
-			enabledText = warning("No")
+			enabledText = warning(fmt.Sprintf("%-7s", "No"))
 		}
-		sb.WriteString(fmt.Sprintf("% -*s  %-6s  %-*s  %s\n", nameWidth, name, serverType, urlWidth, url, enabledText))
+		sb.WriteString(fmt.Sprintf("% -*s  %-7s  %-*s  %-*s  %s  %s\n", nameWidth, name, serverType, urlWidth, url, limitsWidth, formatServerLimits(s.Limits), enabledText, formatServerHealth(s.Health)))
 	}
 	return sb.String()
 }
 
+// formatServerPairTable formats paired servers as a table.
+func formatServerPairTable(pairs []services.ServerPairInfo) string {
+	if len(pairs) == 0 {
+		return info("No servers paired.")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(header("Paired Servers") + "\n")
+	sb.WriteString("\n")
+
+	primaryWidth, secondaryWidth := 7, 9 // "Primary", "Secondary"
+	for _, p := range pairs {
+		primaryWidth = max(primaryWidth, len(p.PrimaryName))
+		secondaryWidth = max(secondaryWidth, len(p.SecondaryName))
+	}
+
+	sb.WriteString(fmt.Sprintf("%-*s  %-*s  %-7s  %s\n", primaryWidth, "Primary", secondaryWidth, "Secondary", "Type", "Policy"))
+	sb.WriteString(fmt.Sprintf("%s  %s  %s  %s\n", strings.Repeat("-", primaryWidth), strings.Repeat("-", secondaryWidth), strings.Repeat("-", 7), strings.Repeat("-", 14)))
+	for _, p := range pairs {
+		sb.WriteString(fmt.Sprintf("%-*s  %-*s  %-7s  %s\n", primaryWidth, p.PrimaryName, secondaryWidth, p.SecondaryName, strings.Title(p.Type), p.Policy))
+	}
+	return sb.String()
+}
+
+func formatGivenUpTable(items []services.GivenUpInfo) string {
+	if len(items) == 0 {
+		return info("No items given up on.")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(header("Given Up") + "\n")
+	sb.WriteString("\n")
+
+	serverWidth, titleWidth := 6, 5 // "Server", "Title"
+	for _, item := range items {
+		serverWidth = max(serverWidth, len(item.ServerName))
+		titleWidth = max(titleWidth, len(item.Title))
+	}
+
+	sb.WriteString(fmt.Sprintf("%-*s  %-7s  %-8s  %-*s  %-9s  %-8s  %s\n", serverWidth, "Server", "Item", "Category", titleWidth, "Title", "Policy", "Searches", "Next Search"))
+	sb.WriteString(fmt.Sprintf("%s  %s  %s  %s  %s  %s  %s\n", strings.Repeat("-", serverWidth), strings.Repeat("-", 7), strings.Repeat("-", 8),
+		strings.Repeat("-", titleWidth), strings.Repeat("-", 9), strings.Repeat("-", 8), strings.Repeat("-", 16)))
+	for _, item := range items {
+		next := "Never"
+		if item.RetryAt != nil {
+			next = item.RetryAt.Local().Format("2006-01-02 15:04")
+		}
+		sb.WriteString(fmt.Sprintf("%-*s  %-7d  %-8s  %-*s  %-9s  %-8d  %s\n", serverWidth, item.ServerName, item.ItemID, item.Category,
+			titleWidth, item.Title, item.Policy, item.Attempts, next))
+	}
+	return sb.String()
+}
+
+func formatExclusionTable(exclusions []services.ExclusionInfo) string {
+	if len(exclusions) == 0 {
+		return info("No exclusions.")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(header("Exclusions") + "\n")
+	sb.WriteString("\n")
+
+	serverWidth, titleWidth := 6, 5 // "Server", "Title"
+	for _, e := range exclusions {
+		serverWidth = max(serverWidth, len(e.ServerName))
+		titleWidth = max(titleWidth, len(e.Title))
+	}
+
+	sb.WriteString(fmt.Sprintf("%-36s  %-*s  %-6s  %-8s  %-*s  %s\n", "ID", serverWidth, "Server", "Kind", "Target", titleWidth, "Title", "Expires"))
+	sb.WriteString(fmt.Sprintf("%s  %s  %s  %s  %s  %s\n", strings.Repeat("-", 36), strings.Repeat("-", serverWidth), strings.Repeat("-", 6),
+		strings.Repeat("-", 8), strings.Repeat("-", titleWidth), strings.Repeat("-", 16)))
+	for _, e := range exclusions {
+		expires := "Never"
+		if e.ExpiresAt != nil {
+			expires = e.ExpiresAt.Local().Format("2006-01-02 15:04")
+		}
+		sb.WriteString(fmt.Sprintf("%-36s  %-*s  %-6s  %-8d  %-*s  %s\n", e.ID, serverWidth, e.ServerName, e.Kind, e.TargetID, titleWidth, e.Title, expires))
+		if e.Reason != "" {
+			sb.WriteString(fmt.Sprintf("%36s  %s\n", "", "Reason: "+e.Reason))
+		}
+	}
+	return sb.String()
+}
+
+// formatExclusionExpiry describes when an exclusion ends, e.g. "until 2025-06-01 12:00" or "forever".
+func formatExclusionExpiry(expiresAt *time.Time) string {
+	if expiresAt == nil {
+		return "forever"
+	}
+	return "until " + expiresAt.Local().Format("2006-01-02 15:04")
+}
+
+// formatServerHealth describes a server's health for the server table.
+func formatServerHealth(h database.ServerHealth) string {
+	switch h.State {
+	case database.ServerCircuitOpen:
+		until := ""
+		if h.OpenUntil != nil {
+			until = " until " + h.OpenUntil.Local().Format("2006-01-02 15:04")
+		}
+		return errorMsg(fmt.Sprintf("Skipped%s (%d failures: %s)", until, h.ConsecutiveFailures, h.LastError))
+	case database.ServerDegraded:
+		return warning(fmt.Sprintf("Degraded (%d failures: %s)", h.ConsecutiveFailures, h.LastError))
+	default:
+		return success("Healthy")
+	}
+}
+
+// formatServerLimits summarises a server's allocation overrides, or "-" when none are set
+func formatServerLimits(l database.ServerLimits) string {
+	var parts []string
+	if l.MaxMissing != nil {
+		parts = append(parts, fmt.Sprintf("missing<=%d", *l.MaxMissing))
+	}
+	if l.NeverCutoff {
+		parts = append(parts, "no cutoff")
+	} else if l.MaxCutoff != nil {
+		parts = append(parts, fmt.Sprintf("cutoff<=%d", *l.MaxCutoff))
+	}
+	if w := l.EffectiveWeight(); w != 1 {
+		parts = append(parts, fmt.Sprintf("weight %g", w))
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ", ")
+}
+
 // formatLogTable formats a slice of logger.LogEntry into a human-readable table.
 func formatLogTable(logs []logger.LogEntry) string {
 	if len(logs) == 0 {
@@ -179,6 +315,10 @@ func formatConfigTable(config *database.AppConfig) string {
 	}
 	sb.WriteString(keyValue("Enabled", enabledText) + "\n")
 	sb.WriteString(keyValue("Interval", fmt.Sprintf("%d hours", config.Schedule.IntervalHours)) + "\n")
+	sb.WriteString(keyValue("Cron", formatOptional(config.Schedule.Cron)) + "\n")
+	sb.WriteString(keyValue("Windows", formatOptional(config.Schedule.Windows)) + "\n")
+	sb.WriteString(keyValue("Blackouts", formatOptional(config.Schedule.Blackouts)) + "\n")
+	sb.WriteString(keyValue("Catch Up", string(config.Schedule.CatchUp)) + "\n")
 	sb.WriteString("\n")
 
 	sb.WriteString(colorBold + "Search Limits:" + colorReset + "\n")
@@ -186,10 +326,105 @@ func formatConfigTable(config *database.AppConfig) string {
 	sb.WriteString(keyValue("Missing Episodes", formatLimit(config.SearchLimits.MissingEpisodesLimit)) + "\n")
 	sb.WriteString(keyValue("Cutoff Movies", formatLimit(config.SearchLimits.CutoffMoviesLimit)) + "\n")
 	sb.WriteString(keyValue("Cutoff Episodes", formatLimit(config.SearchLimits.CutoffEpisodesLimit)) + "\n")
+	sb.WriteString("\n")
+
+	sb.WriteString(colorBold + "Search:" + colorReset + "\n")
+	sb.WriteString(keyValue("Cooldown", formatCooldown(config.Search.CooldownHours)) + "\n")
+	sb.WriteString(keyValue("Strategy", string(config.Search.Strategy)) + "\n")
+	sb.WriteString(keyValue("Season Packs", formatSeasonPack(config.Search.SeasonPackPercent)) + "\n")
+	sb.WriteString(keyValue("Max Queue", formatMaxQueue(config.Search.MaxQueueSize)) + "\n")
+	sb.WriteString(keyValue("Give Up", formatGiveUp(config.Search)) + "\n")
+	sb.WriteString("\n")
+
+	sb.WriteString(colorBold + "Detection:" + colorReset + "\n")
+	sb.WriteString(keyValue("Full Refresh", formatFullRefresh(config.Detection.FullRefreshHours)) + "\n")
+	sb.WriteString(keyValue("Skip Unavailable", formatBool(config.Detection.SkipUnavailable)) + "\n")
+	sb.WriteString(keyValue("Release Grace", formatReleaseGrace(config.Detection.ReleaseGraceHours)) + "\n")
+	sb.WriteString("\n")
+
+	sb.WriteString(colorBold + "Authentication:" + colorReset + "\n")
+	sb.WriteString(keyValue("Mode", string(config.Auth.Mode)) + "\n")
+	sb.WriteString(keyValue("Username", config.Auth.Username) + "\n")
+	sb.WriteString("\n")
+
+	sb.WriteString(colorBold + "Tracing:" + colorReset + "\n")
+	sb.WriteString(keyValue("Exporter", string(config.Tracing.Exporter)) + "\n")
+	sb.WriteString(keyValue("Endpoint", formatOptional(config.Tracing.Endpoint)) + "\n")
+	sb.WriteString("\n")
+
+	sb.WriteString(colorBold + "Requests:" + colorReset + "\n")
+	sb.WriteString(keyValue("URL", formatOptional(config.Requests.URL)) + "\n")
+	sb.WriteString(keyValue("API Key", formatBool(config.Requests.HasAPIKey)) + "\n")
 
 	return sb.String()
 }
 
+// scheduleSummary describes when the scheduler runs, e.g. "cron: 0 2,14 * * *" or "interval: 6 hours".
+func scheduleSummary(schedule database.ScheduleConfig) string {
+	if schedule.Cron != "" {
+		return fmt.Sprintf("cron: %s", schedule.Cron)
+	}
+	return fmt.Sprintf("interval: %d hours", schedule.IntervalHours)
+}
+
+func formatOptional(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func formatFullRefresh(hours int) string {
+	if hours == 0 {
+		return "Every cycle"
+	}
+	return fmt.Sprintf("Every %d hours", hours)
+}
+
+func formatReleaseGrace(hours int) string {
+	if hours == 0 {
+		return "None"
+	}
+	return fmt.Sprintf("%d hours after release", hours)
+}
+
+func formatMaxQueue(size int) string {
+	if size == 0 {
+		return "No limit"
+	}
+	return fmt.Sprintf("Skip servers with more than %d downloads queued", size)
+}
+
+func formatGiveUp(search database.SearchConfig) string {
+	if search.GiveUpAttempts == 0 {
+		return "Never"
+	}
+	switch search.GiveUpPolicy {
+	case database.GiveUpSnooze:
+		return fmt.Sprintf("After %d searches, search every %d days", search.GiveUpAttempts, search.GiveUpDays)
+	case database.GiveUpTag:
+		return fmt.Sprintf("After %d searches, tag '%s' and stop searching", search.GiveUpAttempts, search.GiveUpTagLabel)
+	case database.GiveUpUnmonitor:
+		return fmt.Sprintf("After %d searches, unmonitor and stop searching", search.GiveUpAttempts)
+	default:
+		return fmt.Sprintf("After %d searches, back off up to %d days", search.GiveUpAttempts, search.GiveUpDays)
+	}
+}
+
+func formatSeasonPack(percent int) string {
+	if percent == 0 {
+		return warning("Disabled")
+	}
+	return fmt.Sprintf("When %d%% of a season is missing", percent)
+}
+
+func formatCooldown(hours int) string {
+	if hours == 0 {
+		return warning("Disabled")
+	}
+	return fmt.Sprintf("%d hours", hours)
+}
+
 func formatLimit(limit int) string {
 	if limit == 0 {
 		return warning("Disabled")
@@ -3,9 +3,11 @@ package forms
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/services"
 )
 
 // ConfigForm displays an interactive form for editing application configuration
@@ -24,6 +26,7 @@ func ConfigForm(current database.AppConfig) (*database.AppConfig, error) {
 	missingEpisodesStr := strconv.Itoa(current.SearchLimits.MissingEpisodesLimit)
 	cutoffMoviesStr := strconv.Itoa(current.SearchLimits.CutoffMoviesLimit)
 	cutoffEpisodesStr := strconv.Itoa(current.SearchLimits.CutoffEpisodesLimit)
+	cooldownStr := strconv.Itoa(current.Search.CooldownHours)
 	retentionDaysStr := strconv.Itoa(current.Logs.RetentionDays)
 
 	// Validator for interval (1-168 hours = 1 week)
@@ -38,6 +41,19 @@ func ConfigForm(current database.AppConfig) (*database.AppConfig, error) {
 		return nil
 	}
 
+	// Validators for optional cron expression and time windows (empty clears them)
+	validateCron := func(s string) error {
+		if s == "" {
+			return nil
+		}
+		_, err := services.ParseCron(s)
+		return err
+	}
+	validateWindows := func(s string) error {
+		_, err := services.ParseTimeWindows(s)
+		return err
+	}
+
 	// Validator for search limits (0-1000)
 	validateLimit := func(s string) error {
 		val, err := strconv.Atoi(s)
@@ -50,6 +66,18 @@ func ConfigForm(current database.AppConfig) (*database.AppConfig, error) {
 		return nil
 	}
 
+	// Validator for search cooldown (0-720 hours = 30 days)
+	validateCooldown := func(s string) error {
+		val, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("must be a number")
+		}
+		if val < 0 || val > 720 {
+			return fmt.Errorf("must be between 0 and 720 hours")
+		}
+		return nil
+	}
+
 	// Validator for retention days (7-90)
 	validateRetention := func(s string) error {
 		val, err := strconv.Atoi(s)
@@ -82,6 +110,33 @@ func ConfigForm(current database.AppConfig) (*database.AppConfig, error) {
 				Description("How often to run automation (1-168 hours)").
 				Value(&intervalStr).
 				Validate(validateInterval),
+
+			huh.NewInput().
+				Title("Cron Expression").
+				Description("Overrides the interval when set, e.g. '0 2,14 * * *' (blank = use interval)").
+				Value(&result.Schedule.Cron).
+				Validate(validateCron),
+
+			huh.NewInput().
+				Title("Allowed Windows").
+				Description("Only start runs in these times, e.g. '01:00-06:00,22:00-23:30' (blank = any time)").
+				Value(&result.Schedule.Windows).
+				Validate(validateWindows),
+
+			huh.NewInput().
+				Title("Blackout Periods").
+				Description("Never start runs in these times, e.g. '12:00-18:00' (blank = none)").
+				Value(&result.Schedule.Blackouts).
+				Validate(validateWindows),
+
+			huh.NewSelect[database.CatchUpPolicy]().
+				Title("Missed Runs").
+				Description("What to do on startup if a scheduled run was missed").
+				Options(
+					huh.NewOption("Run immediately", database.CatchUpRun),
+					huh.NewOption("Skip to the next scheduled run", database.CatchUpSkip),
+				).
+				Value(&result.Schedule.CatchUp),
 		),
 
 		huh.NewGroup(
@@ -114,6 +169,55 @@ func ConfigForm(current database.AppConfig) (*database.AppConfig, error) {
 				Validate(validateLimit),
 		),
 
+		huh.NewGroup(
+			huh.NewNote().
+				Title("Search Behaviour").
+				Description("Control how items are picked each cycle"),
+
+			huh.NewInput().
+				Title("Search Cooldown (hours)").
+				Description("Skip items searched within this many hours (0-720, 0=disabled)").
+				Value(&cooldownStr).
+				Validate(validateCooldown),
+
+			huh.NewSelect[database.SelectionMode]().
+				Title("Selection Strategy").
+				Description("Which items to search first when there are more than the limits allow").
+				Options(
+					huh.NewOption("Oldest searched first", database.SelectionOldestSearchedFirst),
+					huh.NewOption("Random", database.SelectionRandom),
+					huh.NewOption("Newest release first", database.SelectionNewestReleaseFirst),
+					huh.NewOption("Alphabetical", database.SelectionAlphabetical),
+					huh.NewOption("Least recently added", database.SelectionLeastRecentlyAdded),
+				).
+				Value(&result.Search.Strategy),
+		),
+
+		huh.NewGroup(
+			huh.NewNote().
+				Title("Authentication").
+				Description("Protect the web UI and API (set the password with 'config set auth.password')"),
+
+			huh.NewSelect[database.AuthMode]().
+				Title("Authentication Mode").
+				Options(
+					huh.NewOption("Disabled", database.AuthDisabled),
+					huh.NewOption("Enabled", database.AuthEnabled),
+					huh.NewOption("Disabled for local addresses", database.AuthDisabledForLocal),
+				).
+				Value(&result.Auth.Mode),
+
+			huh.NewInput().
+				Title("Username").
+				Value(&result.Auth.Username).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return fmt.Errorf("must not be empty")
+					}
+					return nil
+				}),
+		),
+
 		huh.NewGroup(
 			huh.NewNote().
 				Title("Log Retention").
@@ -137,6 +241,13 @@ func ConfigForm(current database.AppConfig) (*database.AppConfig, error) {
 
 	interval, _ := strconv.Atoi(intervalStr)
 	result.Schedule.IntervalHours = interval
+	result.Schedule.Cron = strings.TrimSpace(result.Schedule.Cron)
+	result.Schedule.Windows = strings.TrimSpace(result.Schedule.Windows)
+	result.Schedule.Blackouts = strings.TrimSpace(result.Schedule.Blackouts)
+
+	if err := services.ValidateScheduleConfig(result.Schedule); err != nil {
+		return nil, fmt.Errorf("invalid schedule: %w", err)
+	}
 
 	missingMovies, _ := strconv.Atoi(missingMoviesStr)
 	result.SearchLimits.MissingMoviesLimit = missingMovies
@@ -150,8 +261,13 @@ func ConfigForm(current database.AppConfig) (*database.AppConfig, error) {
 	cutoffEpisodes, _ := strconv.Atoi(cutoffEpisodesStr)
 	result.SearchLimits.CutoffEpisodesLimit = cutoffEpisodes
 
+	cooldown, _ := strconv.Atoi(cooldownStr)
+	result.Search.CooldownHours = cooldown
+
 	retentionDays, _ := strconv.Atoi(retentionDaysStr)
 	result.Logs.RetentionDays = retentionDays
 
+	result.Auth.Username = strings.TrimSpace(result.Auth.Username)
+
 	return &result, nil
 }
@@ -101,7 +101,7 @@ func ValidateAPIKey(s string) error {
 	return nil
 }
 
-// ValidateServerType validates that a server type is either "radarr" or "sonarr"
+// ValidateServerType validates that a server type is "radarr", "sonarr", "lidarr" or "readarr"
 func ValidateServerType(s string) error {
 	s = strings.ToLower(strings.TrimSpace(s))
 
@@ -109,9 +109,10 @@ func ValidateServerType(s string) error {
 		return fmt.Errorf("server type is required")
 	}
 
-	if s != "radarr" && s != "sonarr" {
-		return fmt.Errorf("server type must be 'radarr' or 'sonarr'")
+	switch s {
+	case "radarr", "sonarr", "lidarr", "readarr":
+		return nil
+	default:
+		return fmt.Errorf("server type must be 'radarr', 'sonarr', 'lidarr' or 'readarr'")
 	}
-
-	return nil
 }
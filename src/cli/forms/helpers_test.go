@@ -226,8 +226,18 @@ func TestValidateServerType(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:    "invalid type",
+			name:    "valid lidarr",
 			input:   "lidarr",
+			wantErr: false,
+		},
+		{
+			name:    "valid readarr",
+			input:   "Readarr",
+			wantErr: false,
+		},
+		{
+			name:    "invalid type",
+			input:   "prowlarr",
 			wantErr: true,
 		},
 	}
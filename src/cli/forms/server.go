@@ -57,6 +57,8 @@ func ServerAddForm(ctx context.Context, db *database.DB) (*ServerFormResult, err
 				Options(
 					huh.NewOption("Radarr (Movies)", "radarr"),
 					huh.NewOption("Sonarr (TV Shows)", "sonarr"),
+					huh.NewOption("Lidarr (Music)", "lidarr"),
+					huh.NewOption("Readarr (Books)", "readarr"),
 				).
 				Value(&result.Type),
 
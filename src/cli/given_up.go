@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/services"
+	"github.com/spf13/cobra"
+)
+
+var givenUpCmd = &cobra.Command{
+	Use:   "given-up",
+	Short: "Manage items Janitarr has given up searching",
+	Long: `Manage items searched search.giveUpAttempts times without a release being grabbed.
+How they are handled depends on search.giveUpPolicy: backoff and snooze search them less often,
+while tag and unmonitor stop searching them until they are reset.`,
+}
+
+var givenUpListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List items given up on",
+	RunE:  runGivenUpList,
+}
+
+var givenUpResetCmd = &cobra.Command{
+	Use:   "reset [server] [item-id]",
+	Short: "Search items given up on again",
+	Long: `Search an item given up on like any other item again, untagging or monitoring it again on its
+server if needed. Use --all to reset every item.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
+	RunE: runGivenUpReset,
+}
+
+func init() {
+	givenUpCmd.AddCommand(givenUpListCmd)
+	givenUpCmd.AddCommand(givenUpResetCmd)
+
+	givenUpListCmd.Flags().Bool("json", false, "Output list as JSON")
+	givenUpResetCmd.Flags().String("category", string(database.SearchCategoryMissing), "Category the item was searched in (missing/cutoff)")
+	givenUpResetCmd.Flags().Bool("all", false, "Reset every item given up on")
+}
+
+func runGivenUpList(cmd *cobra.Command, args []string) error {
+	db, err := database.New(dbPath, "./data/.janitarr.key")
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	items, err := services.NewGiveUpManager(db).List()
+	if err != nil {
+		return fmt.Errorf("failed to list given up items: %w", err)
+	}
+
+	outputJSON, _ := cmd.Flags().GetBool("json")
+	if outputJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(items)
+	}
+
+	fmt.Println(formatGivenUpTable(items))
+	return nil
+}
+
+func runGivenUpReset(cmd *cobra.Command, args []string) error {
+	db, err := database.New(dbPath, "./data/.janitarr.key")
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	manager := services.NewGiveUpManager(db)
+	if all, _ := cmd.Flags().GetBool("all"); all {
+		reset, err := manager.ResetAll(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to reset given up items after %d: %w", reset, err)
+		}
+		fmt.Println(success(fmt.Sprintf("Reset %d items", reset)))
+		return nil
+	}
+
+	itemID, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid item ID: %s", args[1])
+	}
+	category, _ := cmd.Flags().GetString("category")
+	if category != string(database.SearchCategoryMissing) && category != string(database.SearchCategoryCutoff) {
+		return fmt.Errorf("invalid category: must be missing or cutoff")
+	}
+
+	if err := manager.Reset(context.Background(), args[0], database.SearchCategory(category), itemID); err != nil {
+		return fmt.Errorf("failed to reset item: %w", err)
+	}
+
+	fmt.Println(success(fmt.Sprintf("Item %d on '%s' will be searched again", itemID, args[0])))
+	return nil
+}
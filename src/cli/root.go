@@ -51,6 +51,8 @@ func NewRootCmd() *cobra.Command {
 	cmd.AddCommand(scanCmd)
 	cmd.AddCommand(statusCmd)
 	cmd.AddCommand(logsCmd)
+	cmd.AddCommand(givenUpCmd)
+	cmd.AddCommand(excludeCmd)
 
 	return cmd
 }
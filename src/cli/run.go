@@ -34,6 +34,12 @@ func runAutomation(cmd *cobra.Command, args []string) error {
 	}
 	defer db.Close()
 
+	stopTracing, err := startTracing(db)
+	if err != nil {
+		return err
+	}
+	defer stopTracing()
+
 	// Initialize services
 	detector := services.NewDetector(db)
 	appLogger := logger.NewLogger(db, logger.LevelInfo, false)
@@ -13,17 +13,24 @@ import (
 var scanCmd = &cobra.Command{
 	Use:   "scan",
 	Short: "Scan servers for missing and cutoff content (detection only)",
-	RunE:  runScan,
+	Long: `Scan servers for missing and cutoff content (detection only).
+
+Shows the backlog cached by the last automation cycle or scan without contacting the servers.
+Servers that have never been scanned are scanned live. Use --refresh to read every server's
+wanted lists in full.`,
+	RunE: runScan,
 }
 
 func init() {
 	scanCmd.Flags().Bool("json", false, "Output results as JSON")
+	scanCmd.Flags().Bool("refresh", false, "Force a live scan, reading every wanted list in full")
 }
 
 func runScan(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
 	outputJSON, _ := cmd.Flags().GetBool("json")
+	refresh, _ := cmd.Flags().GetBool("refresh")
 
 	db, err := database.New(dbPath, "./data/.janitarr.key")
 	if err != nil {
@@ -33,16 +40,33 @@ func runScan(cmd *cobra.Command, args []string) error {
 
 	detector := services.NewDetector(db)
 
-	hideCursor()
-	showProgress("Scanning servers for missing and cutoff content")
+	// Cached results are instant; fall back to a live scan until every server has been scanned
+	var detectionResults *services.DetectionResults
+	fromCache := false
+	if !refresh {
+		cached, uncached, err := detector.CachedAll()
+		if err == nil && len(uncached) == 0 {
+			detectionResults = cached
+			fromCache = true
+		}
+	}
 
-	detectionResults, err := detector.DetectAll(ctx)
+	if detectionResults == nil {
+		if refresh {
+			detector.WithFullRefresh()
+		}
 
-	clearLine()
-	showCursor()
+		hideCursor()
+		showProgress("Scanning servers for missing and cutoff content")
 
-	if err != nil {
-		return fmt.Errorf("error during scan: %w", err)
+		detectionResults, err = detector.DetectAll(ctx)
+
+		clearLine()
+		showCursor()
+
+		if err != nil {
+			return fmt.Errorf("error during scan: %w", err)
+		}
 	}
 
 	if outputJSON {
@@ -57,19 +81,43 @@ func runScan(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Println(header("Scan Results:"))
+	if fromCache {
+		fmt.Println(info("Showing cached results. Use --refresh for a live scan."))
+	}
 	fmt.Printf("  Successful Scans: %d\n", detectionResults.SuccessCount)
 	fmt.Printf("  Failed Scans: %d\n", detectionResults.FailureCount)
+	if detectionResults.SkippedCount > 0 {
+		fmt.Printf("  Skipped Servers: %d\n", detectionResults.SkippedCount)
+	}
 	fmt.Printf("  Total Missing Items: %d\n", detectionResults.TotalMissing)
 	fmt.Printf("  Total Cutoff Unmet Items: %d\n", detectionResults.TotalCutoff)
 	fmt.Println()
 
 	for _, res := range detectionResults.Results {
-		if res.Error != "" {
+		if res.Skipped != "" {
+			fmt.Printf(warning("Server %s (%s) Skipped: %s\n"), res.ServerName, res.ServerType, res.Skipped)
+		} else if res.Error != "" {
 			fmt.Printf(errorMsg("Server %s (%s) Scan Failed: %s\n"), res.ServerName, res.ServerType, res.Error)
 		} else {
-			fmt.Printf(success("Server %s (%s) Scan Successful:\n"), res.ServerName, res.ServerType)
+			if res.CachedAt != nil {
+				fmt.Printf(success("Server %s (%s) Cached %s:\n"), res.ServerName, res.ServerType, res.CachedAt.Local().Format("2006-01-02 15:04"))
+			} else {
+				fmt.Printf(success("Server %s (%s) Scan Successful:\n"), res.ServerName, res.ServerType)
+			}
 			fmt.Printf("  Missing Items: %d\n", len(res.Missing))
 			fmt.Printf("  Cutoff Unmet Items: %d\n", len(res.Cutoff))
+			if res.Excluded > 0 {
+				fmt.Printf("  Excluded: %d\n", res.Excluded)
+			}
+			if res.Filtered > 0 {
+				fmt.Printf("  Filtered Out: %d\n", res.Filtered)
+			}
+			if res.Unavailable > 0 {
+				fmt.Printf("  Not Yet Available: %d\n", res.Unavailable)
+			}
+			if res.RecentlyReleased > 0 {
+				fmt.Printf("  Recently Released: %d\n", res.RecentlyReleased)
+			}
 		}
 	}
 
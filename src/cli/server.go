@@ -16,7 +16,7 @@ import (
 
 var serverCmd = &cobra.Command{
 	Use:   "server",
-	Short: "Manage Radarr/Sonarr server configurations",
+	Short: "Manage Radarr/Sonarr/Lidarr/Readarr server configurations",
 }
 
 var serverAddCmd = &cobra.Command{
@@ -53,7 +53,7 @@ func init() {
 
 	// Server add flags
 	serverAddCmd.Flags().String("name", "", "Server name")
-	serverAddCmd.Flags().String("type", "", "Server type (radarr/sonarr)")
+	serverAddCmd.Flags().String("type", "", "Server type (radarr/sonarr/lidarr/readarr)")
 	serverAddCmd.Flags().String("url", "", "Server URL")
 	serverAddCmd.Flags().String("api-key", "", "Server API key")
 
@@ -61,6 +61,26 @@ func init() {
 	serverEditCmd.Flags().String("name", "", "New server name")
 	serverEditCmd.Flags().String("url", "", "New server URL")
 	serverEditCmd.Flags().String("api-key", "", "New server API key")
+	serverEditCmd.Flags().Int("max-missing", 0, "Max missing searches per cycle for this server (-1 to remove the cap)")
+	serverEditCmd.Flags().Int("max-cutoff", 0, "Max cutoff searches per cycle for this server (-1 to remove the cap)")
+	serverEditCmd.Flags().Float64("weight", 1, "Relative share of the global search limits (default 1)")
+	serverEditCmd.Flags().Bool("never-cutoff", false, "Never trigger cutoff searches on this server")
+	serverEditCmd.Flags().Int("page-size", 0, "Records requested per page of the wanted lists (0 for the default of 100)")
+	serverEditCmd.Flags().Int("page-concurrency", 0, "Wanted-list pages fetched at once (0 for the default of 4)")
+	serverEditCmd.Flags().Bool("monitored-only", false, "Only search monitored items")
+	serverEditCmd.Flags().StringSlice("include-profile", nil, "Only search items with these quality profiles (comma-separated, \"\" to clear)")
+	serverEditCmd.Flags().StringSlice("exclude-profile", nil, "Never search items with these quality profiles")
+	serverEditCmd.Flags().StringSlice("include-tag", nil, "Only search items with at least one of these tags")
+	serverEditCmd.Flags().StringSlice("exclude-tag", nil, "Never search items with any of these tags")
+	serverEditCmd.Flags().StringSlice("include-root-folder", nil, "Only search items stored under these folders")
+	serverEditCmd.Flags().StringSlice("exclude-root-folder", nil, "Never search items stored under these folders")
+	serverEditCmd.Flags().Int("min-year", 0, "Only search items released in or after this year (0 to remove)")
+	serverEditCmd.Flags().Int("max-year", 0, "Only search items released in or before this year (0 to remove)")
+	serverEditCmd.Flags().StringSlice("include-status", nil, "Only search series, artists or authors with these statuses, e.g. continuing")
+	serverEditCmd.Flags().StringSlice("exclude-status", nil, "Never search series, artists or authors with these statuses, e.g. ended")
+	serverEditCmd.Flags().Bool("skip-unaired", false, "Skip episodes that have not aired yet")
+	serverEditCmd.Flags().Int("recently-aired-hours", 0, "Skip episodes that aired within this many hours (0 to remove)")
+	serverEditCmd.Flags().Bool("clear-filters", false, "Remove all filters before applying the filter flags")
 
 	serverListCmd.Flags().Bool("json", false, "Output list as JSON")
 	serverRemoveCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
@@ -240,8 +260,10 @@ func runServerEdit(cmd *cobra.Command, args []string) error {
 	flagName, _ := cmd.Flags().GetString("name")
 	flagURL, _ := cmd.Flags().GetString("url")
 	flagAPIKey, _ := cmd.Flags().GetString("api-key")
+	limitUpdates := serverLimitFlags(cmd)
+	limitUpdates.Filters = serverFilterFlags(cmd, existingServer.Filters)
 
-	hasFlags := flagName != "" || flagURL != "" || flagAPIKey != ""
+	hasFlags := flagName != "" || flagURL != "" || flagAPIKey != "" || limitUpdates.HasLimits() || limitUpdates.HasPagination() || limitUpdates.HasFilters()
 
 	var result *forms.ServerFormResult
 
@@ -267,7 +289,7 @@ func runServerEdit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Build updates from result
-	updates := services.ServerUpdate{}
+	updates := limitUpdates
 
 	if result.Name != "" && result.Name != existingServer.Name {
 		updates.Name = &result.Name
@@ -281,7 +303,7 @@ func runServerEdit(cmd *cobra.Command, args []string) error {
 		updates.APIKey = &result.APIKey
 	}
 
-	if updates.Name == nil && updates.URL == nil && updates.APIKey == nil {
+	if updates.Name == nil && updates.URL == nil && updates.APIKey == nil && !updates.HasLimits() && !updates.HasPagination() && !updates.HasFilters() {
 		fmt.Println(info("No changes detected. Skipping update."))
 		return nil
 	}
@@ -307,6 +329,104 @@ func runServerEdit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// serverLimitFlags collects the per-server limit and pagination flags that were explicitly set
+func serverLimitFlags(cmd *cobra.Command) services.ServerUpdate {
+	var updates services.ServerUpdate
+	flags := cmd.Flags()
+
+	if flags.Changed("max-missing") {
+		v, _ := flags.GetInt("max-missing")
+		updates.MaxMissing = &v
+	}
+	if flags.Changed("max-cutoff") {
+		v, _ := flags.GetInt("max-cutoff")
+		updates.MaxCutoff = &v
+	}
+	if flags.Changed("weight") {
+		v, _ := flags.GetFloat64("weight")
+		updates.Weight = &v
+	}
+	if flags.Changed("never-cutoff") {
+		v, _ := flags.GetBool("never-cutoff")
+		updates.NeverCutoff = &v
+	}
+	if flags.Changed("page-size") {
+		v, _ := flags.GetInt("page-size")
+		updates.PageSize = &v
+	}
+	if flags.Changed("page-concurrency") {
+		v, _ := flags.GetInt("page-concurrency")
+		updates.PageConcurrency = &v
+	}
+
+	return updates
+}
+
+// serverFilterFlags applies the filter flags that were explicitly set to a server's current
+// filters, returning nil if none were set
+func serverFilterFlags(cmd *cobra.Command, current database.ServerFilters) *database.ServerFilters {
+	flags := cmd.Flags()
+	changed := false
+	filters := current
+
+	if clear, _ := flags.GetBool("clear-filters"); clear {
+		filters = database.ServerFilters{}
+		changed = true
+	}
+
+	for name, target := range map[string]*bool{
+		"monitored-only": &filters.MonitoredOnly,
+		"skip-unaired":   &filters.SkipUnaired,
+	} {
+		if flags.Changed(name) {
+			*target, _ = flags.GetBool(name)
+			changed = true
+		}
+	}
+	for name, target := range map[string]*int{
+		"min-year":             &filters.MinYear,
+		"max-year":             &filters.MaxYear,
+		"recently-aired-hours": &filters.RecentlyAiredHours,
+	} {
+		if flags.Changed(name) {
+			*target, _ = flags.GetInt(name)
+			changed = true
+		}
+	}
+	for name, target := range map[string]*[]string{
+		"include-profile":     &filters.IncludeQualityProfiles,
+		"exclude-profile":     &filters.ExcludeQualityProfiles,
+		"include-tag":         &filters.IncludeTags,
+		"exclude-tag":         &filters.ExcludeTags,
+		"include-root-folder": &filters.IncludeRootFolders,
+		"exclude-root-folder": &filters.ExcludeRootFolders,
+		"include-status":      &filters.IncludeStatuses,
+		"exclude-status":      &filters.ExcludeStatuses,
+	} {
+		if flags.Changed(name) {
+			values, _ := flags.GetStringSlice(name)
+			*target = nonEmpty(values)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+	return &filters
+}
+
+// nonEmpty drops blank values, so a flag set to "" clears its list
+func nonEmpty(values []string) []string {
+	var kept []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
 func runServerRemove(cmd *cobra.Command, args []string) error {
 	db, err := database.New(dbPath, "./data/.janitarr.key")
 	if err != nil {
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/services"
+	"github.com/spf13/cobra"
+)
+
+var serverPairCmd = &cobra.Command{
+	Use:   "pair <primary> <secondary>",
+	Short: "Pair two servers that search the same indexers",
+	Long: `Pair two Radarr or Sonarr servers that search the same indexers, such as radarr and radarr-4k,
+so a title wanted on both isn't searched twice in a cycle. Titles are matched by their TMDB,
+IMDb or TVDB ID. Pairing servers that are already paired replaces their policy.
+
+Policies:
+  search-both     Search the title on both servers
+  prefer-primary  Only search the title on the primary server
+  stagger         Search the title on one server per cycle, taking turns`,
+	Args: cobra.ExactArgs(2),
+	RunE: runServerPair,
+}
+
+var serverPairsCmd = &cobra.Command{
+	Use:   "pairs",
+	Short: "List paired servers",
+	RunE:  runServerPairs,
+}
+
+var serverUnpairCmd = &cobra.Command{
+	Use:   "unpair <server> <other>",
+	Short: "Unpair two servers",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runServerUnpair,
+}
+
+func init() {
+	serverCmd.AddCommand(serverPairCmd)
+	serverCmd.AddCommand(serverPairsCmd)
+	serverCmd.AddCommand(serverUnpairCmd)
+
+	serverPairCmd.Flags().String("policy", string(database.PairPreferPrimary), "How titles wanted on both servers are searched (search-both/prefer-primary/stagger)")
+	serverPairsCmd.Flags().Bool("json", false, "Output list as JSON")
+}
+
+func runServerPair(cmd *cobra.Command, args []string) error {
+	db, err := database.New(dbPath, "./data/.janitarr.key")
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	policy, _ := cmd.Flags().GetString("policy")
+	serverManager := services.NewServerManagerFunc(db, nil)
+	pair, err := serverManager.PairServers(args[0], args[1], strings.ToLower(policy))
+	if err != nil {
+		return fmt.Errorf("failed to pair servers: %w", err)
+	}
+
+	fmt.Println(success(fmt.Sprintf("Paired '%s' with '%s' (%s)", pair.PrimaryName, pair.SecondaryName, pair.Policy)))
+	return nil
+}
+
+func runServerPairs(cmd *cobra.Command, args []string) error {
+	db, err := database.New(dbPath, "./data/.janitarr.key")
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	serverManager := services.NewServerManagerFunc(db, nil)
+	pairs, err := serverManager.ListServerPairs()
+	if err != nil {
+		return fmt.Errorf("failed to list server pairs: %w", err)
+	}
+
+	outputJSON, _ := cmd.Flags().GetBool("json")
+	if outputJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(pairs)
+	}
+
+	fmt.Println(formatServerPairTable(pairs))
+	return nil
+}
+
+func runServerUnpair(cmd *cobra.Command, args []string) error {
+	db, err := database.New(dbPath, "./data/.janitarr.key")
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	serverManager := services.NewServerManagerFunc(db, nil)
+	if err := serverManager.UnpairServers(args[0], args[1]); err != nil {
+		return fmt.Errorf("failed to unpair servers: %w", err)
+	}
+
+	fmt.Println(success(fmt.Sprintf("Unpaired '%s' and '%s'", args[0], args[1])))
+	return nil
+}
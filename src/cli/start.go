@@ -11,6 +11,8 @@ import (
 
 	"github.com/edrobertsrayne/janitarr/src/database"
 	"github.com/edrobertsrayne/janitarr/src/logger"
+	"github.com/edrobertsrayne/janitarr/src/metrics"
+	"github.com/edrobertsrayne/janitarr/src/notifications"
 	"github.com/edrobertsrayne/janitarr/src/services"
 	"github.com/edrobertsrayne/janitarr/src/web"
 	"github.com/spf13/cobra"
@@ -68,17 +70,37 @@ func runStart(cmd *cobra.Command, args []string) error {
 	// Initialize logger with configured level in production mode
 	appLogger := logger.NewLogger(db, level, false)
 
-	// Initialize services
-	detector := services.NewDetector(db)
-	searchTrigger := services.NewSearchTrigger(db, appLogger)
-	automation := services.NewAutomation(db, detector, searchTrigger, appLogger)
+	// Export traces if configured; flushed after the graceful shutdown below
+	stopTracing, err := startTracing(db)
+	if err != nil {
+		return err
+	}
+	defer stopTracing()
+
+	// Initialize services, sharing one metrics instance with the web server
+	prometheusMetrics := metrics.NewMetrics()
+	detector := services.NewDetector(db).WithMetrics(prometheusMetrics).WithLogger(appLogger)
+	searchTrigger := services.NewSearchTrigger(db, appLogger).WithMetrics(prometheusMetrics)
+	automation := services.NewAutomation(db, detector, searchTrigger, appLogger).WithMetrics(prometheusMetrics)
 
 	// Create scheduler with automation callback wrapper
 	schedulerCallback := func(ctx context.Context, isManual bool) error {
 		_, err := automation.RunCycle(ctx, isManual, false) // dryRun = false
 		return err
 	}
-	scheduler := services.NewScheduler(db, config.Schedule.IntervalHours, schedulerCallback).WithLogger(appLogger)
+	schedule, err := services.NewRunSchedule(config.Schedule)
+	if err != nil {
+		return fmt.Errorf("invalid schedule configuration: %w", err)
+	}
+	scheduler := services.NewScheduler(db, config.Schedule.IntervalHours, schedulerCallback).WithLogger(appLogger).WithSchedule(schedule)
+
+	// Forward cycle summaries and server errors to notification channels
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go notifications.NewDispatcher(db, appLogger).Run(backgroundCtx)
+
+	// Follow triggered searches until they complete, fail or grab a release
+	go services.NewOutcomeTracker(db, appLogger).Run(backgroundCtx, services.OutcomePollInterval)
 
 	// Start scheduler if enabled
 	ctx := context.Background()
@@ -86,7 +108,7 @@ func runStart(cmd *cobra.Command, args []string) error {
 		if err := scheduler.Start(ctx); err != nil {
 			return fmt.Errorf("failed to start scheduler: %w", err)
 		}
-		fmt.Printf("✓ Scheduler started (interval: %d hours)\n", config.Schedule.IntervalHours)
+		fmt.Printf("✓ Scheduler started (%s)\n", scheduleSummary(config.Schedule))
 	} else {
 		fmt.Println("⚠ Warning: Scheduler is disabled in configuration")
 		fmt.Println("  Use 'janitarr config set schedule.enabled true' to enable")
@@ -99,6 +121,7 @@ func runStart(cmd *cobra.Command, args []string) error {
 		DB:        db,
 		Logger:    appLogger,
 		Scheduler: scheduler,
+		Metrics:   prometheusMetrics,
 		IsDev:     false,
 	})
 
@@ -39,11 +39,18 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	}
 	radarrCount := 0
 	sonarrCount := 0
+	lidarrCount := 0
+	readarrCount := 0
 	for _, s := range servers {
-		if s.Type == "radarr" {
+		switch s.Type {
+		case "radarr":
 			radarrCount++
-		} else if s.Type == "sonarr" {
+		case "sonarr":
 			sonarrCount++
+		case "lidarr":
+			lidarrCount++
+		case "readarr":
+			readarrCount++
 		}
 	}
 
@@ -55,9 +62,11 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	statusInfo := struct {
 		Scheduler    services.SchedulerStatus `json:"scheduler"`
 		ServerCounts struct {
-			Total  int `json:"total"`
-			Radarr int `json:"radarr"`
-			Sonarr int `json:"sonarr"`
+			Total   int `json:"total"`
+			Radarr  int `json:"radarr"`
+			Sonarr  int `json:"sonarr"`
+			Lidarr  int `json:"lidarr"`
+			Readarr int `json:"readarr"`
 		} `json:"serverCounts"`
 		LastCycle struct {
 			Active  bool       `json:"active"`
@@ -67,13 +76,17 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	}{
 		Scheduler: schedulerStatus,
 		ServerCounts: struct {
-			Total  int `json:"total"`
-			Radarr int `json:"radarr"`
-			Sonarr int `json:"sonarr"`
+			Total   int `json:"total"`
+			Radarr  int `json:"radarr"`
+			Sonarr  int `json:"sonarr"`
+			Lidarr  int `json:"lidarr"`
+			Readarr int `json:"readarr"`
 		}{
-			Total:  len(servers),
-			Radarr: radarrCount,
-			Sonarr: sonarrCount,
+			Total:   len(servers),
+			Radarr:  radarrCount,
+			Sonarr:  sonarrCount,
+			Lidarr:  lidarrCount,
+			Readarr: readarrCount,
 		},
 		LastCycle: struct {
 			Active  bool       `json:"active"`
@@ -98,7 +111,9 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	fmt.Println(info("Scheduler Status:"))
 	fmt.Printf("  Running: %s\n", formatBool(schedulerStatus.IsRunning))
 	fmt.Printf("  Cycle Active: %s\n", formatBool(schedulerStatus.IsCycleActive))
-	if schedulerStatus.NextRun != nil {
+	if schedulerStatus.NextRun != nil && schedulerStatus.NextRun.Before(time.Now()) {
+		fmt.Printf("  Next Run: %s (overdue)\n", schedulerStatus.NextRun.Format(time.RFC822))
+	} else if schedulerStatus.NextRun != nil {
 		fmt.Printf("  Next Run: %s (in %s)\n", schedulerStatus.NextRun.Format(time.RFC822), time.Until(*schedulerStatus.NextRun).Round(time.Second))
 	} else {
 		fmt.Println("  Next Run: N/A")
@@ -108,13 +123,19 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	} else {
 		fmt.Println("  Last Run: N/A")
 	}
-	fmt.Printf("  Interval: %d hours\n", schedulerStatus.IntervalHours)
+	if schedulerStatus.Cron != "" {
+		fmt.Printf("  Cron: %s\n", schedulerStatus.Cron)
+	} else {
+		fmt.Printf("  Interval: %d hours\n", schedulerStatus.IntervalHours)
+	}
 	fmt.Println()
 
 	fmt.Println(info("Server Overview:"))
 	fmt.Printf("  Total Configured: %d\n", statusInfo.ServerCounts.Total)
 	fmt.Printf("  Radarr Servers: %d\n", statusInfo.ServerCounts.Radarr)
 	fmt.Printf("  Sonarr Servers: %d\n", statusInfo.ServerCounts.Sonarr)
+	fmt.Printf("  Lidarr Servers: %d\n", statusInfo.ServerCounts.Lidarr)
+	fmt.Printf("  Readarr Servers: %d\n", statusInfo.ServerCounts.Readarr)
 	fmt.Println()
 
 	// Placeholder for last cycle summary until actual implementation exists
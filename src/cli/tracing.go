@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/tracing"
+)
+
+// startTracing installs the trace exporter from the config table and JANITARR_TRACING_* environment.
+// The returned function flushes buffered spans and should be deferred.
+func startTracing(db *database.DB) (func(), error) {
+	config, err := tracing.ConfigFromEnv(db.GetAppConfig().Tracing)
+	if err != nil {
+		return nil, err
+	}
+
+	shutdown, err := tracing.Setup(context.Background(), config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start tracing: %w", err)
+	}
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ Failed to flush traces: %v\n", err)
+		}
+	}, nil
+}
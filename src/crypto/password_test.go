@@ -0,0 +1,36 @@
+package crypto
+
+import "testing"
+
+func TestHashAndCheckPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+	if hash == "correct horse" {
+		t.Fatal("HashPassword() returned the plain password")
+	}
+	if !CheckPassword(hash, "correct horse") {
+		t.Error("CheckPassword() rejected the correct password")
+	}
+	if CheckPassword(hash, "wrong") {
+		t.Error("CheckPassword() accepted a wrong password")
+	}
+}
+
+func TestGenerateToken(t *testing.T) {
+	a, err := GenerateToken(16)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if len(a) != 32 {
+		t.Errorf("GenerateToken(16) returned %d chars, want 32", len(a))
+	}
+	b, _ := GenerateToken(16)
+	if a == b {
+		t.Error("GenerateToken() returned same token twice - randomness issue")
+	}
+	if HashToken(a) == a || HashToken(a) != HashToken(a) {
+		t.Error("HashToken() should be a stable digest different from the token")
+	}
+}
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/crypto"
+)
+
+// Credentials are kept out of AppConfig so they are never returned by the config API.
+const (
+	configKeyPasswordHash = "auth.passwordHash"
+	configKeyAPIKey       = "auth.apiKey"
+)
+
+// SetAuthPassword hashes and stores the web UI password
+func (db *DB) SetAuthPassword(password string) error {
+	if len(password) < 8 {
+		return fmt.Errorf("password must be at least 8 characters")
+	}
+
+	hash, err := crypto.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	return db.SetConfig(configKeyPasswordHash, hash)
+}
+
+// HasAuthPassword reports whether a web UI password has been set
+func (db *DB) HasAuthPassword() bool {
+	val := db.GetConfig(configKeyPasswordHash)
+	return val != nil && *val != ""
+}
+
+// VerifyCredentials checks a username (case-insensitive) and password against the stored login
+func (db *DB) VerifyCredentials(username, password string) bool {
+	hash := db.GetConfig(configKeyPasswordHash)
+	if hash == nil || *hash == "" {
+		return false
+	}
+
+	// Always run the hash comparison so timing does not reveal whether the username matched
+	passwordOK := crypto.CheckPassword(*hash, password)
+	return passwordOK && strings.EqualFold(username, db.GetAppConfig().Auth.Username)
+}
+
+// GetAPIKey returns the API key accepted in the X-Api-Key header
+func (db *DB) GetAPIKey() string {
+	if val := db.GetConfig(configKeyAPIKey); val != nil {
+		return *val
+	}
+	return ""
+}
+
+// RegenerateAPIKey replaces the API key with a new random one and returns it
+func (db *DB) RegenerateAPIKey() (string, error) {
+	key, err := crypto.GenerateToken(16)
+	if err != nil {
+		return "", err
+	}
+	if err := db.SetConfig(configKeyAPIKey, key); err != nil {
+		return "", fmt.Errorf("saving API key: %w", err)
+	}
+	return key, nil
+}
+
+// CreateSession starts a login session and returns the token to store in the session cookie
+func (db *DB) CreateSession(username string, ttl time.Duration) (string, error) {
+	token, err := crypto.GenerateToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+
+	// Opportunistically clear out expired sessions
+	if _, err := db.conn.Exec("DELETE FROM sessions WHERE expires_at <= ?", now.Format(time.RFC3339)); err != nil {
+		return "", fmt.Errorf("deleting expired sessions: %w", err)
+	}
+
+	_, err = db.conn.Exec(`
+		INSERT INTO sessions (token_hash, username, created_at, expires_at)
+		VALUES (?, ?, ?, ?)
+	`, crypto.HashToken(token), username, now.Format(time.RFC3339), now.Add(ttl).Format(time.RFC3339))
+	if err != nil {
+		return "", fmt.Errorf("inserting session: %w", err)
+	}
+
+	return token, nil
+}
+
+// GetSessionUser returns the username for a valid, unexpired session token, or "" if there is none
+func (db *DB) GetSessionUser(token string) string {
+	if token == "" {
+		return ""
+	}
+
+	var username string
+	err := db.conn.QueryRow(`
+		SELECT username FROM sessions WHERE token_hash = ? AND expires_at > ?
+	`, crypto.HashToken(token), time.Now().UTC().Format(time.RFC3339)).Scan(&username)
+	if err != nil {
+		return ""
+	}
+	return username
+}
+
+// DeleteSession ends a login session
+func (db *DB) DeleteSession(token string) error {
+	if _, err := db.conn.Exec("DELETE FROM sessions WHERE token_hash = ?", crypto.HashToken(token)); err != nil {
+		return fmt.Errorf("deleting session: %w", err)
+	}
+	return nil
+}
+
+// DeleteAllSessions logs out every session, e.g. after a password change
+func (db *DB) DeleteAllSessions() error {
+	if _, err := db.conn.Exec("DELETE FROM sessions"); err != nil {
+		return fmt.Errorf("deleting sessions: %w", err)
+	}
+	return nil
+}
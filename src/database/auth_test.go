@@ -0,0 +1,89 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAuthPassword(t *testing.T) {
+	db := testDB(t)
+
+	if db.HasAuthPassword() {
+		t.Error("expected no password on a new database")
+	}
+	if db.VerifyCredentials("admin", "") {
+		t.Error("expected credentials to fail when no password is set")
+	}
+
+	if err := db.SetAuthPassword("short"); err == nil {
+		t.Error("expected error for short password")
+	}
+	if err := db.SetAuthPassword("correct horse"); err != nil {
+		t.Fatalf("SetAuthPassword failed: %v", err)
+	}
+
+	if !db.HasAuthPassword() {
+		t.Error("expected password to be set")
+	}
+	if !db.VerifyCredentials("Admin", "correct horse") {
+		t.Error("expected valid credentials to pass")
+	}
+	if db.VerifyCredentials("admin", "wrong password") {
+		t.Error("expected wrong password to fail")
+	}
+	if db.VerifyCredentials("someone", "correct horse") {
+		t.Error("expected wrong username to fail")
+	}
+
+	// The hash must not leak into the application config
+	if cfg := db.GetAppConfig(); cfg.Auth.Username != "admin" {
+		t.Errorf("expected default username admin, got %q", cfg.Auth.Username)
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	db := testDB(t)
+
+	initial := db.GetAPIKey()
+	if len(initial) != 32 {
+		t.Fatalf("expected a 32 character API key to be generated, got %q", initial)
+	}
+
+	regenerated, err := db.RegenerateAPIKey()
+	if err != nil {
+		t.Fatalf("RegenerateAPIKey failed: %v", err)
+	}
+	if regenerated == initial || db.GetAPIKey() != regenerated {
+		t.Error("expected API key to be replaced")
+	}
+}
+
+func TestSessions(t *testing.T) {
+	db := testDB(t)
+
+	token, err := db.CreateSession("admin", time.Hour)
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if user := db.GetSessionUser(token); user != "admin" {
+		t.Errorf("expected session user admin, got %q", user)
+	}
+	if user := db.GetSessionUser("bogus"); user != "" {
+		t.Errorf("expected unknown token to be rejected, got %q", user)
+	}
+
+	expired, err := db.CreateSession("admin", -time.Minute)
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if user := db.GetSessionUser(expired); user != "" {
+		t.Errorf("expected expired session to be rejected, got %q", user)
+	}
+
+	if err := db.DeleteSession(token); err != nil {
+		t.Fatalf("DeleteSession failed: %v", err)
+	}
+	if user := db.GetSessionUser(token); user != "" {
+		t.Errorf("expected deleted session to be rejected, got %q", user)
+	}
+}
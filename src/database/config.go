@@ -21,6 +21,22 @@ var GetAppConfigFunc = func(db *DB) AppConfig {
 		config.Schedule.Enabled = *val == "true"
 	}
 
+	if val := db.GetConfig("schedule.cron"); val != nil {
+		config.Schedule.Cron = *val
+	}
+
+	if val := db.GetConfig("schedule.windows"); val != nil {
+		config.Schedule.Windows = *val
+	}
+
+	if val := db.GetConfig("schedule.blackouts"); val != nil {
+		config.Schedule.Blackouts = *val
+	}
+
+	if val := db.GetConfig("schedule.catchUp"); val != nil && IsValidCatchUpPolicy(*val) {
+		config.Schedule.CatchUp = CatchUpPolicy(*val)
+	}
+
 	// Search limits
 	if val := db.GetConfig("limits.missing.movies"); val != nil {
 		if i, err := strconv.Atoi(*val); err == nil {
@@ -46,6 +62,66 @@ var GetAppConfigFunc = func(db *DB) AppConfig {
 		}
 	}
 
+	// Search settings
+	if val := db.GetConfig("search.cooldownHours"); val != nil {
+		if i, err := strconv.Atoi(*val); err == nil && i >= 0 {
+			config.Search.CooldownHours = i
+		}
+	}
+
+	if val := db.GetConfig("search.strategy"); val != nil && IsValidSelectionMode(*val) {
+		config.Search.Strategy = SelectionMode(*val)
+	}
+
+	if val := db.GetConfig("search.seasonPackPercent"); val != nil {
+		if i, err := strconv.Atoi(*val); err == nil && i >= 0 && i <= 100 {
+			config.Search.SeasonPackPercent = i
+		}
+	}
+
+	if val := db.GetConfig("search.maxQueueSize"); val != nil {
+		if i, err := strconv.Atoi(*val); err == nil && i >= 0 {
+			config.Search.MaxQueueSize = i
+		}
+	}
+
+	if val := db.GetConfig("search.giveUpAttempts"); val != nil {
+		if i, err := strconv.Atoi(*val); err == nil && i >= 0 {
+			config.Search.GiveUpAttempts = i
+		}
+	}
+
+	if val := db.GetConfig("search.giveUpPolicy"); val != nil && IsValidGiveUpPolicy(*val) {
+		config.Search.GiveUpPolicy = GiveUpPolicy(*val)
+	}
+
+	if val := db.GetConfig("search.giveUpDays"); val != nil {
+		if i, err := strconv.Atoi(*val); err == nil && i >= 1 {
+			config.Search.GiveUpDays = i
+		}
+	}
+
+	if val := db.GetConfig("search.giveUpTagLabel"); val != nil && *val != "" {
+		config.Search.GiveUpTagLabel = *val
+	}
+
+	// Detection settings
+	if val := db.GetConfig("detection.fullRefreshHours"); val != nil {
+		if i, err := strconv.Atoi(*val); err == nil && i >= 0 {
+			config.Detection.FullRefreshHours = i
+		}
+	}
+
+	if val := db.GetConfig("detection.skipUnavailable"); val != nil {
+		config.Detection.SkipUnavailable = *val == "true"
+	}
+
+	if val := db.GetConfig("detection.releaseGraceHours"); val != nil {
+		if i, err := strconv.Atoi(*val); err == nil && i >= 0 {
+			config.Detection.ReleaseGraceHours = i
+		}
+	}
+
 	// Logs settings
 	if val := db.GetConfig("logs.retention_days"); val != nil {
 		if i, err := strconv.Atoi(*val); err == nil {
@@ -56,6 +132,30 @@ var GetAppConfigFunc = func(db *DB) AppConfig {
 		}
 	}
 
+	// Auth settings
+	if val := db.GetConfig("auth.mode"); val != nil && IsValidAuthMode(*val) {
+		config.Auth.Mode = AuthMode(*val)
+	}
+
+	if val := db.GetConfig("auth.username"); val != nil && *val != "" {
+		config.Auth.Username = *val
+	}
+
+	// Tracing settings
+	if val := db.GetConfig("tracing.exporter"); val != nil && IsValidTracingExporter(*val) {
+		config.Tracing.Exporter = TracingExporter(*val)
+	}
+
+	if val := db.GetConfig("tracing.endpoint"); val != nil {
+		config.Tracing.Endpoint = *val
+	}
+
+	// Request manager settings
+	if val := db.GetConfig("requests.url"); val != nil {
+		config.Requests.URL = *val
+	}
+	config.Requests.HasAPIKey = db.GetRequestsAPIKey() != ""
+
 	return config
 }
 
@@ -70,6 +170,18 @@ var SetAppConfigFunc = func(db *DB, update AppConfig) error {
 	if err := db.SetConfig("schedule.enabled", strconv.FormatBool(update.Schedule.Enabled)); err != nil {
 		return err
 	}
+	if err := db.SetConfig("schedule.cron", update.Schedule.Cron); err != nil {
+		return err
+	}
+	if err := db.SetConfig("schedule.windows", update.Schedule.Windows); err != nil {
+		return err
+	}
+	if err := db.SetConfig("schedule.blackouts", update.Schedule.Blackouts); err != nil {
+		return err
+	}
+	if err := db.SetConfig("schedule.catchUp", string(update.Schedule.CatchUp)); err != nil {
+		return err
+	}
 	if err := db.SetConfig("limits.missing.movies", strconv.Itoa(update.SearchLimits.MissingMoviesLimit)); err != nil {
 		return err
 	}
@@ -82,9 +194,57 @@ var SetAppConfigFunc = func(db *DB, update AppConfig) error {
 	if err := db.SetConfig("limits.cutoff.episodes", strconv.Itoa(update.SearchLimits.CutoffEpisodesLimit)); err != nil {
 		return err
 	}
+	if err := db.SetConfig("search.cooldownHours", strconv.Itoa(update.Search.CooldownHours)); err != nil {
+		return err
+	}
+	if err := db.SetConfig("search.strategy", string(update.Search.Strategy)); err != nil {
+		return err
+	}
+	if err := db.SetConfig("search.seasonPackPercent", strconv.Itoa(update.Search.SeasonPackPercent)); err != nil {
+		return err
+	}
+	if err := db.SetConfig("search.maxQueueSize", strconv.Itoa(update.Search.MaxQueueSize)); err != nil {
+		return err
+	}
+	if err := db.SetConfig("search.giveUpAttempts", strconv.Itoa(update.Search.GiveUpAttempts)); err != nil {
+		return err
+	}
+	if err := db.SetConfig("search.giveUpPolicy", string(update.Search.GiveUpPolicy)); err != nil {
+		return err
+	}
+	if err := db.SetConfig("search.giveUpDays", strconv.Itoa(update.Search.GiveUpDays)); err != nil {
+		return err
+	}
+	if err := db.SetConfig("search.giveUpTagLabel", update.Search.GiveUpTagLabel); err != nil {
+		return err
+	}
+	if err := db.SetConfig("detection.fullRefreshHours", strconv.Itoa(update.Detection.FullRefreshHours)); err != nil {
+		return err
+	}
+	if err := db.SetConfig("detection.skipUnavailable", strconv.FormatBool(update.Detection.SkipUnavailable)); err != nil {
+		return err
+	}
+	if err := db.SetConfig("detection.releaseGraceHours", strconv.Itoa(update.Detection.ReleaseGraceHours)); err != nil {
+		return err
+	}
 	if err := db.SetConfig("logs.retention_days", strconv.Itoa(update.Logs.RetentionDays)); err != nil {
 		return err
 	}
+	if err := db.SetConfig("auth.mode", string(update.Auth.Mode)); err != nil {
+		return err
+	}
+	if err := db.SetConfig("auth.username", update.Auth.Username); err != nil {
+		return err
+	}
+	if err := db.SetConfig("tracing.exporter", string(update.Tracing.Exporter)); err != nil {
+		return err
+	}
+	if err := db.SetConfig("tracing.endpoint", update.Tracing.Endpoint); err != nil {
+		return err
+	}
+	if err := db.SetConfig("requests.url", update.Requests.URL); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -18,6 +18,45 @@ var migration001 string
 //go:embed migrations/002_enhanced_logs.sql
 var migration002 string
 
+//go:embed migrations/003_search_history.sql
+var migration003 string
+
+//go:embed migrations/004_lidarr_readarr.sql
+var migration004 string
+
+//go:embed migrations/005_server_limits.sql
+var migration005 string
+
+//go:embed migrations/006_sessions.sql
+var migration006 string
+
+//go:embed migrations/007_notifications.sql
+var migration007 string
+
+//go:embed migrations/008_search_outcomes.sql
+var migration008 string
+
+//go:embed migrations/009_server_health.sql
+var migration009 string
+
+//go:embed migrations/010_server_pagination.sql
+var migration010 string
+
+//go:embed migrations/011_wanted_cache.sql
+var migration011 string
+
+//go:embed migrations/012_server_filters.sql
+var migration012 string
+
+//go:embed migrations/013_server_pairs.sql
+var migration013 string
+
+//go:embed migrations/014_give_up.sql
+var migration014 string
+
+//go:embed migrations/015_exclusions.sql
+var migration015 string
+
 const (
 	// LogRetentionDays is the number of days to keep log entries
 	LogRetentionDays = 30
@@ -103,6 +142,19 @@ func (db *DB) migrate() error {
 	migrations := []string{
 		migration001,
 		migration002,
+		migration003,
+		migration004,
+		migration005,
+		migration006,
+		migration007,
+		migration008,
+		migration009,
+		migration010,
+		migration011,
+		migration012,
+		migration013,
+		migration014,
+		migration015,
 	}
 
 	for i, migration := range migrations {
@@ -142,12 +194,27 @@ func (db *DB) migrate() error {
 // initializeDefaults sets default configuration values if not present
 func (db *DB) initializeDefaults() error {
 	defaults := map[string]string{
-		"schedule.intervalHours":  "6",
-		"schedule.enabled":        "true",
-		"limits.missing.movies":   "10",
-		"limits.missing.episodes": "10",
-		"limits.cutoff.movies":    "5",
-		"limits.cutoff.episodes":  "5",
+		"schedule.intervalHours":      "6",
+		"schedule.enabled":            "true",
+		"schedule.catchUp":            string(CatchUpRun),
+		"auth.mode":                   string(AuthDisabled),
+		"auth.username":               "admin",
+		"limits.missing.movies":       "10",
+		"limits.missing.episodes":     "10",
+		"limits.cutoff.movies":        "5",
+		"limits.cutoff.episodes":      "5",
+		"search.cooldownHours":        "24",
+		"search.strategy":             string(SelectionOldestSearchedFirst),
+		"search.seasonPackPercent":    "75",
+		"search.maxQueueSize":         "0",
+		"search.giveUpAttempts":       "0",
+		"search.giveUpPolicy":         string(GiveUpBackoff),
+		"search.giveUpDays":           "30",
+		"search.giveUpTagLabel":       "janitarr-gave-up",
+		"detection.fullRefreshHours":  "24",
+		"detection.skipUnavailable":   "true",
+		"detection.releaseGraceHours": "0",
+		"tracing.exporter":            string(TracingNone),
 	}
 
 	for key, value := range defaults {
@@ -156,6 +223,13 @@ func (db *DB) initializeDefaults() error {
 		}
 	}
 
+	// Each install gets its own random API key
+	if db.GetConfig(configKeyAPIKey) == nil {
+		if _, err := db.RegenerateAPIKey(); err != nil {
+			return fmt.Errorf("generating API key: %w", err)
+		}
+	}
+
 	return nil
 }
 
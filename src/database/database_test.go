@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -264,6 +265,168 @@ func TestServersByType(t *testing.T) {
 	}
 }
 
+// TestServerTypes_LidarrReadarr tests that the widened type check accepts Lidarr and Readarr
+func TestServerTypes_LidarrReadarr(t *testing.T) {
+	db := testDB(t)
+
+	if _, err := db.AddServer("lidarr1", "http://localhost:8686", "key1", ServerTypeLidarr); err != nil {
+		t.Fatalf("adding lidarr server: %v", err)
+	}
+	if _, err := db.AddServer("readarr1", "http://localhost:8787", "key2", ServerTypeReadarr); err != nil {
+		t.Fatalf("adding readarr server: %v", err)
+	}
+	if _, err := db.AddServer("bogus", "http://localhost:9999", "key3", ServerType("prowlarr")); err == nil {
+		t.Error("expected error adding server with unsupported type")
+	}
+
+	lidarrs, err := db.GetServersByType(ServerTypeLidarr)
+	if err != nil {
+		t.Fatalf("getting servers by type: %v", err)
+	}
+	if len(lidarrs) != 1 {
+		t.Errorf("expected 1 lidarr server, got %d", len(lidarrs))
+	}
+}
+
+// TestMigration004_PreservesData tests that rebuilding the servers table keeps servers and search history
+func TestMigration004_PreservesData(t *testing.T) {
+	db := testDB(t)
+
+	server, err := db.AddServer("radarr1", "http://localhost:7878", "key1", ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	if err := db.RecordSearches(server.ID, SearchCategoryMissing, []int{1, 2}, 0, time.Now()); err != nil {
+		t.Fatalf("recording searches: %v", err)
+	}
+
+	// Re-run the table rebuild and the migrations that add columns back onto the servers table
+	if _, err := db.conn.Exec("DELETE FROM schema_migrations WHERE version IN (4, 5, 10, 12)"); err != nil {
+		t.Fatalf("resetting migration: %v", err)
+	}
+	if err := db.migrate(); err != nil {
+		t.Fatalf("re-running migrations: %v", err)
+	}
+
+	got, err := db.GetServer(server.ID)
+	if err != nil || got == nil {
+		t.Fatalf("server missing after migration: %v", err)
+	}
+	if got.APIKey != "key1" {
+		t.Errorf("expected API key to survive migration, got %q", got.APIKey)
+	}
+
+	history, err := db.GetSearchHistory(server.ID, SearchCategoryMissing)
+	if err != nil {
+		t.Fatalf("getting search history: %v", err)
+	}
+	if len(history) != 2 {
+		t.Errorf("expected 2 search history entries after migration, got %d", len(history))
+	}
+}
+
+// TestServerLimits tests that per-server limits default sensibly and round-trip through updates
+func TestServerLimits(t *testing.T) {
+	db := testDB(t)
+
+	server, err := db.AddServer("radarr1", "http://localhost:7878", "key1", ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	got, err := db.GetServer(server.ID)
+	if err != nil {
+		t.Fatalf("getting server: %v", err)
+	}
+	if got.Limits.MaxMissing != nil || got.Limits.MaxCutoff != nil || got.Limits.NeverCutoff || got.Limits.Weight != 1 {
+		t.Errorf("expected default limits, got %+v", got.Limits)
+	}
+
+	maxMissing := 5
+	limits := ServerLimits{MaxMissing: &maxMissing, Weight: 2.5, NeverCutoff: true}
+	if err := db.UpdateServer(server.ID, &ServerUpdate{Limits: &limits}); err != nil {
+		t.Fatalf("updating limits: %v", err)
+	}
+
+	servers, err := db.GetAllServers()
+	if err != nil {
+		t.Fatalf("getting servers: %v", err)
+	}
+	got = &servers[0]
+	if got.Limits.MaxMissing == nil || *got.Limits.MaxMissing != 5 {
+		t.Errorf("expected max missing 5, got %v", got.Limits.MaxMissing)
+	}
+	if got.Limits.MaxCutoff != nil {
+		t.Errorf("expected no max cutoff, got %d", *got.Limits.MaxCutoff)
+	}
+	if got.Limits.Weight != 2.5 || !got.Limits.NeverCutoff {
+		t.Errorf("expected weight 2.5 and never cutoff, got %+v", got.Limits)
+	}
+}
+
+// TestServerPagination tests that pagination overrides default to zero and round-trip through updates
+func TestServerPagination(t *testing.T) {
+	db := testDB(t)
+
+	server, err := db.AddServer("sonarr1", "http://localhost:8989", "key1", ServerTypeSonarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	got, err := db.GetServer(server.ID)
+	if err != nil {
+		t.Fatalf("getting server: %v", err)
+	}
+	if got.Pagination != (ServerPagination{}) {
+		t.Errorf("expected no pagination overrides, got %+v", got.Pagination)
+	}
+
+	pagination := ServerPagination{PageSize: 250, Concurrency: 8}
+	if err := db.UpdateServer(server.ID, &ServerUpdate{Pagination: &pagination}); err != nil {
+		t.Fatalf("updating pagination: %v", err)
+	}
+
+	got, err = db.GetServerByName("sonarr1")
+	if err != nil {
+		t.Fatalf("getting server: %v", err)
+	}
+	if got.Pagination != pagination {
+		t.Errorf("expected %+v, got %+v", pagination, got.Pagination)
+	}
+}
+
+func TestServerFilters(t *testing.T) {
+	db := testDB(t)
+
+	server, err := db.AddServer("sonarr1", "http://localhost:8989", "key1", ServerTypeSonarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	if !server.Filters.IsEmpty() {
+		t.Errorf("expected no filters, got %+v", server.Filters)
+	}
+
+	filters := ServerFilters{
+		MonitoredOnly:      true,
+		ExcludeTags:        []string{"kids"},
+		IncludeRootFolders: []string{"/tv"},
+		MinYear:            2000,
+		IncludeStatuses:    []string{"continuing"},
+		RecentlyAiredHours: 6,
+	}
+	if err := db.UpdateServer(server.ID, &ServerUpdate{Filters: &filters}); err != nil {
+		t.Fatalf("updating filters: %v", err)
+	}
+
+	servers, err := db.GetAllServers()
+	if err != nil {
+		t.Fatalf("getting servers: %v", err)
+	}
+	if !reflect.DeepEqual(servers[0].Filters, filters) {
+		t.Errorf("expected %+v, got %+v", filters, servers[0].Filters)
+	}
+}
+
 // TestConfigGetSet tests configuration persistence
 func TestConfigGetSet(t *testing.T) {
 	db := testDB(t)
@@ -312,6 +475,7 @@ func TestSetAppConfig(t *testing.T) {
 	config := db.GetAppConfig()
 	config.Schedule.IntervalHours = 24
 	config.SearchLimits.MissingMoviesLimit = 20
+	config.Tracing = TracingConfig{Exporter: TracingFile, Endpoint: "/tmp/traces.jsonl"}
 
 	// Set the modified config
 	err := db.SetAppConfig(config)
@@ -327,6 +491,9 @@ func TestSetAppConfig(t *testing.T) {
 	if newConfig.SearchLimits.MissingMoviesLimit != 20 {
 		t.Errorf("expected missing movies limit 20, got %d", newConfig.SearchLimits.MissingMoviesLimit)
 	}
+	if newConfig.Tracing != config.Tracing {
+		t.Errorf("expected tracing %+v, got %+v", config.Tracing, newConfig.Tracing)
+	}
 	// Other values should remain default
 	if newConfig.Schedule.Enabled != true {
 		t.Error("enabled should remain true")
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const exclusionColumns = "id, server_id, kind, target_id, title, reason, expires_at, created_at"
+
+// AddExclusion saves an exclusion and returns it as stored. Excluding something that is already
+// excluded on the server updates its title, reason and expiry, keeping its ID.
+func (db *DB) AddExclusion(exclusion Exclusion) (*Exclusion, error) {
+	_, err := db.conn.Exec(`
+		INSERT INTO exclusions (`+exclusionColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(server_id, kind, target_id) DO UPDATE SET
+			title = excluded.title,
+			reason = excluded.reason,
+			expires_at = excluded.expires_at
+	`, uuid.New().String(), exclusion.ServerID, exclusion.Kind, exclusion.TargetID, exclusion.Title, exclusion.Reason,
+		formatNullTime(exclusion.ExpiresAt), time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("saving exclusion: %w", err)
+	}
+
+	row := db.conn.QueryRow("SELECT "+exclusionColumns+" FROM exclusions WHERE server_id = ? AND kind = ? AND target_id = ?",
+		exclusion.ServerID, exclusion.Kind, exclusion.TargetID)
+	saved, err := scanExclusion(row)
+	if err != nil {
+		return nil, fmt.Errorf("reading saved exclusion: %w", err)
+	}
+	return &saved, nil
+}
+
+// GetExclusions returns all exclusions, including expired ones, most recent first.
+func (db *DB) GetExclusions() ([]Exclusion, error) {
+	return db.queryExclusions("SELECT " + exclusionColumns + " FROM exclusions ORDER BY created_at DESC, title")
+}
+
+// GetActiveExclusions returns a server's exclusions that have not expired by now.
+func (db *DB) GetActiveExclusions(serverID string, now time.Time) ([]Exclusion, error) {
+	return db.queryExclusions(`
+		SELECT `+exclusionColumns+` FROM exclusions
+		WHERE server_id = ? AND (expires_at IS NULL OR expires_at > ?)
+	`, serverID, now.UTC().Format(time.RFC3339))
+}
+
+// DeleteExclusion removes an exclusion by ID, reporting whether it existed.
+func (db *DB) DeleteExclusion(id string) (bool, error) {
+	result, err := db.conn.Exec("DELETE FROM exclusions WHERE id = ?", id)
+	if err != nil {
+		return false, fmt.Errorf("deleting exclusion: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("checking affected rows: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// DeleteExpiredExclusions removes exclusions that expired by now.
+// Returns the number of exclusions deleted.
+func (db *DB) DeleteExpiredExclusions(now time.Time) (int, error) {
+	result, err := db.conn.Exec("DELETE FROM exclusions WHERE expires_at IS NOT NULL AND expires_at <= ?", now.UTC().Format(time.RFC3339))
+	if err != nil {
+		return 0, fmt.Errorf("deleting expired exclusions: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("checking affected rows: %w", err)
+	}
+	return int(rows), nil
+}
+
+// queryExclusions runs a query selecting exclusionColumns and scans the results.
+func (db *DB) queryExclusions(query string, args ...any) ([]Exclusion, error) {
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying exclusions: %w", err)
+	}
+	defer rows.Close()
+
+	exclusions := []Exclusion{}
+	for rows.Next() {
+		exclusion, err := scanExclusion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning exclusion: %w", err)
+		}
+		exclusions = append(exclusions, exclusion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating exclusions: %w", err)
+	}
+
+	return exclusions, nil
+}
+
+// scanExclusion scans an exclusions row
+func scanExclusion(row rowScanner) (Exclusion, error) {
+	var exclusion Exclusion
+	var expiresAt sql.NullString
+	var createdAt string
+
+	if err := row.Scan(&exclusion.ID, &exclusion.ServerID, &exclusion.Kind, &exclusion.TargetID, &exclusion.Title,
+		&exclusion.Reason, &expiresAt, &createdAt); err != nil {
+		return exclusion, err
+	}
+
+	exclusion.ExpiresAt = parseNullTime(expiresAt)
+	exclusion.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return exclusion, nil
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExclusions(t *testing.T) {
+	db := testDB(t)
+
+	server, err := db.AddServer("sonarr", "http://localhost:8989", "key", ServerTypeSonarr)
+	if err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+
+	series, err := db.AddExclusion(Exclusion{ServerID: server.ID, Kind: ExcludeSeries, TargetID: 10, Title: "Show"})
+	if err != nil {
+		t.Fatalf("AddExclusion failed: %v", err)
+	}
+	if series.ID == "" || series.ExpiresAt != nil || series.CreatedAt.IsZero() {
+		t.Errorf("unexpected exclusion: %+v", series)
+	}
+
+	// Excluding the same series again updates it in place
+	expiresAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	updated, err := db.AddExclusion(Exclusion{ServerID: server.ID, Kind: ExcludeSeries, TargetID: 10, Title: "Show", Reason: "waiting for a release group", ExpiresAt: &expiresAt})
+	if err != nil {
+		t.Fatalf("AddExclusion failed: %v", err)
+	}
+	if updated.ID != series.ID || updated.Reason != "waiting for a release group" || updated.ExpiresAt == nil || !updated.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("expected the exclusion to be updated, got %+v", updated)
+	}
+
+	expired := time.Now().Add(-time.Hour)
+	if _, err := db.AddExclusion(Exclusion{ServerID: server.ID, Kind: ExcludeItem, TargetID: 5, ExpiresAt: &expired}); err != nil {
+		t.Fatalf("AddExclusion failed: %v", err)
+	}
+
+	all, err := db.GetExclusions()
+	if err != nil {
+		t.Fatalf("GetExclusions failed: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("expected 2 exclusions, got %d", len(all))
+	}
+
+	// Expired exclusions are no longer active, and can be cleaned up
+	active, err := db.GetActiveExclusions(server.ID, time.Now())
+	if err != nil {
+		t.Fatalf("GetActiveExclusions failed: %v", err)
+	}
+	if len(active) != 1 || active[0].ID != series.ID {
+		t.Errorf("expected only the series exclusion to be active, got %+v", active)
+	}
+	if deleted, err := db.DeleteExpiredExclusions(time.Now()); err != nil || deleted != 1 {
+		t.Errorf("DeleteExpiredExclusions = %d, %v", deleted, err)
+	}
+
+	if removed, err := db.DeleteExclusion(series.ID); err != nil || !removed {
+		t.Errorf("DeleteExclusion = %v, %v", removed, err)
+	}
+	if removed, _ := db.DeleteExclusion(series.ID); removed {
+		t.Error("expected deleting a missing exclusion to report false")
+	}
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// SaveGivenUpItem records that an item was given up on, replacing any earlier record of it.
+// The time it was first given up on is kept.
+func (db *DB) SaveGivenUpItem(item GivenUpItem) error {
+	_, err := db.conn.Exec(`
+		INSERT INTO given_up_items (server_id, category, item_id, title, policy, attempts, media_id, given_up_at, retry_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(server_id, category, item_id) DO UPDATE SET
+			title = excluded.title,
+			policy = excluded.policy,
+			attempts = excluded.attempts,
+			media_id = excluded.media_id,
+			retry_at = excluded.retry_at
+	`, item.ServerID, item.Category, item.ItemID, item.Title, item.Policy, item.Attempts, item.MediaID,
+		item.GivenUpAt.UTC().Format(time.RFC3339), formatNullTime(item.RetryAt))
+	if err != nil {
+		return fmt.Errorf("saving given up item: %w", err)
+	}
+	return nil
+}
+
+// GetGivenUpItems returns the items given up on across all servers, most recent first.
+func (db *DB) GetGivenUpItems() ([]GivenUpItem, error) {
+	rows, err := db.conn.Query(`
+		SELECT server_id, category, item_id, title, policy, attempts, media_id, given_up_at, retry_at
+		FROM given_up_items ORDER BY given_up_at DESC, title
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("querying given up items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []GivenUpItem{}
+	for rows.Next() {
+		item, err := scanGivenUpItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning given up item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating given up items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetGivenUpItem returns an item given up on, or nil if it isn't.
+func (db *DB) GetGivenUpItem(serverID string, category SearchCategory, itemID int) (*GivenUpItem, error) {
+	row := db.conn.QueryRow(`
+		SELECT server_id, category, item_id, title, policy, attempts, media_id, given_up_at, retry_at
+		FROM given_up_items WHERE server_id = ? AND category = ? AND item_id = ?
+	`, serverID, category, itemID)
+
+	item, err := scanGivenUpItem(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scanning given up item: %w", err)
+	}
+	return &item, nil
+}
+
+// ResetGivenUpItem forgets an item was given up on and resets its search attempts, so it is
+// searched like any other item. It reports whether the item was given up on.
+func (db *DB) ResetGivenUpItem(serverID string, category SearchCategory, itemID int) (bool, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return false, fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("DELETE FROM given_up_items WHERE server_id = ? AND category = ? AND item_id = ?", serverID, category, itemID)
+	if err != nil {
+		return false, fmt.Errorf("resetting given up item: %w", err)
+	}
+	if _, err := tx.Exec("UPDATE search_history SET attempts = 0 WHERE server_id = ? AND category = ? AND item_id = ?", serverID, category, itemID); err != nil {
+		return false, fmt.Errorf("resetting search attempts: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("committing give-up reset: %w", err)
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("checking affected rows: %w", err)
+	}
+	return removed > 0, nil
+}
+
+// scanGivenUpItem scans a given_up_items row
+func scanGivenUpItem(row rowScanner) (GivenUpItem, error) {
+	var item GivenUpItem
+	var givenUpAt string
+	var retryAt sql.NullString
+
+	if err := row.Scan(&item.ServerID, &item.Category, &item.ItemID, &item.Title, &item.Policy,
+		&item.Attempts, &item.MediaID, &givenUpAt, &retryAt); err != nil {
+		return item, err
+	}
+
+	item.GivenUpAt, _ = time.Parse(time.RFC3339, givenUpAt)
+	item.RetryAt = parseNullTime(retryAt)
+	return item, nil
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGivenUpItems(t *testing.T) {
+	db := testDB(t)
+
+	server, err := db.AddServer("radarr", "http://localhost:7878", "key", ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+
+	// Each search counts as an attempt
+	searchedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for i := 0; i < 3; i++ {
+		if err := db.RecordSearches(server.ID, SearchCategoryMissing, []int{1, 2}, 10+i, searchedAt); err != nil {
+			t.Fatalf("RecordSearches failed: %v", err)
+		}
+	}
+	if err := db.RecordSearches(server.ID, SearchCategoryMissing, []int{3}, 20, searchedAt); err != nil {
+		t.Fatalf("RecordSearches failed: %v", err)
+	}
+	attempts, err := db.GetSearchAttempts(server.ID, SearchCategoryMissing, 3)
+	if err != nil {
+		t.Fatalf("GetSearchAttempts failed: %v", err)
+	}
+	if len(attempts) != 2 || attempts[1].Attempts != 3 || !attempts[1].LastSearchedAt.Equal(searchedAt) {
+		t.Fatalf("expected items 1 and 2 with 3 attempts, got %+v", attempts)
+	}
+
+	givenUpAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	retryAt := givenUpAt.Add(48 * time.Hour)
+	for _, id := range []int{1, 2} {
+		item := GivenUpItem{ServerID: server.ID, Category: SearchCategoryMissing, ItemID: id, Title: "Movie", Policy: GiveUpBackoff, Attempts: 3, GivenUpAt: givenUpAt, RetryAt: &retryAt}
+		if err := db.SaveGivenUpItem(item); err != nil {
+			t.Fatalf("SaveGivenUpItem failed: %v", err)
+		}
+	}
+
+	// Saving an item again updates it but keeps when it was first given up on
+	later := GivenUpItem{ServerID: server.ID, Category: SearchCategoryMissing, ItemID: 1, Title: "Movie", Policy: GiveUpTag, Attempts: 4, MediaID: 1, GivenUpAt: time.Now()}
+	if err := db.SaveGivenUpItem(later); err != nil {
+		t.Fatalf("SaveGivenUpItem failed: %v", err)
+	}
+	item, err := db.GetGivenUpItem(server.ID, SearchCategoryMissing, 1)
+	if err != nil || item == nil {
+		t.Fatalf("GetGivenUpItem failed: %v", err)
+	}
+	if item.Policy != GiveUpTag || item.Attempts != 4 || item.RetryAt != nil || !item.GivenUpAt.Equal(givenUpAt) {
+		t.Errorf("unexpected given up item: %+v", item)
+	}
+
+	// Resetting forgets the item and its attempts
+	reset, err := db.ResetGivenUpItem(server.ID, SearchCategoryMissing, 1)
+	if err != nil || !reset {
+		t.Fatalf("ResetGivenUpItem = %v, %v", reset, err)
+	}
+	if reset, _ := db.ResetGivenUpItem(server.ID, SearchCategoryMissing, 1); reset {
+		t.Error("expected resetting an item twice to report false")
+	}
+
+	// A grab also forgets the item
+	if _, err := db.MarkSearchesGrabbed(server.ID, map[int]time.Time{2: time.Now()}, 2*time.Hour); err != nil {
+		t.Fatalf("MarkSearchesGrabbed failed: %v", err)
+	}
+
+	items, err := db.GetGivenUpItems()
+	if err != nil {
+		t.Fatalf("GetGivenUpItems failed: %v", err)
+	}
+	if len(items) != 0 {
+		t.Errorf("expected no given up items, got %+v", items)
+	}
+	attempts, err = db.GetSearchAttempts(server.ID, SearchCategoryMissing, 1)
+	if err != nil {
+		t.Fatalf("GetSearchAttempts failed: %v", err)
+	}
+	if len(attempts) != 1 || attempts[3].Attempts != 1 {
+		t.Errorf("expected only item 3 to have attempts left, got %+v", attempts)
+	}
+}
@@ -0,0 +1,12 @@
+-- Track when each item was last searched so allocation can rotate through the backlog
+CREATE TABLE IF NOT EXISTS search_history (
+  server_id TEXT NOT NULL,
+  item_id INTEGER NOT NULL,
+  category TEXT NOT NULL,
+  last_searched_at TEXT NOT NULL,
+  PRIMARY KEY (server_id, item_id, category),
+  FOREIGN KEY (server_id) REFERENCES servers(id) ON DELETE CASCADE
+);
+
+-- Create index for cooldown lookups by server and category
+CREATE INDEX IF NOT EXISTS idx_search_history_server_category ON search_history(server_id, category, last_searched_at);
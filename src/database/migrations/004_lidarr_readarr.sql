@@ -0,0 +1,25 @@
+-- Allow Lidarr and Readarr servers.
+-- SQLite cannot alter a CHECK constraint, so the servers table is rebuilt.
+-- Foreign keys are disabled while rebuilding so dropping the old table
+-- doesn't cascade into search_history.
+PRAGMA foreign_keys=OFF;
+
+CREATE TABLE servers_new (
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL UNIQUE,
+  url TEXT NOT NULL,
+  api_key TEXT NOT NULL,
+  type TEXT NOT NULL CHECK(type IN ('radarr', 'sonarr', 'lidarr', 'readarr')),
+  enabled INTEGER DEFAULT 1,
+  created_at TEXT NOT NULL,
+  updated_at TEXT NOT NULL
+);
+
+INSERT INTO servers_new (id, name, url, api_key, type, enabled, created_at, updated_at)
+SELECT id, name, url, api_key, type, enabled, created_at, updated_at FROM servers;
+
+DROP TABLE servers;
+
+ALTER TABLE servers_new RENAME TO servers;
+
+PRAGMA foreign_keys=ON;
@@ -0,0 +1,5 @@
+-- Per-server overrides for search allocation (NULL max means no per-server cap)
+ALTER TABLE servers ADD COLUMN max_missing INTEGER;
+ALTER TABLE servers ADD COLUMN max_cutoff INTEGER;
+ALTER TABLE servers ADD COLUMN weight REAL NOT NULL DEFAULT 1;
+ALTER TABLE servers ADD COLUMN never_cutoff INTEGER NOT NULL DEFAULT 0;
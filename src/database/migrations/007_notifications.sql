@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS notification_channels (
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL UNIQUE,
+  provider TEXT NOT NULL,
+  url TEXT NOT NULL,
+  token TEXT NOT NULL DEFAULT '',
+  events TEXT NOT NULL DEFAULT '',
+  title_template TEXT NOT NULL DEFAULT '',
+  body_template TEXT NOT NULL DEFAULT '',
+  enabled INTEGER NOT NULL DEFAULT 1,
+  created_at TEXT NOT NULL,
+  updated_at TEXT NOT NULL
+);
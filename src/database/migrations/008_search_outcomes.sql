@@ -0,0 +1,8 @@
+-- Track the command behind each search and what came of it.
+-- Searches recorded before this migration keep an empty outcome.
+ALTER TABLE search_history ADD COLUMN command_id INTEGER;
+ALTER TABLE search_history ADD COLUMN outcome TEXT NOT NULL DEFAULT '';
+ALTER TABLE search_history ADD COLUMN outcome_at TEXT;
+
+-- Create index for finding searches still awaiting an outcome
+CREATE INDEX IF NOT EXISTS idx_search_history_outcome ON search_history(server_id, outcome);
@@ -0,0 +1,12 @@
+-- Health of each server across automation cycles, used to skip servers that keep failing.
+-- Servers without a row are healthy.
+CREATE TABLE IF NOT EXISTS server_health (
+  server_id TEXT PRIMARY KEY,
+  state TEXT NOT NULL DEFAULT 'healthy',
+  consecutive_failures INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT NOT NULL DEFAULT '',
+  last_success_at TEXT,
+  last_failure_at TEXT,
+  open_until TEXT,
+  FOREIGN KEY (server_id) REFERENCES servers(id) ON DELETE CASCADE
+);
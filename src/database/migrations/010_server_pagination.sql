@@ -0,0 +1,3 @@
+-- Per-server overrides for fetching wanted lists (0 means use the default)
+ALTER TABLE servers ADD COLUMN page_size INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE servers ADD COLUMN page_concurrency INTEGER NOT NULL DEFAULT 0;
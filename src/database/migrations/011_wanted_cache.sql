@@ -0,0 +1,21 @@
+-- Cached wanted lists, so detection can refresh incrementally and backlog counts can be shown
+-- without contacting the servers. Items keep the order the server returned them in.
+CREATE TABLE IF NOT EXISTS wanted_items (
+  server_id TEXT NOT NULL,
+  category TEXT NOT NULL CHECK (category IN ('missing', 'cutoff')),
+  item_id INTEGER NOT NULL,
+  position INTEGER NOT NULL,
+  data TEXT NOT NULL,
+  PRIMARY KEY (server_id, category, item_id),
+  FOREIGN KEY (server_id) REFERENCES servers(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_wanted_items_position ON wanted_items(server_id, category, position);
+
+-- When each server's wanted lists were last read in full and last brought up to date
+CREATE TABLE IF NOT EXISTS wanted_cache (
+  server_id TEXT PRIMARY KEY,
+  full_refresh_at TEXT NOT NULL,
+  refreshed_at TEXT NOT NULL,
+  FOREIGN KEY (server_id) REFERENCES servers(id) ON DELETE CASCADE
+);
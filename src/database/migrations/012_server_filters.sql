@@ -0,0 +1,6 @@
+-- Per-server rules deciding which wanted items are searched, stored as JSON
+ALTER TABLE servers ADD COLUMN filters TEXT NOT NULL DEFAULT '{}';
+
+-- Cached items predate the tags, paths and statuses the filters match on, so read them again
+DELETE FROM wanted_items;
+DELETE FROM wanted_cache;
@@ -0,0 +1,15 @@
+-- Pairs of servers of the same type that share indexers, and how items wanted on both are searched
+CREATE TABLE IF NOT EXISTS server_pairs (
+  primary_id TEXT NOT NULL,
+  secondary_id TEXT NOT NULL,
+  policy TEXT NOT NULL CHECK (policy IN ('search-both', 'prefer-primary', 'stagger')),
+  created_at TEXT NOT NULL,
+  PRIMARY KEY (primary_id, secondary_id),
+  CHECK (primary_id <> secondary_id),
+  FOREIGN KEY (primary_id) REFERENCES servers(id) ON DELETE CASCADE,
+  FOREIGN KEY (secondary_id) REFERENCES servers(id) ON DELETE CASCADE
+);
+
+-- Cached items predate the TMDB, IMDb and TVDB IDs paired servers are matched on, so read them again
+DELETE FROM wanted_items;
+DELETE FROM wanted_cache;
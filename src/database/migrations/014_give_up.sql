@@ -0,0 +1,19 @@
+-- Count the searches of each item since a release was last grabbed for it, so items that are
+-- never found can be given up on. Items searched before this migration count one attempt.
+ALTER TABLE search_history ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
+UPDATE search_history SET attempts = 1 WHERE outcome <> 'grabbed';
+
+-- Items Janitarr has given up searching, and what it did about them
+CREATE TABLE IF NOT EXISTS given_up_items (
+  server_id TEXT NOT NULL,
+  category TEXT NOT NULL CHECK (category IN ('missing', 'cutoff')),
+  item_id INTEGER NOT NULL,
+  title TEXT NOT NULL,
+  policy TEXT NOT NULL CHECK (policy IN ('backoff', 'snooze', 'tag', 'unmonitor')),
+  attempts INTEGER NOT NULL,
+  media_id INTEGER NOT NULL DEFAULT 0,
+  given_up_at TEXT NOT NULL,
+  retry_at TEXT,
+  PRIMARY KEY (server_id, category, item_id),
+  FOREIGN KEY (server_id) REFERENCES servers(id) ON DELETE CASCADE
+);
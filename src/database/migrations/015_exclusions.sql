@@ -0,0 +1,13 @@
+-- Items that must never be searched automatically, matched by item, series, TMDB or TVDB ID
+CREATE TABLE IF NOT EXISTS exclusions (
+  id TEXT PRIMARY KEY,
+  server_id TEXT NOT NULL,
+  kind TEXT NOT NULL CHECK (kind IN ('item', 'series', 'tmdb', 'tvdb')),
+  target_id INTEGER NOT NULL,
+  title TEXT NOT NULL DEFAULT '',
+  reason TEXT NOT NULL DEFAULT '',
+  expires_at TEXT,
+  created_at TEXT NOT NULL,
+  UNIQUE (server_id, kind, target_id),
+  FOREIGN KEY (server_id) REFERENCES servers(id) ON DELETE CASCADE
+);
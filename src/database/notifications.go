@@ -0,0 +1,250 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationChannelUpdate represents optional fields for updating a notification channel
+type NotificationChannelUpdate struct {
+	Name          *string
+	URL           *string
+	Token         *string
+	Events        []NotificationEvent // Replaces the subscribed events when non-nil
+	TitleTemplate *string
+	BodyTemplate  *string
+	Enabled       *bool
+}
+
+const notificationChannelColumns = `id, name, provider, url, token, events, title_template, body_template, enabled, created_at, updated_at`
+
+// AddNotificationChannel adds a new notification channel
+func (db *DB) AddNotificationChannel(channel NotificationChannel) (*NotificationChannel, error) {
+	if channel.Name == "" {
+		return nil, fmt.Errorf("channel name is required")
+	}
+	if channel.URL == "" {
+		return nil, fmt.Errorf("channel URL is required")
+	}
+	if !IsValidNotificationProvider(string(channel.Provider)) {
+		return nil, fmt.Errorf("unknown notification provider: %s", channel.Provider)
+	}
+
+	encryptedToken, err := db.encryptToken(channel.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	channel.ID = uuid.New().String()
+	channel.HasToken = channel.Token != ""
+	channel.CreatedAt = now
+	channel.UpdatedAt = now
+
+	_, err = db.conn.Exec(`
+		INSERT INTO notification_channels (`+notificationChannelColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, channel.ID, channel.Name, channel.Provider, channel.URL, encryptedToken, joinEvents(channel.Events),
+		channel.TitleTemplate, channel.BodyTemplate, boolToInt(channel.Enabled),
+		now.Format(time.RFC3339), now.Format(time.RFC3339))
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, fmt.Errorf("notification channel with name '%s' already exists", channel.Name)
+		}
+		return nil, fmt.Errorf("inserting notification channel: %w", err)
+	}
+
+	return &channel, nil
+}
+
+// GetNotificationChannel retrieves a notification channel by ID, returning nil if it does not exist
+func (db *DB) GetNotificationChannel(id string) (*NotificationChannel, error) {
+	rows, err := db.conn.Query(`SELECT `+notificationChannelColumns+` FROM notification_channels WHERE id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("querying notification channel: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	return db.scanNotificationChannel(rows)
+}
+
+// GetNotificationChannels retrieves all notification channels
+func (db *DB) GetNotificationChannels() ([]NotificationChannel, error) {
+	rows, err := db.conn.Query(`SELECT ` + notificationChannelColumns + ` FROM notification_channels ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("querying notification channels: %w", err)
+	}
+	defer rows.Close()
+
+	var channels []NotificationChannel
+	for rows.Next() {
+		channel, err := db.scanNotificationChannel(rows)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, *channel)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating notification channels: %w", err)
+	}
+
+	return channels, nil
+}
+
+// UpdateNotificationChannel updates a notification channel's fields
+func (db *DB) UpdateNotificationChannel(id string, updates *NotificationChannelUpdate) error {
+	if updates == nil {
+		return nil
+	}
+
+	var setClauses []string
+	var args []any
+
+	if updates.Name != nil {
+		setClauses = append(setClauses, "name = ?")
+		args = append(args, *updates.Name)
+	}
+	if updates.URL != nil {
+		setClauses = append(setClauses, "url = ?")
+		args = append(args, *updates.URL)
+	}
+	if updates.Token != nil {
+		encryptedToken, err := db.encryptToken(*updates.Token)
+		if err != nil {
+			return err
+		}
+		setClauses = append(setClauses, "token = ?")
+		args = append(args, encryptedToken)
+	}
+	if updates.Events != nil {
+		setClauses = append(setClauses, "events = ?")
+		args = append(args, joinEvents(updates.Events))
+	}
+	if updates.TitleTemplate != nil {
+		setClauses = append(setClauses, "title_template = ?")
+		args = append(args, *updates.TitleTemplate)
+	}
+	if updates.BodyTemplate != nil {
+		setClauses = append(setClauses, "body_template = ?")
+		args = append(args, *updates.BodyTemplate)
+	}
+	if updates.Enabled != nil {
+		setClauses = append(setClauses, "enabled = ?")
+		args = append(args, boolToInt(*updates.Enabled))
+	}
+
+	if len(setClauses) == 0 {
+		return nil // Nothing to update
+	}
+
+	setClauses = append(setClauses, "updated_at = ?")
+	args = append(args, time.Now().UTC().Format(time.RFC3339), id)
+
+	query := fmt.Sprintf("UPDATE notification_channels SET %s WHERE id = ?", strings.Join(setClauses, ", "))
+	result, err := db.conn.Exec(query, args...)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return fmt.Errorf("notification channel with name '%s' already exists", *updates.Name)
+		}
+		return fmt.Errorf("updating notification channel: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("checking affected rows: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("notification channel not found: %s", id)
+	}
+
+	return nil
+}
+
+// DeleteNotificationChannel removes a notification channel
+func (db *DB) DeleteNotificationChannel(id string) (bool, error) {
+	result, err := db.conn.Exec("DELETE FROM notification_channels WHERE id = ?", id)
+	if err != nil {
+		return false, fmt.Errorf("deleting notification channel: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("checking affected rows: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// scanNotificationChannel scans a notification channel from the current row
+func (db *DB) scanNotificationChannel(rows *sql.Rows) (*NotificationChannel, error) {
+	var channel NotificationChannel
+	var encryptedToken, events, createdAt, updatedAt string
+	var enabled int
+
+	err := rows.Scan(&channel.ID, &channel.Name, &channel.Provider, &channel.URL, &encryptedToken, &events,
+		&channel.TitleTemplate, &channel.BodyTemplate, &enabled, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("scanning notification channel: %w", err)
+	}
+
+	if encryptedToken != "" {
+		token, err := db.decryptAPIKey(encryptedToken)
+		if err != nil {
+			return nil, fmt.Errorf("decrypting notification token: %w", err)
+		}
+		channel.Token = token
+		channel.HasToken = true
+	}
+
+	channel.Events = splitEvents(events)
+	channel.Enabled = enabled == 1
+	channel.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	channel.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	return &channel, nil
+}
+
+// encryptToken encrypts a provider token for storage, leaving empty tokens empty
+func (db *DB) encryptToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	encrypted, err := db.encryptAPIKey(token)
+	if err != nil {
+		return "", fmt.Errorf("encrypting notification token: %w", err)
+	}
+	return encrypted, nil
+}
+
+func joinEvents(events []NotificationEvent) string {
+	parts := make([]string, len(events))
+	for i, e := range events {
+		parts[i] = string(e)
+	}
+	return strings.Join(parts, ",")
+}
+
+func splitEvents(events string) []NotificationEvent {
+	result := []NotificationEvent{}
+	for _, e := range strings.Split(events, ",") {
+		if e != "" {
+			result = append(result, NotificationEvent(e))
+		}
+	}
+	return result
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
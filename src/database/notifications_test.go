@@ -0,0 +1,72 @@
+package database
+
+import (
+	"testing"
+)
+
+func TestNotificationChannelCRUD(t *testing.T) {
+	db := testDB(t)
+
+	channel, err := db.AddNotificationChannel(NotificationChannel{
+		Name:     "gotify",
+		Provider: NotificationGotify,
+		URL:      "http://gotify.local",
+		Token:    "secret",
+		Events:   []NotificationEvent{NotificationEventCycleEnd, NotificationEventRateLimit},
+		Enabled:  true,
+	})
+	if err != nil {
+		t.Fatalf("AddNotificationChannel failed: %v", err)
+	}
+
+	got, err := db.GetNotificationChannel(channel.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetNotificationChannel failed: %v", err)
+	}
+	if got.Token != "secret" || !got.HasToken {
+		t.Errorf("expected token to round-trip, got %q", got.Token)
+	}
+	if !got.Subscribes(NotificationEventRateLimit) || got.Subscribes(NotificationEventServerError) {
+		t.Errorf("unexpected events: %v", got.Events)
+	}
+
+	// Tokens are encrypted at rest
+	var stored string
+	if err := db.conn.QueryRow("SELECT token FROM notification_channels WHERE id = ?", channel.ID).Scan(&stored); err != nil {
+		t.Fatalf("reading token: %v", err)
+	}
+	if stored == "secret" {
+		t.Error("expected token to be encrypted")
+	}
+
+	if _, err := db.AddNotificationChannel(NotificationChannel{Name: "gotify", Provider: NotificationWebhook, URL: "http://x"}); err == nil {
+		t.Error("expected duplicate name to fail")
+	}
+	if _, err := db.AddNotificationChannel(NotificationChannel{Name: "other", Provider: "pager", URL: "http://x"}); err == nil {
+		t.Error("expected unknown provider to fail")
+	}
+
+	disabled := false
+	if err := db.UpdateNotificationChannel(channel.ID, &NotificationChannelUpdate{
+		Enabled: &disabled,
+		Events:  []NotificationEvent{NotificationEventServerError},
+	}); err != nil {
+		t.Fatalf("UpdateNotificationChannel failed: %v", err)
+	}
+
+	channels, err := db.GetNotificationChannels()
+	if err != nil {
+		t.Fatalf("GetNotificationChannels failed: %v", err)
+	}
+	if len(channels) != 1 || channels[0].Enabled || !channels[0].Subscribes(NotificationEventServerError) {
+		t.Errorf("expected updated channel, got %+v", channels)
+	}
+
+	deleted, err := db.DeleteNotificationChannel(channel.ID)
+	if err != nil || !deleted {
+		t.Fatalf("DeleteNotificationChannel failed: %v", err)
+	}
+	if got, _ := db.GetNotificationChannel(channel.ID); got != nil {
+		t.Error("expected channel to be deleted")
+	}
+}
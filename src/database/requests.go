@@ -0,0 +1,34 @@
+package database
+
+import "fmt"
+
+// configKeyRequestsAPIKey holds the Overseerr or Jellyseerr API key, encrypted like server API keys.
+// It is kept out of AppConfig so it is never returned by the config API.
+const configKeyRequestsAPIKey = "requests.apiKey"
+
+// SetRequestsAPIKey encrypts and stores the Overseerr or Jellyseerr API key. An empty key removes it.
+func (db *DB) SetRequestsAPIKey(apiKey string) error {
+	if apiKey == "" {
+		return db.SetConfig(configKeyRequestsAPIKey, "")
+	}
+
+	encrypted, err := db.encryptAPIKey(apiKey)
+	if err != nil {
+		return fmt.Errorf("encrypting requests API key: %w", err)
+	}
+	return db.SetConfig(configKeyRequestsAPIKey, encrypted)
+}
+
+// GetRequestsAPIKey returns the Overseerr or Jellyseerr API key, or "" if none is stored or it cannot be decrypted.
+func (db *DB) GetRequestsAPIKey() string {
+	val := db.GetConfig(configKeyRequestsAPIKey)
+	if val == nil || *val == "" {
+		return ""
+	}
+
+	apiKey, err := db.decryptAPIKey(*val)
+	if err != nil {
+		return ""
+	}
+	return apiKey
+}
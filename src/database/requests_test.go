@@ -0,0 +1,40 @@
+package database
+
+import "testing"
+
+func TestRequestsAPIKey(t *testing.T) {
+	db := testDB(t)
+
+	if db.GetRequestsAPIKey() != "" || db.GetAppConfig().Requests.HasAPIKey {
+		t.Fatal("expected no requests API key by default")
+	}
+
+	if err := db.SetRequestsAPIKey("seerr-key"); err != nil {
+		t.Fatalf("SetRequestsAPIKey failed: %v", err)
+	}
+	if stored := db.GetConfig(configKeyRequestsAPIKey); stored == nil || *stored == "seerr-key" {
+		t.Errorf("expected the API key to be stored encrypted, got %v", stored)
+	}
+	if got := db.GetRequestsAPIKey(); got != "seerr-key" {
+		t.Errorf("expected the API key back, got %q", got)
+	}
+	if !db.GetAppConfig().Requests.HasAPIKey {
+		t.Error("expected the config to report a stored API key")
+	}
+
+	// Saving the config keeps the key, and an empty key removes it
+	config := db.GetAppConfig()
+	config.Requests.URL = "http://overseerr:5055"
+	if err := db.SetAppConfig(config); err != nil {
+		t.Fatalf("SetAppConfig failed: %v", err)
+	}
+	if db.GetRequestsAPIKey() != "seerr-key" || db.GetAppConfig().Requests.URL != "http://overseerr:5055" {
+		t.Errorf("expected URL and key to be kept, got %+v", db.GetAppConfig().Requests)
+	}
+	if err := db.SetRequestsAPIKey(""); err != nil {
+		t.Fatalf("SetRequestsAPIKey failed: %v", err)
+	}
+	if db.GetRequestsAPIKey() != "" || db.GetAppConfig().Requests.HasAPIKey {
+		t.Error("expected the API key to be removed")
+	}
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"time"
+)
+
+// Scheduler run times are runtime state rather than settings, so they live in the
+// config table under their own keys and are not part of AppConfig.
+const (
+	configKeyLastRun = "schedule.lastRun"
+	configKeyNextRun = "schedule.nextRun"
+)
+
+// GetSchedulerRunTimes returns the persisted last and next scheduled run times (nil if never set)
+func (db *DB) GetSchedulerRunTimes() (lastRun, nextRun *time.Time) {
+	return db.getConfigTime(configKeyLastRun), db.getConfigTime(configKeyNextRun)
+}
+
+// SetSchedulerLastRun persists when the scheduler last completed a cycle
+func (db *DB) SetSchedulerLastRun(t time.Time) error {
+	return db.SetConfig(configKeyLastRun, t.UTC().Format(time.RFC3339))
+}
+
+// SetSchedulerNextRun persists when the scheduler plans to run next
+func (db *DB) SetSchedulerNextRun(t time.Time) error {
+	return db.SetConfig(configKeyNextRun, t.UTC().Format(time.RFC3339))
+}
+
+// getConfigTime reads an RFC3339 timestamp from the config table
+func (db *DB) getConfigTime(key string) *time.Time {
+	val := db.GetConfig(key)
+	if val == nil {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, *val)
+	if err != nil {
+		return nil
+	}
+	t = t.Local() // Cron expressions and time windows are evaluated in local time
+	return &t
+}
@@ -0,0 +1,40 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedulerRunTimes(t *testing.T) {
+	db := testDB(t)
+
+	lastRun, nextRun := db.GetSchedulerRunTimes()
+	if lastRun != nil || nextRun != nil {
+		t.Fatalf("expected no run times on a fresh database, got %v %v", lastRun, nextRun)
+	}
+
+	last := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	next := last.Add(6 * time.Hour)
+	if err := db.SetSchedulerLastRun(last); err != nil {
+		t.Fatalf("SetSchedulerLastRun failed: %v", err)
+	}
+	if err := db.SetSchedulerNextRun(next); err != nil {
+		t.Fatalf("SetSchedulerNextRun failed: %v", err)
+	}
+
+	lastRun, nextRun = db.GetSchedulerRunTimes()
+	if lastRun == nil || !lastRun.Equal(last) {
+		t.Errorf("expected last run %v, got %v", last, lastRun)
+	}
+	if nextRun == nil || !nextRun.Equal(next) {
+		t.Errorf("expected next run %v, got %v", next, nextRun)
+	}
+
+	// Saving settings must not clear the persisted run times
+	if err := db.SetAppConfig(db.GetAppConfig()); err != nil {
+		t.Fatalf("SetAppConfig failed: %v", err)
+	}
+	if lastRun, _ = db.GetSchedulerRunTimes(); lastRun == nil {
+		t.Error("expected last run to survive a config update")
+	}
+}
@@ -0,0 +1,267 @@
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// RecordSearches stores the time the given items were searched on a server, along with the
+// ID of the command that runs the search (0 if unknown). Searches with a command start out
+// pending until the command's outcome is recorded.
+// Existing entries are updated in place so each item keeps a single row per category, counting
+// the item's search attempts.
+func (db *DB) RecordSearches(serverID string, category SearchCategory, itemIDs []int, commandID int, searchedAt time.Time) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO search_history (server_id, item_id, category, last_searched_at, command_id, outcome, outcome_at, attempts)
+		VALUES (?, ?, ?, ?, ?, ?, NULL, 1)
+		ON CONFLICT(server_id, item_id, category) DO UPDATE SET
+			last_searched_at = excluded.last_searched_at,
+			command_id = excluded.command_id,
+			outcome = excluded.outcome,
+			outcome_at = NULL,
+			attempts = search_history.attempts + 1
+	`)
+	if err != nil {
+		return fmt.Errorf("preparing search history insert: %w", err)
+	}
+	defer stmt.Close()
+
+	var command any
+	outcome := SearchOutcome("")
+	if commandID > 0 {
+		command = commandID
+		outcome = SearchOutcomePending
+	}
+
+	timestamp := searchedAt.UTC().Format(time.RFC3339)
+	for _, itemID := range itemIDs {
+		if _, err := stmt.Exec(serverID, itemID, category, timestamp, command, outcome); err != nil {
+			return fmt.Errorf("recording search for item %d: %w", itemID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing search history: %w", err)
+	}
+	return nil
+}
+
+// GetSearchHistory returns the last search time for every recorded item on a server and category,
+// indexed by item ID.
+func (db *DB) GetSearchHistory(serverID string, category SearchCategory) (map[int]time.Time, error) {
+	rows, err := db.conn.Query(`
+		SELECT item_id, last_searched_at FROM search_history
+		WHERE server_id = ? AND category = ?
+	`, serverID, category)
+	if err != nil {
+		return nil, fmt.Errorf("querying search history: %w", err)
+	}
+	defer rows.Close()
+
+	history := make(map[int]time.Time)
+	for rows.Next() {
+		var itemID int
+		var searchedAt string
+		if err := rows.Scan(&itemID, &searchedAt); err != nil {
+			return nil, fmt.Errorf("scanning search history: %w", err)
+		}
+		if t, err := time.Parse(time.RFC3339, searchedAt); err == nil {
+			history[itemID] = t
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating search history: %w", err)
+	}
+
+	return history, nil
+}
+
+// GetSearchAttempts returns the items on a server and category searched at least min times since
+// a release was last grabbed for them, indexed by item ID.
+func (db *DB) GetSearchAttempts(serverID string, category SearchCategory, min int) (map[int]SearchAttempts, error) {
+	rows, err := db.conn.Query(`
+		SELECT item_id, attempts, last_searched_at FROM search_history
+		WHERE server_id = ? AND category = ? AND attempts >= ?
+	`, serverID, category, min)
+	if err != nil {
+		return nil, fmt.Errorf("querying search attempts: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := make(map[int]SearchAttempts)
+	for rows.Next() {
+		var itemID int
+		var entry SearchAttempts
+		var searchedAt string
+		if err := rows.Scan(&itemID, &entry.Attempts, &searchedAt); err != nil {
+			return nil, fmt.Errorf("scanning search attempts: %w", err)
+		}
+		entry.LastSearchedAt, _ = time.Parse(time.RFC3339, searchedAt)
+		attempts[itemID] = entry
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating search attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// GetRecentlySearched returns the set of item IDs searched on a server and category since the given time.
+func (db *DB) GetRecentlySearched(serverID string, category SearchCategory, since time.Time) (map[int]bool, error) {
+	rows, err := db.conn.Query(`
+		SELECT item_id FROM search_history
+		WHERE server_id = ? AND category = ? AND last_searched_at >= ?
+	`, serverID, category, since.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("querying recent searches: %w", err)
+	}
+	defer rows.Close()
+
+	recent := make(map[int]bool)
+	for rows.Next() {
+		var itemID int
+		if err := rows.Scan(&itemID); err != nil {
+			return nil, fmt.Errorf("scanning recent search: %w", err)
+		}
+		recent[itemID] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating recent searches: %w", err)
+	}
+
+	return recent, nil
+}
+
+// GetPendingSearchCommands returns the commands on a server whose searches are still awaiting an outcome.
+func (db *DB) GetPendingSearchCommands(serverID string) ([]PendingSearchCommand, error) {
+	rows, err := db.conn.Query(`
+		SELECT command_id, MIN(last_searched_at) FROM search_history
+		WHERE server_id = ? AND outcome = ? AND command_id IS NOT NULL
+		GROUP BY command_id
+		ORDER BY command_id
+	`, serverID, SearchOutcomePending)
+	if err != nil {
+		return nil, fmt.Errorf("querying pending search commands: %w", err)
+	}
+	defer rows.Close()
+
+	var commands []PendingSearchCommand
+	for rows.Next() {
+		var command PendingSearchCommand
+		var searchedAt string
+		if err := rows.Scan(&command.CommandID, &searchedAt); err != nil {
+			return nil, fmt.Errorf("scanning pending search command: %w", err)
+		}
+		command.SearchedAt, _ = time.Parse(time.RFC3339, searchedAt)
+		commands = append(commands, command)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating pending search commands: %w", err)
+	}
+
+	return commands, nil
+}
+
+// SetSearchCommandOutcome records the outcome of the pending searches started by a command.
+// Returns the number of searches updated.
+func (db *DB) SetSearchCommandOutcome(serverID string, commandID int, outcome SearchOutcome, at time.Time) (int, error) {
+	result, err := db.conn.Exec(`
+		UPDATE search_history SET outcome = ?, outcome_at = ?
+		WHERE server_id = ? AND command_id = ? AND outcome = ?
+	`, outcome, at.UTC().Format(time.RFC3339), serverID, commandID, SearchOutcomePending)
+	if err != nil {
+		return 0, fmt.Errorf("setting search outcome: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("checking affected rows: %w", err)
+	}
+
+	return int(rows), nil
+}
+
+// MarkSearchesGrabbed marks searches as grabbed for items that had a release grabbed within the
+// window after they were last searched. grabs maps item IDs to the time of the grab.
+// A grab resets the item's search attempts, so it is no longer given up on.
+// Returns the number of searches updated.
+func (db *DB) MarkSearchesGrabbed(serverID string, grabs map[int]time.Time, window time.Duration) (int, error) {
+	if len(grabs) == 0 {
+		return 0, nil
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		UPDATE search_history SET outcome = ?, outcome_at = ?, attempts = 0
+		WHERE server_id = ? AND item_id = ? AND outcome IN (?, ?)
+			AND last_searched_at <= ? AND last_searched_at >= ?
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("preparing grab update: %w", err)
+	}
+	defer stmt.Close()
+
+	release, err := tx.Prepare("DELETE FROM given_up_items WHERE server_id = ? AND item_id = ?")
+	if err != nil {
+		return 0, fmt.Errorf("preparing give-up reset: %w", err)
+	}
+	defer release.Close()
+
+	updated := 0
+	for itemID, grabbedAt := range grabs {
+		timestamp := grabbedAt.UTC().Format(time.RFC3339)
+		earliest := grabbedAt.Add(-window).UTC().Format(time.RFC3339)
+		result, err := stmt.Exec(SearchOutcomeGrabbed, timestamp, serverID, itemID, SearchOutcomePending, SearchOutcomeCompleted, timestamp, earliest)
+		if err != nil {
+			return 0, fmt.Errorf("marking item %d grabbed: %w", itemID, err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil || rows == 0 {
+			continue
+		}
+		updated += int(rows)
+		if _, err := release.Exec(serverID, itemID); err != nil {
+			return 0, fmt.Errorf("resetting give-up for item %d: %w", itemID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing grab updates: %w", err)
+	}
+	return updated, nil
+}
+
+// ClearSearchHistory removes all search history for a server.
+// Returns the number of rows deleted.
+func (db *DB) ClearSearchHistory(serverID string) (int, error) {
+	result, err := db.conn.Exec("DELETE FROM search_history WHERE server_id = ?", serverID)
+	if err != nil {
+		return 0, fmt.Errorf("clearing search history: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("checking affected rows: %w", err)
+	}
+
+	return int(rows), nil
+}
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSearchHistoryRecordAndGet(t *testing.T) {
+	db := testDB(t)
+
+	server, err := db.AddServer("radarr1", "http://localhost:7878", "key", ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+
+	old := time.Now().Add(-48 * time.Hour)
+	if err := db.RecordSearches(server.ID, SearchCategoryMissing, []int{1, 2}, 0, old); err != nil {
+		t.Fatalf("RecordSearches failed: %v", err)
+	}
+
+	// Re-recording an item updates its timestamp rather than adding a row
+	now := time.Now()
+	if err := db.RecordSearches(server.ID, SearchCategoryMissing, []int{2}, 0, now); err != nil {
+		t.Fatalf("RecordSearches failed: %v", err)
+	}
+
+	history, err := db.GetSearchHistory(server.ID, SearchCategoryMissing)
+	if err != nil {
+		t.Fatalf("GetSearchHistory failed: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 history entries, got %d", len(history))
+	}
+	if history[2].Unix() != now.Unix() {
+		t.Errorf("expected item 2 last searched at %v, got %v", now, history[2])
+	}
+
+	// Categories are tracked independently
+	cutoff, err := db.GetSearchHistory(server.ID, SearchCategoryCutoff)
+	if err != nil {
+		t.Fatalf("GetSearchHistory failed: %v", err)
+	}
+	if len(cutoff) != 0 {
+		t.Errorf("expected no cutoff history, got %d", len(cutoff))
+	}
+}
+
+func TestGetRecentlySearched(t *testing.T) {
+	db := testDB(t)
+
+	server, err := db.AddServer("sonarr1", "http://localhost:8989", "key", ServerTypeSonarr)
+	if err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+
+	_ = db.RecordSearches(server.ID, SearchCategoryCutoff, []int{10}, 0, time.Now().Add(-72*time.Hour))
+	_ = db.RecordSearches(server.ID, SearchCategoryCutoff, []int{20, 30}, 0, time.Now().Add(-1*time.Hour))
+
+	recent, err := db.GetRecentlySearched(server.ID, SearchCategoryCutoff, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetRecentlySearched failed: %v", err)
+	}
+	if len(recent) != 2 || !recent[20] || !recent[30] {
+		t.Errorf("expected items 20 and 30 to be recent, got %v", recent)
+	}
+}
+
+func TestClearSearchHistory(t *testing.T) {
+	db := testDB(t)
+
+	server, err := db.AddServer("radarr1", "http://localhost:7878", "key", ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+	_ = db.RecordSearches(server.ID, SearchCategoryMissing, []int{1, 2, 3}, 0, time.Now())
+
+	deleted, err := db.ClearSearchHistory(server.ID)
+	if err != nil {
+		t.Fatalf("ClearSearchHistory failed: %v", err)
+	}
+	if deleted != 3 {
+		t.Errorf("expected 3 rows deleted, got %d", deleted)
+	}
+}
+
+func TestSearchOutcomes(t *testing.T) {
+	db := testDB(t)
+
+	server, err := db.AddServer("radarr1", "http://localhost:7878", "key", ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+
+	searchedAt := time.Now().Add(-2 * time.Hour)
+	_ = db.RecordSearches(server.ID, SearchCategoryMissing, []int{1, 2}, 10, searchedAt)
+	_ = db.RecordSearches(server.ID, SearchCategoryMissing, []int{3}, 11, searchedAt)
+	_ = db.RecordSearches(server.ID, SearchCategoryCutoff, []int{4}, 12, searchedAt)
+	_ = db.RecordSearches(server.ID, SearchCategoryCutoff, []int{5}, 0, searchedAt) // No command to follow
+
+	pending, err := db.GetPendingSearchCommands(server.ID)
+	if err != nil {
+		t.Fatalf("GetPendingSearchCommands failed: %v", err)
+	}
+	if len(pending) != 3 || pending[0].CommandID != 10 || pending[0].SearchedAt.Unix() != searchedAt.Unix() {
+		t.Fatalf("expected commands 10, 11 and 12 pending, got %+v", pending)
+	}
+
+	// Item 1 is grabbed before its command finishes; the command outcome must not overwrite it
+	grabbed, err := db.MarkSearchesGrabbed(server.ID, map[int]time.Time{1: time.Now(), 3: searchedAt.Add(-time.Hour)}, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("MarkSearchesGrabbed failed: %v", err)
+	}
+	if grabbed != 1 {
+		t.Errorf("expected 1 search marked grabbed (item 3 was grabbed before its search), got %d", grabbed)
+	}
+
+	if n, err := db.SetSearchCommandOutcome(server.ID, 10, SearchOutcomeCompleted, time.Now()); err != nil || n != 1 {
+		t.Errorf("expected 1 search completed, got %d (%v)", n, err)
+	}
+	if n, err := db.SetSearchCommandOutcome(server.ID, 11, SearchOutcomeFailed, time.Now()); err != nil || n != 1 {
+		t.Errorf("expected 1 search failed, got %d (%v)", n, err)
+	}
+
+	stats, err := db.GetSearchOutcomeStats()
+	if err != nil {
+		t.Fatalf("GetSearchOutcomeStats failed: %v", err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("expected stats for 1 server, got %d", len(stats))
+	}
+	got := stats[0]
+	if got.Grabbed != 1 || got.Completed != 1 || got.Failed != 1 || got.Pending != 1 {
+		t.Errorf("unexpected outcome counts: %+v", got)
+	}
+	if rate := got.SuccessRate(); rate < 0.33 || rate > 0.34 {
+		t.Errorf("expected success rate of 1/3, got %v", rate)
+	}
+
+	// Searching again starts a new pending outcome
+	_ = db.RecordSearches(server.ID, SearchCategoryMissing, []int{3}, 20, time.Now())
+	pending, _ = db.GetPendingSearchCommands(server.ID)
+	if len(pending) != 2 || pending[1].CommandID != 20 {
+		t.Errorf("expected commands 12 and 20 pending, got %+v", pending)
+	}
+}
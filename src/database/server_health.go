@@ -0,0 +1,114 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// GetServerHealth returns the health of a server. Servers with no recorded health are healthy.
+func (db *DB) GetServerHealth(serverID string) (ServerHealth, error) {
+	row := db.conn.QueryRow(`
+		SELECT server_id, state, consecutive_failures, last_error, last_success_at, last_failure_at, open_until
+		FROM server_health WHERE server_id = ?
+	`, serverID)
+
+	_, health, err := scanServerHealth(row)
+	if err == sql.ErrNoRows {
+		return ServerHealth{State: ServerHealthy}, nil
+	}
+	if err != nil {
+		return ServerHealth{State: ServerHealthy}, fmt.Errorf("scanning server health: %w", err)
+	}
+	return health, nil
+}
+
+// GetAllServerHealth returns the recorded health of every server, indexed by server ID.
+// Servers missing from the map are healthy.
+func (db *DB) GetAllServerHealth() (map[string]ServerHealth, error) {
+	rows, err := db.conn.Query(`
+		SELECT server_id, state, consecutive_failures, last_error, last_success_at, last_failure_at, open_until
+		FROM server_health
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("querying server health: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]ServerHealth)
+	for rows.Next() {
+		serverID, health, err := scanServerHealth(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning server health: %w", err)
+		}
+		result[serverID] = health
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating server health: %w", err)
+	}
+
+	return result, nil
+}
+
+// SetServerHealth stores the health of a server, replacing any previous value
+func (db *DB) SetServerHealth(serverID string, health ServerHealth) error {
+	_, err := db.conn.Exec(`
+		INSERT INTO server_health (server_id, state, consecutive_failures, last_error, last_success_at, last_failure_at, open_until)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(server_id) DO UPDATE SET
+			state = excluded.state,
+			consecutive_failures = excluded.consecutive_failures,
+			last_error = excluded.last_error,
+			last_success_at = excluded.last_success_at,
+			last_failure_at = excluded.last_failure_at,
+			open_until = excluded.open_until
+	`, serverID, health.State, health.ConsecutiveFailures, health.LastError,
+		formatNullTime(health.LastSuccessAt), formatNullTime(health.LastFailureAt), formatNullTime(health.OpenUntil))
+	if err != nil {
+		return fmt.Errorf("saving server health: %w", err)
+	}
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanServerHealth scans a server_health row
+func scanServerHealth(row rowScanner) (string, ServerHealth, error) {
+	var serverID string
+	var health ServerHealth
+	var lastSuccess, lastFailure, openUntil sql.NullString
+
+	if err := row.Scan(&serverID, &health.State, &health.ConsecutiveFailures, &health.LastError,
+		&lastSuccess, &lastFailure, &openUntil); err != nil {
+		return "", health, err
+	}
+
+	health.LastSuccessAt = parseNullTime(lastSuccess)
+	health.LastFailureAt = parseNullTime(lastFailure)
+	health.OpenUntil = parseNullTime(openUntil)
+	return serverID, health, nil
+}
+
+// formatNullTime formats an optional time for storage, using NULL when unset
+func formatNullTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// parseNullTime parses an optional RFC3339 column
+func parseNullTime(v sql.NullString) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, v.String)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestServerHealth(t *testing.T) {
+	db := testDB(t)
+
+	server, err := db.AddServer("Sonarr", "http://localhost:8989", "key", ServerTypeSonarr)
+	if err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+
+	// Servers start out healthy without a stored row
+	health, err := db.GetServerHealth(server.ID)
+	if err != nil {
+		t.Fatalf("GetServerHealth failed: %v", err)
+	}
+	if health.State != ServerHealthy || health.ConsecutiveFailures != 0 {
+		t.Errorf("expected a healthy server, got %+v", health)
+	}
+
+	failedAt := time.Now().Truncate(time.Second)
+	openUntil := failedAt.Add(time.Hour)
+	stored := ServerHealth{
+		State:               ServerCircuitOpen,
+		ConsecutiveFailures: 3,
+		LastError:           "connection refused",
+		LastFailureAt:       &failedAt,
+		OpenUntil:           &openUntil,
+	}
+	if err := db.SetServerHealth(server.ID, stored); err != nil {
+		t.Fatalf("SetServerHealth failed: %v", err)
+	}
+
+	health, err = db.GetServerHealth(server.ID)
+	if err != nil {
+		t.Fatalf("GetServerHealth failed: %v", err)
+	}
+	if health.State != ServerCircuitOpen || health.ConsecutiveFailures != 3 || health.LastError != "connection refused" {
+		t.Errorf("unexpected health: %+v", health)
+	}
+	if health.OpenUntil == nil || !health.OpenUntil.Equal(openUntil) {
+		t.Errorf("expected open until %v, got %v", openUntil, health.OpenUntil)
+	}
+	if health.LastSuccessAt != nil {
+		t.Errorf("expected no last success, got %v", health.LastSuccessAt)
+	}
+
+	// Saving again replaces the stored value
+	if err := db.SetServerHealth(server.ID, ServerHealth{State: ServerHealthy, LastSuccessAt: &failedAt}); err != nil {
+		t.Fatalf("SetServerHealth failed: %v", err)
+	}
+	all, err := db.GetAllServerHealth()
+	if err != nil {
+		t.Fatalf("GetAllServerHealth failed: %v", err)
+	}
+	if got := all[server.ID]; got.State != ServerHealthy || got.OpenUntil != nil || got.LastSuccessAt == nil {
+		t.Errorf("unexpected health after update: %+v", got)
+	}
+
+	// Health is removed along with its server
+	if _, err := db.DeleteServer(server.ID); err != nil {
+		t.Fatalf("DeleteServer failed: %v", err)
+	}
+	all, err = db.GetAllServerHealth()
+	if err != nil {
+		t.Fatalf("GetAllServerHealth failed: %v", err)
+	}
+	if len(all) != 0 {
+		t.Errorf("expected health to be deleted with the server, got %v", all)
+	}
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// SetServerPair pairs two servers with the given policy. A server pair is unordered, so this
+// replaces any existing pair of the two servers, whichever of them was primary.
+func (db *DB) SetServerPair(primaryID, secondaryID string, policy PairPolicy) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		DELETE FROM server_pairs
+		WHERE (primary_id = ? AND secondary_id = ?) OR (primary_id = ? AND secondary_id = ?)
+	`, primaryID, secondaryID, secondaryID, primaryID); err != nil {
+		return fmt.Errorf("replacing server pair: %w", err)
+	}
+	if _, err := tx.Exec(`
+		INSERT INTO server_pairs (primary_id, secondary_id, policy, created_at)
+		VALUES (?, ?, ?, ?)
+	`, primaryID, secondaryID, policy, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("saving server pair: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetServerPairs returns all server pairs, oldest first
+func (db *DB) GetServerPairs() ([]ServerPair, error) {
+	rows, err := db.conn.Query(`
+		SELECT primary_id, secondary_id, policy, created_at
+		FROM server_pairs ORDER BY created_at, primary_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("querying server pairs: %w", err)
+	}
+	defer rows.Close()
+
+	pairs := []ServerPair{}
+	for rows.Next() {
+		var pair ServerPair
+		var createdAt string
+		if err := rows.Scan(&pair.PrimaryID, &pair.SecondaryID, &pair.Policy, &createdAt); err != nil {
+			return nil, fmt.Errorf("scanning server pair: %w", err)
+		}
+		pair.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		pairs = append(pairs, pair)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating server pairs: %w", err)
+	}
+
+	return pairs, nil
+}
+
+// DeleteServerPair unpairs two servers, in either order. It reports whether they were paired.
+func (db *DB) DeleteServerPair(serverID, otherID string) (bool, error) {
+	result, err := db.conn.Exec(`
+		DELETE FROM server_pairs
+		WHERE (primary_id = ? AND secondary_id = ?) OR (primary_id = ? AND secondary_id = ?)
+	`, serverID, otherID, otherID, serverID)
+	if err != nil {
+		return false, fmt.Errorf("deleting server pair: %w", err)
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("deleting server pair: %w", err)
+	}
+	return removed > 0, nil
+}
@@ -0,0 +1,68 @@
+package database
+
+import "testing"
+
+func TestServerPairs(t *testing.T) {
+	db := testDB(t)
+
+	radarr, err := db.AddServer("radarr", "http://localhost:7878", "key", ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+	radarr4k, err := db.AddServer("radarr-4k", "http://localhost:7879", "key", ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+
+	if err := db.SetServerPair(radarr.ID, radarr4k.ID, PairPreferPrimary); err != nil {
+		t.Fatalf("SetServerPair failed: %v", err)
+	}
+
+	// Pairing the servers the other way round replaces the pair
+	if err := db.SetServerPair(radarr4k.ID, radarr.ID, PairStagger); err != nil {
+		t.Fatalf("SetServerPair failed: %v", err)
+	}
+	pairs, err := db.GetServerPairs()
+	if err != nil {
+		t.Fatalf("GetServerPairs failed: %v", err)
+	}
+	if len(pairs) != 1 {
+		t.Fatalf("expected 1 pair, got %d", len(pairs))
+	}
+	if pairs[0].PrimaryID != radarr4k.ID || pairs[0].SecondaryID != radarr.ID || pairs[0].Policy != PairStagger {
+		t.Errorf("unexpected pair: %+v", pairs[0])
+	}
+
+	// A server can't be paired with itself, or with an unknown server
+	if err := db.SetServerPair(radarr.ID, radarr.ID, PairStagger); err == nil {
+		t.Error("expected an error pairing a server with itself")
+	}
+	if err := db.SetServerPair(radarr.ID, "missing", PairStagger); err == nil {
+		t.Error("expected an error pairing with an unknown server")
+	}
+
+	// Pairs can be removed in either order
+	removed, err := db.DeleteServerPair(radarr.ID, radarr4k.ID)
+	if err != nil || !removed {
+		t.Fatalf("expected the pair to be removed, got %v (%v)", removed, err)
+	}
+	removed, err = db.DeleteServerPair(radarr.ID, radarr4k.ID)
+	if err != nil || removed {
+		t.Errorf("expected nothing to remove, got %v (%v)", removed, err)
+	}
+
+	// Removing a server removes its pairs
+	if err := db.SetServerPair(radarr.ID, radarr4k.ID, PairSearchBoth); err != nil {
+		t.Fatalf("SetServerPair failed: %v", err)
+	}
+	if _, err := db.DeleteServer(radarr4k.ID); err != nil {
+		t.Fatalf("DeleteServer failed: %v", err)
+	}
+	pairs, err = db.GetServerPairs()
+	if err != nil {
+		t.Fatalf("GetServerPairs failed: %v", err)
+	}
+	if len(pairs) != 0 {
+		t.Errorf("expected pairs to be removed with the server, got %v", pairs)
+	}
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -11,10 +12,13 @@ import (
 
 // ServerUpdate represents optional fields for updating a server
 type ServerUpdate struct {
-	Name    *string
-	URL     *string
-	APIKey  *string
-	Enabled *bool
+	Name       *string
+	URL        *string
+	APIKey     *string
+	Enabled    *bool
+	Limits     *ServerLimits     // Replaces all per-server limits when set
+	Pagination *ServerPagination // Replaces the pagination overrides when set
+	Filters    *ServerFilters    // Replaces all wanted-item filters when set
 }
 
 // AddServer adds a new server to the database
@@ -43,6 +47,7 @@ func (db *DB) AddServer(name, url, apiKey string, serverType ServerType) (*Serve
 		APIKey:    apiKey, // Return unencrypted key to caller
 		Type:      serverType,
 		Enabled:   true,
+		Limits:    ServerLimits{Weight: 1},
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -65,7 +70,7 @@ func (db *DB) AddServer(name, url, apiKey string, serverType ServerType) (*Serve
 // GetServer retrieves a server by ID
 func (db *DB) GetServer(id string) (*Server, error) {
 	row := db.conn.QueryRow(`
-		SELECT id, name, url, api_key, type, enabled, created_at, updated_at
+		SELECT id, name, url, api_key, type, enabled, max_missing, max_cutoff, weight, never_cutoff, page_size, page_concurrency, filters, created_at, updated_at
 		FROM servers WHERE id = ?
 	`, id)
 
@@ -75,7 +80,7 @@ func (db *DB) GetServer(id string) (*Server, error) {
 // GetServerByName retrieves a server by name (case-insensitive)
 func (db *DB) GetServerByName(name string) (*Server, error) {
 	row := db.conn.QueryRow(`
-		SELECT id, name, url, api_key, type, enabled, created_at, updated_at
+		SELECT id, name, url, api_key, type, enabled, max_missing, max_cutoff, weight, never_cutoff, page_size, page_concurrency, filters, created_at, updated_at
 		FROM servers WHERE LOWER(name) = LOWER(?)
 	`, name)
 
@@ -85,7 +90,7 @@ func (db *DB) GetServerByName(name string) (*Server, error) {
 // GetAllServers retrieves all servers
 func (db *DB) GetAllServers() ([]Server, error) {
 	rows, err := db.conn.Query(`
-		SELECT id, name, url, api_key, type, enabled, created_at, updated_at
+		SELECT id, name, url, api_key, type, enabled, max_missing, max_cutoff, weight, never_cutoff, page_size, page_concurrency, filters, created_at, updated_at
 		FROM servers ORDER BY name
 	`)
 	if err != nil {
@@ -112,7 +117,7 @@ func (db *DB) GetAllServers() ([]Server, error) {
 // GetServersByType retrieves all servers of a specific type
 func (db *DB) GetServersByType(serverType ServerType) ([]Server, error) {
 	rows, err := db.conn.Query(`
-		SELECT id, name, url, api_key, type, enabled, created_at, updated_at
+		SELECT id, name, url, api_key, type, enabled, max_missing, max_cutoff, weight, never_cutoff, page_size, page_concurrency, filters, created_at, updated_at
 		FROM servers WHERE type = ? ORDER BY name
 	`, serverType)
 	if err != nil {
@@ -224,6 +229,29 @@ func (db *DB) UpdateServer(id string, updates *ServerUpdate) error {
 		args = append(args, enabled)
 	}
 
+	if updates.Limits != nil {
+		neverCutoff := 0
+		if updates.Limits.NeverCutoff {
+			neverCutoff = 1
+		}
+		setClauses = append(setClauses, "max_missing = ?", "max_cutoff = ?", "weight = ?", "never_cutoff = ?")
+		args = append(args, updates.Limits.MaxMissing, updates.Limits.MaxCutoff, updates.Limits.EffectiveWeight(), neverCutoff)
+	}
+
+	if updates.Pagination != nil {
+		setClauses = append(setClauses, "page_size = ?", "page_concurrency = ?")
+		args = append(args, updates.Pagination.PageSize, updates.Pagination.Concurrency)
+	}
+
+	if updates.Filters != nil {
+		filters, err := json.Marshal(updates.Filters)
+		if err != nil {
+			return fmt.Errorf("encoding filters: %w", err)
+		}
+		setClauses = append(setClauses, "filters = ?")
+		args = append(args, string(filters))
+	}
+
 	if len(setClauses) == 0 {
 		return nil // Nothing to update
 	}
@@ -271,10 +299,13 @@ func (db *DB) DeleteServer(id string) (bool, error) {
 func (db *DB) scanServer(row *sql.Row) (*Server, error) {
 	var server Server
 	var encryptedKey string
-	var enabled int
-	var createdAt, updatedAt string
+	var enabled, neverCutoff int
+	var maxMissing, maxCutoff sql.NullInt64
+	var filters, createdAt, updatedAt string
 
-	err := row.Scan(&server.ID, &server.Name, &server.URL, &encryptedKey, &server.Type, &enabled, &createdAt, &updatedAt)
+	err := row.Scan(&server.ID, &server.Name, &server.URL, &encryptedKey, &server.Type, &enabled,
+		&maxMissing, &maxCutoff, &server.Limits.Weight, &neverCutoff,
+		&server.Pagination.PageSize, &server.Pagination.Concurrency, &filters, &createdAt, &updatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -289,6 +320,12 @@ func (db *DB) scanServer(row *sql.Row) (*Server, error) {
 	}
 	server.APIKey = apiKey
 	server.Enabled = enabled == 1
+	server.Limits.MaxMissing = nullIntPtr(maxMissing)
+	server.Limits.MaxCutoff = nullIntPtr(maxCutoff)
+	server.Limits.NeverCutoff = neverCutoff == 1
+	if err := json.Unmarshal([]byte(filters), &server.Filters); err != nil {
+		return nil, fmt.Errorf("decoding filters: %w", err)
+	}
 
 	// Parse timestamps
 	server.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
@@ -301,10 +338,13 @@ func (db *DB) scanServer(row *sql.Row) (*Server, error) {
 func (db *DB) scanServerRow(rows *sql.Rows) (*Server, error) {
 	var server Server
 	var encryptedKey string
-	var enabled int
-	var createdAt, updatedAt string
+	var enabled, neverCutoff int
+	var maxMissing, maxCutoff sql.NullInt64
+	var filters, createdAt, updatedAt string
 
-	err := rows.Scan(&server.ID, &server.Name, &server.URL, &encryptedKey, &server.Type, &enabled, &createdAt, &updatedAt)
+	err := rows.Scan(&server.ID, &server.Name, &server.URL, &encryptedKey, &server.Type, &enabled,
+		&maxMissing, &maxCutoff, &server.Limits.Weight, &neverCutoff,
+		&server.Pagination.PageSize, &server.Pagination.Concurrency, &filters, &createdAt, &updatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("scanning server: %w", err)
 	}
@@ -316,6 +356,12 @@ func (db *DB) scanServerRow(rows *sql.Rows) (*Server, error) {
 	}
 	server.APIKey = apiKey
 	server.Enabled = enabled == 1
+	server.Limits.MaxMissing = nullIntPtr(maxMissing)
+	server.Limits.MaxCutoff = nullIntPtr(maxCutoff)
+	server.Limits.NeverCutoff = neverCutoff == 1
+	if err := json.Unmarshal([]byte(filters), &server.Filters); err != nil {
+		return nil, fmt.Errorf("decoding filters: %w", err)
+	}
 
 	// Parse timestamps
 	server.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
@@ -323,3 +369,12 @@ func (db *DB) scanServerRow(rows *sql.Rows) (*Server, error) {
 
 	return &server, nil
 }
+
+// nullIntPtr converts a nullable integer column to an optional int
+func nullIntPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int64)
+	return &n
+}
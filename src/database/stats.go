@@ -57,6 +57,38 @@ func (db *DB) GetServerStats(serverID string) ServerStats {
 	return stats
 }
 
+// GetSearchOutcomeStats counts search outcomes for every server, ordered by server name.
+// Only the latest search of each item and category is counted.
+func (db *DB) GetSearchOutcomeStats() ([]SearchOutcomeStats, error) {
+	rows, err := db.conn.Query(`
+		SELECT
+			s.id, s.name, s.type,
+			COALESCE(SUM(CASE WHEN h.outcome = 'pending' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN h.outcome = 'completed' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN h.outcome = 'failed' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN h.outcome = 'grabbed' THEN 1 ELSE 0 END), 0)
+		FROM servers s
+		LEFT JOIN search_history h ON h.server_id = s.id
+		GROUP BY s.id
+		ORDER BY s.name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []SearchOutcomeStats
+	for rows.Next() {
+		var s SearchOutcomeStats
+		if err := rows.Scan(&s.ServerID, &s.ServerName, &s.ServerType, &s.Pending, &s.Completed, &s.Failed, &s.Grabbed); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
 // GetServerCounts retrieves server counts grouped by type
 func (db *DB) GetServerCounts() (map[string]ServerCounts, error) {
 	rows, err := db.conn.Query(`
@@ -6,8 +6,10 @@ import "time"
 type ServerType string
 
 const (
-	ServerTypeRadarr ServerType = "radarr"
-	ServerTypeSonarr ServerType = "sonarr"
+	ServerTypeRadarr  ServerType = "radarr"
+	ServerTypeSonarr  ServerType = "sonarr"
+	ServerTypeLidarr  ServerType = "lidarr"
+	ServerTypeReadarr ServerType = "readarr"
 )
 
 // LogEntryType represents the type of activity log entry
@@ -28,16 +30,357 @@ const (
 	SearchCategoryCutoff  SearchCategory = "cutoff"
 )
 
+// SearchOutcome records what happened after a search was triggered
+type SearchOutcome string
+
+const (
+	SearchOutcomePending   SearchOutcome = "pending"   // The search command has not finished yet
+	SearchOutcomeCompleted SearchOutcome = "completed" // The search finished without grabbing a release (so far)
+	SearchOutcomeFailed    SearchOutcome = "failed"    // The search command failed or never finished
+	SearchOutcomeGrabbed   SearchOutcome = "grabbed"   // A release was grabbed after the search
+)
+
+// SelectionMode identifies how items are picked from a server's wanted list
+type SelectionMode string
+
+const (
+	SelectionOldestSearchedFirst SelectionMode = "oldest-searched-first"
+	SelectionRandom              SelectionMode = "random"
+	SelectionNewestReleaseFirst  SelectionMode = "newest-release-first"
+	SelectionAlphabetical        SelectionMode = "alphabetical"
+	SelectionLeastRecentlyAdded  SelectionMode = "least-recently-added"
+)
+
+// SelectionModes lists all supported selection modes in display order
+var SelectionModes = []SelectionMode{
+	SelectionOldestSearchedFirst,
+	SelectionRandom,
+	SelectionNewestReleaseFirst,
+	SelectionAlphabetical,
+	SelectionLeastRecentlyAdded,
+}
+
+// IsValidSelectionMode reports whether the given string names a supported selection mode
+func IsValidSelectionMode(mode string) bool {
+	for _, m := range SelectionModes {
+		if string(m) == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// GiveUpPolicy decides what happens to an item that keeps being searched without a release being grabbed
+type GiveUpPolicy string
+
+const (
+	GiveUpBackoff   GiveUpPolicy = "backoff"   // Wait twice as long before each further search
+	GiveUpSnooze    GiveUpPolicy = "snooze"    // Stop searching the item for a number of days
+	GiveUpTag       GiveUpPolicy = "tag"       // Tag the item on its server and stop searching it
+	GiveUpUnmonitor GiveUpPolicy = "unmonitor" // Unmonitor the item on its server
+)
+
+// GiveUpPolicies lists all supported give-up policies in display order
+var GiveUpPolicies = []GiveUpPolicy{GiveUpBackoff, GiveUpSnooze, GiveUpTag, GiveUpUnmonitor}
+
+// IsValidGiveUpPolicy reports whether the given string names a supported give-up policy
+func IsValidGiveUpPolicy(policy string) bool {
+	for _, p := range GiveUpPolicies {
+		if string(p) == policy {
+			return true
+		}
+	}
+	return false
+}
+
+// ExclusionKind identifies what an exclusion matches
+type ExclusionKind string
+
+const (
+	ExcludeItem   ExclusionKind = "item"   // A movie, episode, album or book by its ID on the server
+	ExcludeSeries ExclusionKind = "series" // Every episode of a series by its Sonarr series ID
+	ExcludeTMDB   ExclusionKind = "tmdb"   // A movie by its TMDB ID
+	ExcludeTVDB   ExclusionKind = "tvdb"   // Every episode of a series by the series' TVDB ID
+)
+
+// ExclusionKinds lists all supported exclusion kinds in display order
+var ExclusionKinds = []ExclusionKind{ExcludeItem, ExcludeSeries, ExcludeTMDB, ExcludeTVDB}
+
+// IsValidExclusionKind reports whether the given string names a supported exclusion kind
+func IsValidExclusionKind(kind string) bool {
+	for _, k := range ExclusionKinds {
+		if string(k) == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// CatchUpPolicy decides what the scheduler does when a run was missed while Janitarr was stopped
+type CatchUpPolicy string
+
+const (
+	CatchUpRun  CatchUpPolicy = "run"  // Run immediately on startup
+	CatchUpSkip CatchUpPolicy = "skip" // Wait for the next scheduled run
+)
+
+// IsValidCatchUpPolicy reports whether the given string names a supported catch-up policy
+func IsValidCatchUpPolicy(policy string) bool {
+	return policy == string(CatchUpRun) || policy == string(CatchUpSkip)
+}
+
+// AuthMode controls when the web UI and API require authentication
+type AuthMode string
+
+const (
+	AuthDisabled         AuthMode = "disabled"           // No authentication
+	AuthEnabled          AuthMode = "enabled"            // Always require a login or API key
+	AuthDisabledForLocal AuthMode = "disabled-for-local" // Require authentication only for non-local addresses
+)
+
+// IsValidAuthMode reports whether the given string names a supported authentication mode
+func IsValidAuthMode(mode string) bool {
+	switch AuthMode(mode) {
+	case AuthDisabled, AuthEnabled, AuthDisabledForLocal:
+		return true
+	}
+	return false
+}
+
+// TracingExporter selects where OpenTelemetry spans are sent
+type TracingExporter string
+
+const (
+	TracingNone   TracingExporter = "none"   // Tracing disabled
+	TracingOTLP   TracingExporter = "otlp"   // OTLP over HTTP to the configured endpoint
+	TracingStdout TracingExporter = "stdout" // JSON spans written to standard output
+	TracingFile   TracingExporter = "file"   // JSON spans appended to the file named by the endpoint
+)
+
+// IsValidTracingExporter reports whether the given string names a supported tracing exporter
+func IsValidTracingExporter(exporter string) bool {
+	switch TracingExporter(exporter) {
+	case TracingNone, TracingOTLP, TracingStdout, TracingFile:
+		return true
+	}
+	return false
+}
+
+// ServerHealthState describes whether automation cycles still contact a server
+type ServerHealthState string
+
+const (
+	ServerHealthy     ServerHealthState = "healthy"  // Last contact succeeded
+	ServerDegraded    ServerHealthState = "degraded" // Recent failures, still contacted every cycle
+	ServerCircuitOpen ServerHealthState = "open"     // Skipped until OpenUntil, then probed before use
+)
+
+// ServerHealth tracks a server's recent failures across automation cycles
+type ServerHealth struct {
+	State               ServerHealthState `json:"state"`
+	ConsecutiveFailures int               `json:"consecutiveFailures"`
+	LastError           string            `json:"lastError,omitempty"`
+	LastSuccessAt       *time.Time        `json:"lastSuccessAt,omitempty"`
+	LastFailureAt       *time.Time        `json:"lastFailureAt,omitempty"`
+	OpenUntil           *time.Time        `json:"openUntil,omitempty"` // Set while the circuit is open
+}
+
+// PairPolicy decides how an item wanted on both servers of a pair is searched
+type PairPolicy string
+
+const (
+	PairSearchBoth    PairPolicy = "search-both"    // Search the item on both servers
+	PairPreferPrimary PairPolicy = "prefer-primary" // Only search the item on the primary server
+	PairStagger       PairPolicy = "stagger"        // Search the item on one server per cycle, taking turns
+)
+
+// PairPolicies lists all supported pair policies in display order
+var PairPolicies = []PairPolicy{PairSearchBoth, PairPreferPrimary, PairStagger}
+
+// IsValidPairPolicy reports whether the given string names a supported pair policy
+func IsValidPairPolicy(policy string) bool {
+	for _, p := range PairPolicies {
+		if string(p) == policy {
+			return true
+		}
+	}
+	return false
+}
+
+// ServerPair links two servers of the same type that search the same indexers, such as a
+// Radarr instance and its 4K counterpart, so a title wanted on both isn't searched twice.
+type ServerPair struct {
+	PrimaryID   string     `json:"primaryId"`
+	SecondaryID string     `json:"secondaryId"`
+	Policy      PairPolicy `json:"policy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// Exclusion keeps the items it matches on a server from being searched automatically.
+type Exclusion struct {
+	ID        string        `json:"id"`
+	ServerID  string        `json:"serverId"`
+	Kind      ExclusionKind `json:"kind"`
+	TargetID  int           `json:"targetId"` // Item, series, TMDB or TVDB ID, depending on Kind
+	Title     string        `json:"title,omitempty"`
+	Reason    string        `json:"reason,omitempty"`
+	ExpiresAt *time.Time    `json:"expiresAt,omitempty"` // Nil for exclusions that never expire
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
 // Server represents a configured media server
 type Server struct {
-	ID        string     `json:"id"`
-	Name      string     `json:"name"`
-	URL       string     `json:"url"`
-	APIKey    string     `json:"apiKey"`
-	Type      ServerType `json:"type"`
-	Enabled   bool       `json:"enabled"`
-	CreatedAt time.Time  `json:"createdAt"`
-	UpdatedAt time.Time  `json:"updatedAt"`
+	ID         string           `json:"id"`
+	Name       string           `json:"name"`
+	URL        string           `json:"url"`
+	APIKey     string           `json:"apiKey"`
+	Type       ServerType       `json:"type"`
+	Enabled    bool             `json:"enabled"`
+	Limits     ServerLimits     `json:"limits"`
+	Pagination ServerPagination `json:"pagination"`
+	Filters    ServerFilters    `json:"filters"`
+	CreatedAt  time.Time        `json:"createdAt"`
+	UpdatedAt  time.Time        `json:"updatedAt"`
+}
+
+// ServerLimits holds optional per-server overrides applied during search allocation
+type ServerLimits struct {
+	MaxMissing  *int    `json:"maxMissing,omitempty"` // Hard cap on missing searches per cycle (nil = no cap)
+	MaxCutoff   *int    `json:"maxCutoff,omitempty"`  // Hard cap on cutoff searches per cycle (nil = no cap)
+	Weight      float64 `json:"weight"`               // Multiplier on the server's share of the global limits
+	NeverCutoff bool    `json:"neverCutoff"`          // Never trigger cutoff searches on this server
+}
+
+// EffectiveWeight returns the allocation weight, treating unset or invalid weights as 1
+func (l ServerLimits) EffectiveWeight() float64 {
+	if l.Weight <= 0 {
+		return 1
+	}
+	return l.Weight
+}
+
+// MaxFor returns the hard cap for a search category, or nil if the server has no cap
+func (l ServerLimits) MaxFor(category SearchCategory) *int {
+	if category == SearchCategoryCutoff {
+		return l.MaxCutoff
+	}
+	return l.MaxMissing
+}
+
+// ServerPagination holds optional per-server overrides for fetching wanted lists
+type ServerPagination struct {
+	PageSize    int `json:"pageSize,omitempty"`    // Records requested per page (0 = default)
+	Concurrency int `json:"concurrency,omitempty"` // Pages fetched at once (0 = default)
+}
+
+// ServerFilters holds optional per-server rules deciding which wanted items are searched.
+// Names are matched case-insensitively and an empty rule allows everything. Items missing the
+// detail a year or status rule needs are not filtered by it.
+type ServerFilters struct {
+	MonitoredOnly          bool     `json:"monitoredOnly,omitempty"`          // Skip unmonitored items
+	IncludeQualityProfiles []string `json:"includeQualityProfiles,omitempty"` // Only items with one of these quality profiles
+	ExcludeQualityProfiles []string `json:"excludeQualityProfiles,omitempty"` // Skip items with any of these quality profiles
+	IncludeTags            []string `json:"includeTags,omitempty"`            // Only items tagged with at least one of these
+	ExcludeTags            []string `json:"excludeTags,omitempty"`            // Skip items tagged with any of these
+	IncludeRootFolders     []string `json:"includeRootFolders,omitempty"`     // Only items stored under one of these folders
+	ExcludeRootFolders     []string `json:"excludeRootFolders,omitempty"`     // Skip items stored under any of these folders
+	MinYear                int      `json:"minYear,omitempty"`                // Skip items released before this year (0 = no minimum)
+	MaxYear                int      `json:"maxYear,omitempty"`                // Skip items released after this year (0 = no maximum)
+	IncludeStatuses        []string `json:"includeStatuses,omitempty"`        // Only series, artists or authors with one of these statuses, e.g. "continuing"
+	ExcludeStatuses        []string `json:"excludeStatuses,omitempty"`        // Skip series, artists or authors with any of these statuses
+	SkipUnaired            bool     `json:"skipUnaired,omitempty"`            // Skip episodes that have not aired or have no air date
+	RecentlyAiredHours     int      `json:"recentlyAiredHours,omitempty"`     // Skip episodes that aired within this many hours
+}
+
+// IsEmpty reports whether the filters allow every item
+func (f ServerFilters) IsEmpty() bool {
+	return !f.MonitoredOnly && !f.SkipUnaired && f.MinYear == 0 && f.MaxYear == 0 && f.RecentlyAiredHours == 0 &&
+		len(f.IncludeQualityProfiles) == 0 && len(f.ExcludeQualityProfiles) == 0 &&
+		len(f.IncludeTags) == 0 && len(f.ExcludeTags) == 0 &&
+		len(f.IncludeRootFolders) == 0 && len(f.ExcludeRootFolders) == 0 &&
+		len(f.IncludeStatuses) == 0 && len(f.ExcludeStatuses) == 0
+}
+
+// NotificationProvider identifies the service a notification channel sends to
+type NotificationProvider string
+
+const (
+	NotificationWebhook NotificationProvider = "webhook"
+	NotificationNtfy    NotificationProvider = "ntfy"
+	NotificationGotify  NotificationProvider = "gotify"
+	NotificationApprise NotificationProvider = "apprise"
+	NotificationDiscord NotificationProvider = "discord"
+)
+
+// NotificationProviders lists all supported notification providers in display order
+var NotificationProviders = []NotificationProvider{
+	NotificationWebhook,
+	NotificationNtfy,
+	NotificationGotify,
+	NotificationApprise,
+	NotificationDiscord,
+}
+
+// IsValidNotificationProvider reports whether the given string names a supported provider
+func IsValidNotificationProvider(provider string) bool {
+	for _, p := range NotificationProviders {
+		if string(p) == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationEvent identifies an event that notification channels can subscribe to
+type NotificationEvent string
+
+const (
+	NotificationEventCycleEnd    NotificationEvent = "cycle_end"    // An automation cycle finished
+	NotificationEventServerError NotificationEvent = "server_error" // Detection or search failed on a server
+	NotificationEventRateLimit   NotificationEvent = "rate_limit"   // A server rate limited Janitarr's searches
+)
+
+// NotificationEvents lists all events channels can subscribe to
+var NotificationEvents = []NotificationEvent{
+	NotificationEventCycleEnd,
+	NotificationEventServerError,
+	NotificationEventRateLimit,
+}
+
+// IsValidNotificationEvent reports whether the given string names a supported event
+func IsValidNotificationEvent(event string) bool {
+	for _, e := range NotificationEvents {
+		if string(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationChannel is a configured destination for notifications
+type NotificationChannel struct {
+	ID            string               `json:"id"`
+	Name          string               `json:"name"`
+	Provider      NotificationProvider `json:"provider"`
+	URL           string               `json:"url"`
+	Token         string               `json:"-"`             // Provider credential, encrypted at rest and never returned by the API
+	HasToken      bool                 `json:"hasToken"`      // Whether a token is stored
+	Events        []NotificationEvent  `json:"events"`        // Events that trigger this channel
+	TitleTemplate string               `json:"titleTemplate"` // Custom title template (empty = default)
+	BodyTemplate  string               `json:"bodyTemplate"`  // Custom body template (empty = default)
+	Enabled       bool                 `json:"enabled"`
+	CreatedAt     time.Time            `json:"createdAt"`
+	UpdatedAt     time.Time            `json:"updatedAt"`
+}
+
+// Subscribes reports whether the channel should be notified of the given event
+func (c NotificationChannel) Subscribes(event NotificationEvent) bool {
+	for _, e := range c.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
 }
 
 // LogEntry represents an activity log entry
@@ -55,8 +398,12 @@ type LogEntry struct {
 
 // ScheduleConfig represents scheduler configuration
 type ScheduleConfig struct {
-	IntervalHours int  `json:"intervalHours"`
-	Enabled       bool `json:"enabled"`
+	IntervalHours int           `json:"intervalHours"`
+	Enabled       bool          `json:"enabled"`
+	Cron          string        `json:"cron"`      // Cron expression; overrides IntervalHours when set
+	Windows       string        `json:"windows"`   // Allowed run windows, e.g. "01:00-06:00,22:00-23:30" (empty = any time)
+	Blackouts     string        `json:"blackouts"` // Periods when runs must not start, same format as Windows
+	CatchUp       CatchUpPolicy `json:"catchUp"`   // What to do with a run missed while stopped
 }
 
 // SearchLimits represents search limit configuration
@@ -67,6 +414,94 @@ type SearchLimits struct {
 	CutoffEpisodesLimit  int `json:"cutoffEpisodesLimit"`
 }
 
+// Search limit keys, as used by `janitarr config set`
+const (
+	LimitMissingMovies   = "limits.missing.movies"
+	LimitMissingEpisodes = "limits.missing.episodes"
+	LimitCutoffMovies    = "limits.cutoff.movies"
+	LimitCutoffEpisodes  = "limits.cutoff.episodes"
+)
+
+// SearchLimitKeys lists the search limits in display order
+var SearchLimitKeys = []string{LimitMissingMovies, LimitMissingEpisodes, LimitCutoffMovies, LimitCutoffEpisodes}
+
+// LimitKey returns the search limit a server type's searches count against. Sonarr uses the episode
+// limits; Radarr, Lidarr and Readarr search one title at a time and share the movie limits.
+func LimitKey(serverType ServerType, category SearchCategory) string {
+	episodes := serverType == ServerTypeSonarr
+	switch {
+	case category == SearchCategoryCutoff && episodes:
+		return LimitCutoffEpisodes
+	case category == SearchCategoryCutoff:
+		return LimitCutoffMovies
+	case episodes:
+		return LimitMissingEpisodes
+	default:
+		return LimitMissingMovies
+	}
+}
+
+// Get returns the value of the search limit with the given key, or 0 for an unknown key
+func (l SearchLimits) Get(key string) int {
+	switch key {
+	case LimitMissingMovies:
+		return l.MissingMoviesLimit
+	case LimitMissingEpisodes:
+		return l.MissingEpisodesLimit
+	case LimitCutoffMovies:
+		return l.CutoffMoviesLimit
+	case LimitCutoffEpisodes:
+		return l.CutoffEpisodesLimit
+	default:
+		return 0
+	}
+}
+
+// SearchConfig represents search behaviour configuration
+type SearchConfig struct {
+	CooldownHours int           `json:"cooldownHours"` // Skip items searched within this many hours (0 = disabled)
+	Strategy      SelectionMode `json:"strategy"`      // How items are picked from each server's wanted list
+
+	// Percentage of a season's aired episodes that must be missing before Sonarr searches the
+	// whole season instead of each episode (0 = always search episodes)
+	SeasonPackPercent int `json:"seasonPackPercent"`
+
+	// Skip servers with more than this many downloads in their queue (0 = no limit)
+	MaxQueueSize int `json:"maxQueueSize"`
+
+	GiveUpAttempts int          `json:"giveUpAttempts"` // Give up on items after this many searches without a grab (0 = never)
+	GiveUpPolicy   GiveUpPolicy `json:"giveUpPolicy"`   // What happens to items given up on
+	GiveUpDays     int          `json:"giveUpDays"`     // Days a snoozed item waits, and the longest wait with backoff
+	GiveUpTagLabel string       `json:"giveUpTagLabel"` // Tag added to items given up on with the tag policy
+}
+
+// DetectionConfig represents how wanted lists are refreshed
+type DetectionConfig struct {
+	FullRefreshHours  int  `json:"fullRefreshHours"`  // Hours between full reads of each wanted list (0 = every cycle)
+	SkipUnavailable   bool `json:"skipUnavailable"`   // Leave out items that are not released or available yet
+	ReleaseGraceHours int  `json:"releaseGraceHours"` // Leave out items released or aired within this many hours (0 = disabled)
+}
+
+// AuthConfig represents web UI and API authentication settings.
+// The password hash and API key are stored separately and never included here.
+type AuthConfig struct {
+	Mode     AuthMode `json:"mode"`
+	Username string   `json:"username"`
+}
+
+// TracingConfig represents OpenTelemetry tracing settings, applied when Janitarr starts
+type TracingConfig struct {
+	Exporter TracingExporter `json:"exporter"`
+	Endpoint string          `json:"endpoint"` // OTLP endpoint URL, or file path for the file exporter (empty = exporter default)
+}
+
+// RequestsConfig represents the optional Overseerr or Jellyseerr connection whose requests are searched first.
+// The API key is stored separately, encrypted, and never included here.
+type RequestsConfig struct {
+	URL       string `json:"url"`       // Overseerr or Jellyseerr URL (empty = disabled)
+	HasAPIKey bool   `json:"hasApiKey"` // Whether an API key is stored
+}
+
 // LogsConfig represents logging configuration
 type LogsConfig struct {
 	RetentionDays int `json:"retentionDays"`
@@ -74,9 +509,14 @@ type LogsConfig struct {
 
 // AppConfig represents the full application configuration
 type AppConfig struct {
-	Schedule     ScheduleConfig `json:"schedule"`
-	SearchLimits SearchLimits   `json:"searchLimits"`
-	Logs         LogsConfig     `json:"logs"`
+	Schedule     ScheduleConfig  `json:"schedule"`
+	SearchLimits SearchLimits    `json:"searchLimits"`
+	Search       SearchConfig    `json:"search"`
+	Detection    DetectionConfig `json:"detection"`
+	Logs         LogsConfig      `json:"logs"`
+	Auth         AuthConfig      `json:"auth"`
+	Tracing      TracingConfig   `json:"tracing"`
+	Requests     RequestsConfig  `json:"requests"`
 }
 
 // DefaultAppConfig returns the default application configuration
@@ -85,6 +525,7 @@ func DefaultAppConfig() AppConfig {
 		Schedule: ScheduleConfig{
 			IntervalHours: 6,
 			Enabled:       true,
+			CatchUp:       CatchUpRun,
 		},
 		SearchLimits: SearchLimits{
 			MissingMoviesLimit:   10,
@@ -92,10 +533,101 @@ func DefaultAppConfig() AppConfig {
 			CutoffMoviesLimit:    5,
 			CutoffEpisodesLimit:  5,
 		},
+		Search: SearchConfig{
+			CooldownHours:     24,
+			Strategy:          SelectionOldestSearchedFirst,
+			SeasonPackPercent: 75,
+			GiveUpPolicy:      GiveUpBackoff,
+			GiveUpDays:        30,
+			GiveUpTagLabel:    "janitarr-gave-up",
+		},
+		Detection: DetectionConfig{
+			FullRefreshHours: 24,
+			SkipUnavailable:  true,
+		},
 		Logs: LogsConfig{
 			RetentionDays: 30,
 		},
+		Auth: AuthConfig{
+			Mode:     AuthDisabled,
+			Username: "admin",
+		},
+		Tracing: TracingConfig{
+			Exporter: TracingNone,
+		},
+	}
+}
+
+// SearchHistoryEntry records when an item was last searched on a server
+type SearchHistoryEntry struct {
+	ServerID       string         `json:"serverId"`
+	ItemID         int            `json:"itemId"`
+	Category       SearchCategory `json:"category"`
+	LastSearchedAt time.Time      `json:"lastSearchedAt"`
+}
+
+// SearchAttempts counts the searches of an item since a release was last grabbed for it
+type SearchAttempts struct {
+	Attempts       int       `json:"attempts"`
+	LastSearchedAt time.Time `json:"lastSearchedAt"`
+}
+
+// GivenUpItem is an item Janitarr has given up searching after repeated unsuccessful searches
+type GivenUpItem struct {
+	ServerID  string         `json:"serverId"`
+	Category  SearchCategory `json:"category"`
+	ItemID    int            `json:"itemId"`
+	Title     string         `json:"title"`
+	Policy    GiveUpPolicy   `json:"policy"`            // Policy applied when the item was given up on
+	Attempts  int            `json:"attempts"`          // Searches without a grab when last checked
+	MediaID   int            `json:"mediaId,omitempty"` // Movie or series the give-up tag was added to
+	GivenUpAt time.Time      `json:"givenUpAt"`
+	RetryAt   *time.Time     `json:"retryAt,omitempty"` // When the item is searched again, with backoff or snooze
+}
+
+// WantedItem is an entry of a cached wanted list. Data holds the item encoded as JSON.
+type WantedItem struct {
+	ID   int
+	Data string
+}
+
+// WantedCacheState describes a server's cached wanted lists
+type WantedCacheState struct {
+	ServerID      string    `json:"serverId"`
+	FullRefreshAt time.Time `json:"fullRefreshAt"` // When the lists were last read in full
+	RefreshedAt   time.Time `json:"refreshedAt"`   // When the lists were last brought up to date
+	MissingCount  int       `json:"missingCount"`
+	CutoffCount   int       `json:"cutoffCount"`
+}
+
+// PendingSearchCommand is a search command whose outcome is not known yet
+type PendingSearchCommand struct {
+	CommandID  int       `json:"commandId"`
+	SearchedAt time.Time `json:"searchedAt"`
+}
+
+// SearchOutcomeStats counts the outcomes of the latest search of each item on a server
+type SearchOutcomeStats struct {
+	ServerID   string `json:"serverId"`
+	ServerName string `json:"serverName"`
+	ServerType string `json:"serverType"`
+	Pending    int    `json:"pending"`
+	Completed  int    `json:"completed"`
+	Failed     int    `json:"failed"`
+	Grabbed    int    `json:"grabbed"`
+}
+
+// Finished returns the number of searches with a known outcome
+func (s SearchOutcomeStats) Finished() int {
+	return s.Completed + s.Failed + s.Grabbed
+}
+
+// SuccessRate returns the fraction of finished searches that grabbed a release (0 if none finished)
+func (s SearchOutcomeStats) SuccessRate() float64 {
+	if s.Finished() == 0 {
+		return 0
 	}
+	return float64(s.Grabbed) / float64(s.Finished())
 }
 
 // LogFilters represents filters for log queries
@@ -0,0 +1,171 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const wantedCacheQuery = `
+	SELECT c.server_id, c.full_refresh_at, c.refreshed_at,
+		(SELECT COUNT(*) FROM wanted_items w WHERE w.server_id = c.server_id AND w.category = 'missing'),
+		(SELECT COUNT(*) FROM wanted_items w WHERE w.server_id = c.server_id AND w.category = 'cutoff')
+	FROM wanted_cache c
+`
+
+// GetWantedCache returns the state of a server's cached wanted lists, or nil if they have never been cached
+func (db *DB) GetWantedCache(serverID string) (*WantedCacheState, error) {
+	state, err := scanWantedCache(db.conn.QueryRow(wantedCacheQuery+" WHERE c.server_id = ?", serverID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scanning wanted cache: %w", err)
+	}
+	return &state, nil
+}
+
+// GetAllWantedCache returns the state of every server's cached wanted lists, indexed by server ID.
+// Servers missing from the map have never been cached.
+func (db *DB) GetAllWantedCache() (map[string]WantedCacheState, error) {
+	rows, err := db.conn.Query(wantedCacheQuery)
+	if err != nil {
+		return nil, fmt.Errorf("querying wanted cache: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]WantedCacheState)
+	for rows.Next() {
+		state, err := scanWantedCache(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning wanted cache: %w", err)
+		}
+		result[state.ServerID] = state
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating wanted cache: %w", err)
+	}
+
+	return result, nil
+}
+
+// SetWantedCacheRefreshed records when a server's cached wanted lists were last read in full and
+// last brought up to date
+func (db *DB) SetWantedCacheRefreshed(serverID string, fullRefreshAt, refreshedAt time.Time) error {
+	_, err := db.conn.Exec(`
+		INSERT INTO wanted_cache (server_id, full_refresh_at, refreshed_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(server_id) DO UPDATE SET
+			full_refresh_at = excluded.full_refresh_at,
+			refreshed_at = excluded.refreshed_at
+	`, serverID, fullRefreshAt.UTC().Format(time.RFC3339), refreshedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("saving wanted cache: %w", err)
+	}
+	return nil
+}
+
+// GetWantedItems returns a server's cached wanted list in the order the server returned it
+func (db *DB) GetWantedItems(serverID string, category SearchCategory) ([]WantedItem, error) {
+	rows, err := db.conn.Query(`
+		SELECT item_id, data FROM wanted_items
+		WHERE server_id = ? AND category = ?
+		ORDER BY position
+	`, serverID, category)
+	if err != nil {
+		return nil, fmt.Errorf("querying wanted items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []WantedItem
+	for rows.Next() {
+		var item WantedItem
+		if err := rows.Scan(&item.ID, &item.Data); err != nil {
+			return nil, fmt.Errorf("scanning wanted item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating wanted items: %w", err)
+	}
+
+	return items, nil
+}
+
+// ReplaceWantedItems replaces a server's cached wanted list
+func (db *DB) ReplaceWantedItems(serverID string, category SearchCategory, items []WantedItem) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM wanted_items WHERE server_id = ? AND category = ?", serverID, category); err != nil {
+		return fmt.Errorf("clearing wanted items: %w", err)
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT OR REPLACE INTO wanted_items (server_id, category, item_id, position, data)
+		VALUES (?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("preparing wanted item insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for i, item := range items {
+		if _, err := stmt.Exec(serverID, category, item.ID, i, item.Data); err != nil {
+			return fmt.Errorf("caching item %d: %w", item.ID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// RemoveWantedItems removes items from a server's cached wanted list
+func (db *DB) RemoveWantedItems(serverID string, category SearchCategory, itemIDs []int) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare("DELETE FROM wanted_items WHERE server_id = ? AND category = ? AND item_id = ?")
+	if err != nil {
+		return fmt.Errorf("preparing wanted item delete: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, id := range itemIDs {
+		if _, err := stmt.Exec(serverID, category, id); err != nil {
+			return fmt.Errorf("removing item %d: %w", id, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ClearWantedCache forgets a server's cached wanted lists, so the next detection reads them in full
+func (db *DB) ClearWantedCache(serverID string) error {
+	if _, err := db.conn.Exec("DELETE FROM wanted_items WHERE server_id = ?", serverID); err != nil {
+		return fmt.Errorf("clearing wanted items: %w", err)
+	}
+	if _, err := db.conn.Exec("DELETE FROM wanted_cache WHERE server_id = ?", serverID); err != nil {
+		return fmt.Errorf("clearing wanted cache: %w", err)
+	}
+	return nil
+}
+
+// scanWantedCache scans a row of wantedCacheQuery
+func scanWantedCache(row rowScanner) (WantedCacheState, error) {
+	var state WantedCacheState
+	var fullRefreshAt, refreshedAt string
+	if err := row.Scan(&state.ServerID, &fullRefreshAt, &refreshedAt, &state.MissingCount, &state.CutoffCount); err != nil {
+		return state, err
+	}
+	state.FullRefreshAt, _ = time.Parse(time.RFC3339, fullRefreshAt)
+	state.RefreshedAt, _ = time.Parse(time.RFC3339, refreshedAt)
+	return state, nil
+}
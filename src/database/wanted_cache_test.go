@@ -0,0 +1,78 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWantedCache(t *testing.T) {
+	db := testDB(t)
+
+	server, err := db.AddServer("Radarr", "http://localhost:7878", "key", ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+
+	// Nothing is cached until the first detection
+	state, err := db.GetWantedCache(server.ID)
+	if err != nil || state != nil {
+		t.Fatalf("expected no cache, got %+v, %v", state, err)
+	}
+
+	missing := []WantedItem{{ID: 30, Data: `{"id":30}`}, {ID: 10, Data: `{"id":10}`}, {ID: 20, Data: `{"id":20}`}}
+	if err := db.ReplaceWantedItems(server.ID, SearchCategoryMissing, missing); err != nil {
+		t.Fatalf("ReplaceWantedItems failed: %v", err)
+	}
+	if err := db.ReplaceWantedItems(server.ID, SearchCategoryCutoff, []WantedItem{{ID: 5, Data: `{"id":5}`}}); err != nil {
+		t.Fatalf("ReplaceWantedItems failed: %v", err)
+	}
+
+	fullRefreshAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	refreshedAt := time.Now().Truncate(time.Second)
+	if err := db.SetWantedCacheRefreshed(server.ID, fullRefreshAt, refreshedAt); err != nil {
+		t.Fatalf("SetWantedCacheRefreshed failed: %v", err)
+	}
+
+	state, err = db.GetWantedCache(server.ID)
+	if err != nil || state == nil {
+		t.Fatalf("GetWantedCache failed: %+v, %v", state, err)
+	}
+	if !state.FullRefreshAt.Equal(fullRefreshAt) || !state.RefreshedAt.Equal(refreshedAt) {
+		t.Errorf("expected refresh times %v and %v, got %+v", fullRefreshAt, refreshedAt, state)
+	}
+	if state.MissingCount != 3 || state.CutoffCount != 1 {
+		t.Errorf("expected 3 missing and 1 cutoff, got %+v", state)
+	}
+
+	// Removing items keeps the remaining order
+	if err := db.RemoveWantedItems(server.ID, SearchCategoryMissing, []int{10}); err != nil {
+		t.Fatalf("RemoveWantedItems failed: %v", err)
+	}
+	items, err := db.GetWantedItems(server.ID, SearchCategoryMissing)
+	if err != nil {
+		t.Fatalf("GetWantedItems failed: %v", err)
+	}
+	if len(items) != 2 || items[0].ID != 30 || items[1].ID != 20 || items[1].Data != `{"id":20}` {
+		t.Errorf("expected items 30 and 20 in order, got %+v", items)
+	}
+
+	all, err := db.GetAllWantedCache()
+	if err != nil {
+		t.Fatalf("GetAllWantedCache failed: %v", err)
+	}
+	if all[server.ID].MissingCount != 2 {
+		t.Errorf("expected 2 missing after removal, got %+v", all)
+	}
+
+	// Clearing forgets both the items and the refresh times
+	if err := db.ClearWantedCache(server.ID); err != nil {
+		t.Fatalf("ClearWantedCache failed: %v", err)
+	}
+	state, err = db.GetWantedCache(server.ID)
+	if err != nil || state != nil {
+		t.Errorf("expected no cache after clearing, got %+v, %v", state, err)
+	}
+	if items, _ := db.GetWantedItems(server.ID, SearchCategoryCutoff); len(items) != 0 {
+		t.Errorf("expected cached items to be cleared, got %+v", items)
+	}
+}
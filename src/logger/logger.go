@@ -58,23 +58,33 @@ func (l *Logger) LogCycleStart(isManual bool) *LogEntry {
 }
 
 // LogCycleEnd logs the end of an automation cycle.
-func (l *Logger) LogCycleEnd(totalSearches, failures int, isManual bool) *LogEntry {
+func (l *Logger) LogCycleEnd(summary CycleSummary, isManual bool) *LogEntry {
+	metadata := map[string]interface{}{
+		"searches":    summary.Searches,
+		"failures":    summary.Failures,
+		"manual":      isManual,
+		"missing":     summary.MissingTriggered,
+		"cutoff":      summary.CutoffTriggered,
+		"servers":     summary.ServersChecked,
+		"duration_ms": summary.Duration.Milliseconds(),
+		"dry_run":     summary.DryRun,
+	}
+	if len(summary.Errors) > 0 {
+		metadata["errors"] = summary.Errors
+	}
+
 	entry := LogEntry{
 		Type:     LogTypeCycleEnd,
 		Message:  "Automation cycle finished.",
 		IsManual: isManual,
-		Count:    totalSearches, // Store total searches in count
-		Metadata: map[string]interface{}{
-			"searches": totalSearches,
-			"failures": failures,
-			"manual":   isManual,
-		},
+		Count:    summary.Searches, // Store total searches in count
+		Metadata: metadata,
 	}
 
 	// Console log at info level
 	l.console.Info("Automation cycle finished",
-		"searches", totalSearches,
-		"failures", failures,
+		"searches", summary.Searches,
+		"failures", summary.Failures,
 		"manual", isManual)
 
 	return l.AddLog(entry)
@@ -126,8 +136,44 @@ func (l *Logger) LogSearches(serverName, serverType, category string, count int,
 	return l.AddLog(entry)
 }
 
+// outcomeMessages describes each search outcome in the activity log.
+var outcomeMessages = map[string]string{
+	"completed": "Searches finished without grabbing a release.",
+	"failed":    "Searches failed.",
+	"grabbed":   "Releases grabbed after search.",
+}
+
+// LogSearchOutcome logs the outcome of searches once the server reports it.
+func (l *Logger) LogSearchOutcome(serverName, serverType, outcome string, count int) *LogEntry {
+	message, ok := outcomeMessages[outcome]
+	if !ok {
+		message = fmt.Sprintf("Searches %s.", outcome)
+	}
+
+	entry := LogEntry{
+		Type:       LogTypeOutcome,
+		ServerName: serverName,
+		ServerType: serverType,
+		Count:      count,
+		Message:    message,
+		Metadata: map[string]interface{}{
+			"outcome": outcome,
+		},
+	}
+
+	// Console log at info level
+	l.console.Info("Search outcome",
+		"server", serverName,
+		"type", serverType,
+		"outcome", outcome,
+		"count", count)
+
+	return l.AddLog(entry)
+}
+
 // LogMovieSearch logs a movie search with detailed metadata.
-func (l *Logger) LogMovieSearch(serverName, serverType, title string, year int, qualityProfile, category string) *LogEntry {
+// requestedBy names who requested the movie in Overseerr or Jellyseerr, if anyone did.
+func (l *Logger) LogMovieSearch(serverName, serverType string, movieID int, title string, year int, qualityProfile, category, requestedBy string) *LogEntry {
 	entry := LogEntry{
 		Type:       LogTypeSearch,
 		ServerName: serverName,
@@ -136,25 +182,28 @@ func (l *Logger) LogMovieSearch(serverName, serverType, title string, year int,
 		Message:    "Search triggered.",
 		Count:      1,
 		Metadata: map[string]interface{}{
+			"itemId":  movieID,
 			"title":   title,
 			"year":    year,
 			"quality": qualityProfile,
 		},
 	}
+	addRequestedBy(&entry, requestedBy)
 
 	// Console log at info level with detailed metadata
-	l.console.Info("Search triggered",
+	l.console.Info("Search triggered", withRequestedBy(requestedBy,
 		"title", title,
 		"year", year,
 		"quality", qualityProfile,
 		"server", serverName,
-		"category", category)
+		"category", category)...)
 
 	return l.AddLog(entry)
 }
 
 // LogEpisodeSearch logs an episode search with detailed metadata.
-func (l *Logger) LogEpisodeSearch(serverName, serverType, seriesTitle, episodeTitle string, season, episode int, qualityProfile, category string) *LogEntry {
+// requestedBy names who requested the series in Overseerr or Jellyseerr, if anyone did.
+func (l *Logger) LogEpisodeSearch(serverName, serverType string, episodeID, seriesID int, seriesTitle, episodeTitle string, season, episode int, qualityProfile, category, requestedBy string) *LogEntry {
 	episodeStr := fmt.Sprintf("S%02dE%02d", season, episode)
 	entry := LogEntry{
 		Type:       LogTypeSearch,
@@ -164,19 +213,140 @@ func (l *Logger) LogEpisodeSearch(serverName, serverType, seriesTitle, episodeTi
 		Message:    "Search triggered.",
 		Count:      1,
 		Metadata: map[string]interface{}{
-			"series":  seriesTitle,
-			"episode": episodeStr,
-			"title":   episodeTitle,
-			"quality": qualityProfile,
+			"itemId":   episodeID,
+			"seriesId": seriesID,
+			"series":   seriesTitle,
+			"episode":  episodeStr,
+			"title":    episodeTitle,
+			"quality":  qualityProfile,
+			"command":  "EpisodeSearch",
 		},
 	}
+	addRequestedBy(&entry, requestedBy)
 
 	// Console log at info level with detailed metadata
-	l.console.Info("Search triggered",
+	l.console.Info("Search triggered", withRequestedBy(requestedBy,
 		"series", seriesTitle,
 		"episode", episodeStr,
 		"title", episodeTitle,
 		"quality", qualityProfile,
+		"command", "EpisodeSearch",
+		"server", serverName,
+		"category", category)...)
+
+	return l.AddLog(entry)
+}
+
+// LogSeasonSearch logs a Sonarr search covering a whole season (SeasonSearch) or series (SeriesSearch).
+// requestedBy names who requested the series in Overseerr or Jellyseerr, if anyone did.
+func (l *Logger) LogSeasonSearch(serverName, serverType, command string, seriesID int, seriesTitle string, season, episodes int, qualityProfile, category, requestedBy string) *LogEntry {
+	message, consoleMessage := "Season search triggered.", "Season search triggered"
+	metadata := map[string]interface{}{
+		"seriesId": seriesID,
+		"series":   seriesTitle,
+		"episodes": episodes,
+		"quality":  qualityProfile,
+		"command":  command,
+	}
+	if command == "SeriesSearch" {
+		message, consoleMessage = "Series search triggered.", "Series search triggered"
+	} else {
+		metadata["season"] = fmt.Sprintf("S%02d", season)
+	}
+
+	entry := LogEntry{
+		Type:       LogTypeSearch,
+		ServerName: serverName,
+		ServerType: serverType,
+		Category:   category,
+		Message:    message,
+		Count:      1,
+		Metadata:   metadata,
+	}
+	addRequestedBy(&entry, requestedBy)
+
+	// Console log at info level with detailed metadata
+	l.console.Info(consoleMessage, withRequestedBy(requestedBy,
+		"series", seriesTitle,
+		"season", season,
+		"episodes", episodes,
+		"quality", qualityProfile,
+		"command", command,
+		"server", serverName,
+		"category", category)...)
+
+	return l.AddLog(entry)
+}
+
+// withRequestedBy adds who requested a searched item to console log key-value pairs.
+func withRequestedBy(requestedBy string, keyvals ...interface{}) []interface{} {
+	if requestedBy != "" {
+		keyvals = append(keyvals, "requestedBy", requestedBy)
+	}
+	return keyvals
+}
+
+// addRequestedBy records who requested a searched item, so the log shows why it was searched first.
+func addRequestedBy(entry *LogEntry, requestedBy string) {
+	if requestedBy != "" {
+		entry.Metadata["requestedBy"] = requestedBy
+	}
+}
+
+// LogAlbumSearch logs an album search with detailed metadata.
+func (l *Logger) LogAlbumSearch(serverName, serverType string, albumID int, artistName, title string, year int, qualityProfile, category string) *LogEntry {
+	entry := LogEntry{
+		Type:       LogTypeSearch,
+		ServerName: serverName,
+		ServerType: serverType,
+		Category:   category,
+		Message:    "Search triggered.",
+		Count:      1,
+		Metadata: map[string]interface{}{
+			"itemId":  albumID,
+			"artist":  artistName,
+			"title":   title,
+			"year":    year,
+			"quality": qualityProfile,
+		},
+	}
+
+	// Console log at info level with detailed metadata
+	l.console.Info("Search triggered",
+		"artist", artistName,
+		"title", title,
+		"year", year,
+		"quality", qualityProfile,
+		"server", serverName,
+		"category", category)
+
+	return l.AddLog(entry)
+}
+
+// LogBookSearch logs a book search with detailed metadata.
+func (l *Logger) LogBookSearch(serverName, serverType string, bookID int, authorName, title string, year int, qualityProfile, category string) *LogEntry {
+	entry := LogEntry{
+		Type:       LogTypeSearch,
+		ServerName: serverName,
+		ServerType: serverType,
+		Category:   category,
+		Message:    "Search triggered.",
+		Count:      1,
+		Metadata: map[string]interface{}{
+			"itemId":  bookID,
+			"author":  authorName,
+			"title":   title,
+			"year":    year,
+			"quality": qualityProfile,
+		},
+	}
+
+	// Console log at info level with detailed metadata
+	l.console.Info("Search triggered",
+		"author", authorName,
+		"title", title,
+		"year", year,
+		"quality", qualityProfile,
 		"server", serverName,
 		"category", category)
 
@@ -221,6 +391,28 @@ func (l *Logger) LogSearchError(serverName, serverType, category, reason string)
 	return l.AddLog(entry)
 }
 
+// LogRateLimitLockout logs that a server rate limited three searches in a row and is skipped
+// for the rest of the cycle. Notifications treat this entry, not the individual 429s, as the
+// rate limit event.
+func (l *Logger) LogRateLimitLockout(serverName, serverType string) *LogEntry {
+	entry := LogEntry{
+		Type:       LogTypeError,
+		ServerName: serverName,
+		ServerType: serverType,
+		Message:    "rate limited on 3 consecutive searches, skipping for the rest of the cycle",
+		Metadata: map[string]interface{}{
+			"rate_limit_lockout": true,
+		},
+	}
+
+	// Console log at warn level
+	l.console.Warn("Rate limit lockout",
+		"server", serverName,
+		"type", serverType)
+
+	return l.AddLog(entry)
+}
+
 // Subscribe returns a channel that receives log entries.
 func (l *Logger) Subscribe() <-chan LogEntry {
 	l.mu.Lock()
@@ -43,7 +43,7 @@ func TestLogCycleEnd_Persists(t *testing.T) {
 	db := &mockDB{}
 	logger := NewLogger(db, LevelInfo, false)
 
-	logger.LogCycleEnd(10, 2, false)
+	logger.LogCycleEnd(CycleSummary{Searches: 10, Failures: 2}, false)
 
 	if len(db.logs) != 1 {
 		t.Fatalf("expected 1 log entry, got %d", len(db.logs))
@@ -104,6 +104,20 @@ func TestLogError_Persists(t *testing.T) {
 	}
 }
 
+func TestLogRateLimitLockout_Persists(t *testing.T) {
+	db := &mockDB{}
+	logger := NewLogger(db, LevelInfo, false)
+
+	logger.LogRateLimitLockout("radarr", "radarr")
+
+	if len(db.logs) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(db.logs))
+	}
+	if db.logs[0].Type != LogTypeError || db.logs[0].Metadata["rate_limit_lockout"] != true {
+		t.Errorf("expected a lockout error entry, got %+v", db.logs[0])
+	}
+}
+
 func TestBroadcast_SendsToSubscribers(t *testing.T) {
 	db := &mockDB{}
 	logger := NewLogger(db, LevelInfo, false)
@@ -19,6 +19,8 @@ const (
 	LogTypeSearch LogEntryType = "search"
 	// LogTypeError indicates an error occurred.
 	LogTypeError LogEntryType = "error"
+	// LogTypeOutcome indicates triggered searches finished, failed or grabbed a release.
+	LogTypeOutcome LogEntryType = "outcome"
 )
 
 // LogEntry represents a single log entry.
@@ -36,6 +38,18 @@ type LogEntry struct {
 	Metadata   map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// CycleSummary holds the totals recorded when an automation cycle finishes.
+type CycleSummary struct {
+	Searches         int           `json:"searches"`         // Total searches triggered
+	MissingTriggered int           `json:"missingTriggered"` // Searches for missing items
+	CutoffTriggered  int           `json:"cutoffTriggered"`  // Searches for quality upgrades
+	Failures         int           `json:"failures"`         // Failed detections and searches
+	ServersChecked   int           `json:"serversChecked"`   // Servers included in detection
+	Duration         time.Duration `json:"duration"`         // How long the cycle took
+	DryRun           bool          `json:"dryRun"`           // Whether searches were only previewed
+	Errors           []string      `json:"errors,omitempty"` // Error messages collected during the cycle
+}
+
 // LogFilters contains optional filters for querying logs.
 type LogFilters struct {
 	Type      *string
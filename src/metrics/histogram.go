@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// labelEscaper escapes label values for the Prometheus text format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labels formats the server and type labels
+func (k serverKey) labels() string {
+	return fmt.Sprintf("server=\"%s\",type=\"%s\"", labelEscaper.Replace(k.name), labelEscaper.Replace(k.serverType))
+}
+
+// labels formats the server, type and category labels
+func (k searchKey) labels() string {
+	return fmt.Sprintf("%s,category=\"%s\"", k.serverKey.labels(), labelEscaper.Replace(k.category))
+}
+
+// sortedServerKeys returns the keys of a per-server map in a stable order
+func sortedServerKeys[V any](values map[serverKey]V) []serverKey {
+	keys := make([]serverKey, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].serverType < keys[j].serverType
+	})
+	return keys
+}
+
+// sortedSearchKeys returns the keys of a per-search map in a stable order
+func sortedSearchKeys(values map[searchKey]int64) []searchKey {
+	keys := make([]searchKey, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].serverKey != keys[j].serverKey {
+			if keys[i].name != keys[j].name {
+				return keys[i].name < keys[j].name
+			}
+			return keys[i].serverType < keys[j].serverType
+		}
+		return keys[i].category < keys[j].category
+	})
+	return keys
+}
+
+// histogram accumulates observations into fixed buckets.
+// Unlike raw duration slices it uses constant memory however many observations are made.
+type histogram struct {
+	buckets []float64
+	counts  []int64 // Observations less than or equal to each bucket
+	sum     float64
+	count   int64
+}
+
+// newHistogram creates a histogram with the given ascending bucket bounds
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{
+		buckets: buckets,
+		counts:  make([]int64, len(buckets)),
+	}
+}
+
+// observe adds a value to the histogram
+func (h *histogram) observe(value float64) {
+	for i, bound := range h.buckets {
+		if value <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += value
+	h.count++
+}
+
+// write appends the histogram in Prometheus text format; labels may be empty
+func (h *histogram) write(sb *strings.Builder, name, labels string) {
+	prefix := ""
+	if labels != "" {
+		prefix = labels + ","
+	}
+
+	for i, bound := range h.buckets {
+		sb.WriteString(fmt.Sprintf("%s_bucket{%sle=\"%s\"} %d\n", name, prefix, strconv.FormatFloat(bound, 'f', -1, 64), h.counts[i]))
+	}
+	sb.WriteString(fmt.Sprintf("%s_bucket{%sle=\"+Inf\"} %d\n", name, prefix, h.count))
+
+	if labels != "" {
+		labels = "{" + labels + "}"
+	}
+	sb.WriteString(fmt.Sprintf("%s_sum%s %.6f\n", name, labels, h.sum))
+	sb.WriteString(fmt.Sprintf("%s_count%s %d\n", name, labels, h.count))
+}
@@ -19,4 +19,8 @@ type DatabaseProvider interface {
 	Ping() error
 	GetLogCount(ctx context.Context) (int, error)
 	GetServerCounts() (map[string]database.ServerCounts, error)
+	GetSearchOutcomeStats() ([]database.SearchOutcomeStats, error)
 }
+
+// Metrics records measurements from the automation services
+var _ services.MetricsRecorder = (*Metrics)(nil)
@@ -9,32 +9,64 @@ import (
 	"time"
 )
 
+// Histogram buckets in seconds for automation timings
+var (
+	cycleDurationBuckets     = []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800}
+	detectionDurationBuckets = []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+)
+
+// serverKey identifies a server in per-server metrics
+type serverKey struct {
+	name       string
+	serverType string
+}
+
+// searchKey identifies a server and search category
+type searchKey struct {
+	serverKey
+	category string
+}
+
+// backlog holds the latest detected item counts for a server
+type backlog struct {
+	missing int
+	cutoff  int
+}
+
 // Metrics collects and exposes Prometheus-compatible metrics
 type Metrics struct {
-	mu             sync.RWMutex
-	startTime      time.Time
-	version        string
-	cyclesTotal    int64
-	cyclesFailed   int64
-	searchesTotal  map[string]int64 // key: "type:category"
-	searchesFailed map[string]int64 // key: "type:category"
-	httpRequests   map[string]int64 // key: "method:path:status"
-	httpDurations  map[string][]float64
-	scheduler      SchedulerStatusProvider
-	database       DatabaseProvider
-	cacheExpiry    time.Time
-	cachedLogCount int
-	cachedDbStatus int // 1 for connected, 0 for disconnected
+	mu                 sync.RWMutex
+	startTime          time.Time
+	version            string
+	cyclesTotal        int64
+	cyclesFailed       int64
+	cycleDurations     *histogram
+	detectionDurations map[serverKey]*histogram
+	backlogs           map[serverKey]backlog
+	searchesTotal      map[searchKey]int64
+	searchesFailed     map[searchKey]int64
+	rateLimitLockouts  map[serverKey]int64
+	httpRequests       map[string]int64 // key: "method:path:status"
+	httpDurations      map[string][]float64
+	scheduler          SchedulerStatusProvider
+	database           DatabaseProvider
+	cacheExpiry        time.Time
+	cachedLogCount     int
+	cachedDbStatus     int // 1 for connected, 0 for disconnected
 }
 
 // NewMetrics creates a new Metrics instance
 func NewMetrics() *Metrics {
 	return &Metrics{
-		startTime:      time.Now(),
-		searchesTotal:  make(map[string]int64),
-		searchesFailed: make(map[string]int64),
-		httpRequests:   make(map[string]int64),
-		httpDurations:  make(map[string][]float64),
+		startTime:          time.Now(),
+		cycleDurations:     newHistogram(cycleDurationBuckets),
+		detectionDurations: make(map[serverKey]*histogram),
+		backlogs:           make(map[serverKey]backlog),
+		searchesTotal:      make(map[searchKey]int64),
+		searchesFailed:     make(map[searchKey]int64),
+		rateLimitLockouts:  make(map[serverKey]int64),
+		httpRequests:       make(map[string]int64),
+		httpDurations:      make(map[string][]float64),
 	}
 }
 
@@ -70,18 +102,55 @@ func (m *Metrics) IncrementCycles(failed bool) {
 	}
 }
 
-// IncrementSearches increments the search counter for a specific type and category
-func (m *Metrics) IncrementSearches(serverType, category string, failed bool) {
+// ObserveCycle records a completed automation cycle and its duration
+func (m *Metrics) ObserveCycle(duration time.Duration, failed bool) {
+	m.IncrementCycles(failed)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cycleDurations.observe(duration.Seconds())
+}
+
+// ObserveDetection records how long detection took for a server
+func (m *Metrics) ObserveDetection(serverName, serverType string, duration time.Duration) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	key := fmt.Sprintf("%s:%s", serverType, category)
-	m.searchesTotal[key]++
+	key := serverKey{serverName, serverType}
+	if m.detectionDurations[key] == nil {
+		m.detectionDurations[key] = newHistogram(detectionDurationBuckets)
+	}
+	m.detectionDurations[key].observe(duration.Seconds())
+}
+
+// SetBacklog records the number of missing and cutoff unmet items last detected on a server
+func (m *Metrics) SetBacklog(serverName, serverType string, missing, cutoff int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.backlogs[serverKey{serverName, serverType}] = backlog{missing: missing, cutoff: cutoff}
+}
+
+// IncrementSearches adds count searched items to the counters for a server and category
+func (m *Metrics) IncrementSearches(serverName, serverType, category string, count int, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := searchKey{serverKey{serverName, serverType}, category}
+	m.searchesTotal[key] += int64(count)
 	if failed {
-		m.searchesFailed[key]++
+		m.searchesFailed[key] += int64(count)
 	}
 }
 
+// IncrementRateLimitLockouts counts a server being skipped for the rest of a cycle after repeated rate limiting
+func (m *Metrics) IncrementRateLimitLockouts(serverName, serverType string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rateLimitLockouts[serverKey{serverName, serverType}]++
+}
+
 // RecordHTTPRequest records an HTTP request with its duration
 func (m *Metrics) RecordHTTPRequest(method, path string, status int, duration time.Duration) {
 	m.mu.Lock()
@@ -169,7 +238,6 @@ func (m *Metrics) Format() string {
 	m.mu.RLock()
 	cyclesTotal := m.cyclesTotal
 	cyclesFailed := m.cyclesFailed
-	m.mu.RUnlock()
 
 	// Cycles
 	sb.WriteString("# HELP janitarr_cycles_total Total number of automation cycles executed\n")
@@ -182,48 +250,61 @@ func (m *Metrics) Format() string {
 	sb.WriteString(fmt.Sprintf("janitarr_cycles_failed_total %d\n", cyclesFailed))
 	sb.WriteString("\n")
 
-	// Searches by type and category
-	if len(m.searchesTotal) > 0 {
-		sb.WriteString("# HELP janitarr_searches_total Total number of searches triggered\n")
-		sb.WriteString("# TYPE janitarr_searches_total counter\n")
+	if m.cycleDurations.count > 0 {
+		sb.WriteString("# HELP janitarr_cycle_duration_seconds Automation cycle duration in seconds\n")
+		sb.WriteString("# TYPE janitarr_cycle_duration_seconds histogram\n")
+		m.cycleDurations.write(&sb, "janitarr_cycle_duration_seconds", "")
+		sb.WriteString("\n")
+	}
 
-		// Sort keys for consistent output
-		var keys []string
-		for k := range m.searchesTotal {
-			keys = append(keys, k)
+	// Detection by server
+	if len(m.detectionDurations) > 0 {
+		sb.WriteString("# HELP janitarr_detection_duration_seconds Detection duration in seconds by server\n")
+		sb.WriteString("# TYPE janitarr_detection_duration_seconds histogram\n")
+		for _, key := range sortedServerKeys(m.detectionDurations) {
+			m.detectionDurations[key].write(&sb, "janitarr_detection_duration_seconds", key.labels())
 		}
-		sort.Strings(keys)
+		sb.WriteString("\n")
+	}
 
-		for _, key := range keys {
-			parts := strings.Split(key, ":")
-			if len(parts) == 2 {
-				sb.WriteString(fmt.Sprintf("janitarr_searches_total{type=\"%s\",category=\"%s\"} %d\n",
-					parts[0], parts[1], m.searchesTotal[key]))
-			}
+	if len(m.backlogs) > 0 {
+		sb.WriteString("# HELP janitarr_backlog_items Missing and cutoff unmet items found by the last detection by server\n")
+		sb.WriteString("# TYPE janitarr_backlog_items gauge\n")
+		for _, key := range sortedServerKeys(m.backlogs) {
+			sb.WriteString(fmt.Sprintf("janitarr_backlog_items{%s,category=\"missing\"} %d\n", key.labels(), m.backlogs[key].missing))
+			sb.WriteString(fmt.Sprintf("janitarr_backlog_items{%s,category=\"cutoff\"} %d\n", key.labels(), m.backlogs[key].cutoff))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Searches by server and category
+	if len(m.searchesTotal) > 0 {
+		sb.WriteString("# HELP janitarr_searches_total Total number of items searched\n")
+		sb.WriteString("# TYPE janitarr_searches_total counter\n")
+		for _, key := range sortedSearchKeys(m.searchesTotal) {
+			sb.WriteString(fmt.Sprintf("janitarr_searches_total{%s} %d\n", key.labels(), m.searchesTotal[key]))
 		}
 		sb.WriteString("\n")
 	}
 
 	if len(m.searchesFailed) > 0 {
-		sb.WriteString("# HELP janitarr_searches_failed_total Total number of failed searches\n")
+		sb.WriteString("# HELP janitarr_searches_failed_total Total number of items whose search request failed\n")
 		sb.WriteString("# TYPE janitarr_searches_failed_total counter\n")
-
-		// Sort keys for consistent output
-		var keys []string
-		for k := range m.searchesFailed {
-			keys = append(keys, k)
+		for _, key := range sortedSearchKeys(m.searchesFailed) {
+			sb.WriteString(fmt.Sprintf("janitarr_searches_failed_total{%s} %d\n", key.labels(), m.searchesFailed[key]))
 		}
-		sort.Strings(keys)
+		sb.WriteString("\n")
+	}
 
-		for _, key := range keys {
-			parts := strings.Split(key, ":")
-			if len(parts) == 2 {
-				sb.WriteString(fmt.Sprintf("janitarr_searches_failed_total{type=\"%s\",category=\"%s\"} %d\n",
-					parts[0], parts[1], m.searchesFailed[key]))
-			}
+	if len(m.rateLimitLockouts) > 0 {
+		sb.WriteString("# HELP janitarr_rate_limit_lockouts_total Times a server was skipped for the rest of a cycle after repeated rate limiting\n")
+		sb.WriteString("# TYPE janitarr_rate_limit_lockouts_total counter\n")
+		for _, key := range sortedServerKeys(m.rateLimitLockouts) {
+			sb.WriteString(fmt.Sprintf("janitarr_rate_limit_lockouts_total{%s} %d\n", key.labels(), m.rateLimitLockouts[key]))
 		}
 		sb.WriteString("\n")
 	}
+	m.mu.RUnlock()
 
 	// HTTP requests
 	if len(m.httpRequests) > 0 {
@@ -329,6 +410,31 @@ func (m *Metrics) Format() string {
 		}
 	}
 
+	// Search outcome metrics
+	if database != nil {
+		outcomeStats, err := database.GetSearchOutcomeStats()
+		if err == nil && len(outcomeStats) > 0 {
+			sb.WriteString("# HELP janitarr_search_outcomes Latest search outcome of each item by server\n")
+			sb.WriteString("# TYPE janitarr_search_outcomes gauge\n")
+			for _, stats := range outcomeStats {
+				labels := serverKey{stats.ServerName, stats.ServerType}.labels()
+				sb.WriteString(fmt.Sprintf("janitarr_search_outcomes{%s,outcome=\"pending\"} %d\n", labels, stats.Pending))
+				sb.WriteString(fmt.Sprintf("janitarr_search_outcomes{%s,outcome=\"completed\"} %d\n", labels, stats.Completed))
+				sb.WriteString(fmt.Sprintf("janitarr_search_outcomes{%s,outcome=\"failed\"} %d\n", labels, stats.Failed))
+				sb.WriteString(fmt.Sprintf("janitarr_search_outcomes{%s,outcome=\"grabbed\"} %d\n", labels, stats.Grabbed))
+			}
+			sb.WriteString("\n")
+
+			sb.WriteString("# HELP janitarr_search_success_ratio Fraction of finished searches that grabbed a release by server\n")
+			sb.WriteString("# TYPE janitarr_search_success_ratio gauge\n")
+			for _, stats := range outcomeStats {
+				sb.WriteString(fmt.Sprintf("janitarr_search_success_ratio{%s} %.4f\n",
+					serverKey{stats.ServerName, stats.ServerType}.labels(), stats.SuccessRate()))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
 	// Database metrics
 	if database != nil {
 		// Database connection status
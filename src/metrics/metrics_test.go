@@ -78,29 +78,29 @@ func TestIncrementSearches(t *testing.T) {
 	m := NewMetrics()
 
 	// Increment successful searches
-	m.IncrementSearches("radarr", "missing", false)
-	key := "radarr:missing"
-	if m.searchesTotal[key] != 1 {
-		t.Errorf("expected searchesTotal[%s] to be 1, got %d", key, m.searchesTotal[key])
+	m.IncrementSearches("Radarr", "radarr", "missing", 3, false)
+	key := searchKey{serverKey{"Radarr", "radarr"}, "missing"}
+	if m.searchesTotal[key] != 3 {
+		t.Errorf("expected searchesTotal[%v] to be 3, got %d", key, m.searchesTotal[key])
 	}
 	if m.searchesFailed[key] != 0 {
-		t.Errorf("expected searchesFailed[%s] to be 0, got %d", key, m.searchesFailed[key])
+		t.Errorf("expected searchesFailed[%v] to be 0, got %d", key, m.searchesFailed[key])
 	}
 
 	// Increment failed search
-	m.IncrementSearches("radarr", "missing", true)
-	if m.searchesTotal[key] != 2 {
-		t.Errorf("expected searchesTotal[%s] to be 2, got %d", key, m.searchesTotal[key])
+	m.IncrementSearches("Radarr", "radarr", "missing", 2, true)
+	if m.searchesTotal[key] != 5 {
+		t.Errorf("expected searchesTotal[%v] to be 5, got %d", key, m.searchesTotal[key])
 	}
-	if m.searchesFailed[key] != 1 {
-		t.Errorf("expected searchesFailed[%s] to be 1, got %d", key, m.searchesFailed[key])
+	if m.searchesFailed[key] != 2 {
+		t.Errorf("expected searchesFailed[%v] to be 2, got %d", key, m.searchesFailed[key])
 	}
 
-	// Different type and category
-	m.IncrementSearches("sonarr", "cutoff", false)
-	key2 := "sonarr:cutoff"
+	// Different server, type and category
+	m.IncrementSearches("Sonarr", "sonarr", "cutoff", 1, false)
+	key2 := searchKey{serverKey{"Sonarr", "sonarr"}, "cutoff"}
 	if m.searchesTotal[key2] != 1 {
-		t.Errorf("expected searchesTotal[%s] to be 1, got %d", key2, m.searchesTotal[key2])
+		t.Errorf("expected searchesTotal[%v] to be 1, got %d", key2, m.searchesTotal[key2])
 	}
 }
 
@@ -148,8 +148,8 @@ func TestFormat_PrometheusFormat(t *testing.T) {
 	// Add some data
 	m.IncrementCycles(false)
 	m.IncrementCycles(true)
-	m.IncrementSearches("radarr", "missing", false)
-	m.IncrementSearches("sonarr", "cutoff", true)
+	m.IncrementSearches("Radarr", "radarr", "missing", 1, false)
+	m.IncrementSearches("Sonarr", "sonarr", "cutoff", 1, true)
 	m.RecordHTTPRequest("GET", "/api/health", 200, 50*time.Millisecond)
 	m.RecordHTTPRequest("GET", "/api/health", 200, 100*time.Millisecond)
 
@@ -168,11 +168,11 @@ func TestFormat_PrometheusFormat(t *testing.T) {
 		"janitarr_cycles_failed_total 1",
 		"# HELP janitarr_searches_total",
 		"# TYPE janitarr_searches_total counter",
-		"janitarr_searches_total{type=\"radarr\",category=\"missing\"} 1",
-		"janitarr_searches_total{type=\"sonarr\",category=\"cutoff\"} 1",
+		"janitarr_searches_total{server=\"Radarr\",type=\"radarr\",category=\"missing\"} 1",
+		"janitarr_searches_total{server=\"Sonarr\",type=\"sonarr\",category=\"cutoff\"} 1",
 		"# HELP janitarr_searches_failed_total",
 		"# TYPE janitarr_searches_failed_total counter",
-		"janitarr_searches_failed_total{type=\"sonarr\",category=\"cutoff\"} 1",
+		"janitarr_searches_failed_total{server=\"Sonarr\",type=\"sonarr\",category=\"cutoff\"} 1",
 		"# HELP janitarr_http_requests_total",
 		"# TYPE janitarr_http_requests_total counter",
 		"janitarr_http_requests_total{method=\"GET\",path=\"/api/health\",status=\"200\"} 2",
@@ -308,6 +308,7 @@ type mockDatabase struct {
 	logCountErr     error
 	serverCounts    map[string]database.ServerCounts
 	serverCountsErr error
+	outcomeStats    []database.SearchOutcomeStats
 }
 
 func (m *mockDatabase) Ping() error {
@@ -322,6 +323,10 @@ func (m *mockDatabase) GetServerCounts() (map[string]database.ServerCounts, erro
 	return m.serverCounts, m.serverCountsErr
 }
 
+func (m *mockDatabase) GetSearchOutcomeStats() ([]database.SearchOutcomeStats, error) {
+	return m.outcomeStats, nil
+}
+
 // Tests for new metrics
 
 func TestSetVersion(t *testing.T) {
@@ -441,6 +446,29 @@ func TestDatabaseMetrics(t *testing.T) {
 	}
 }
 
+func TestSearchOutcomeMetrics(t *testing.T) {
+	m := NewMetrics()
+	m.SetDatabase(&mockDatabase{
+		outcomeStats: []database.SearchOutcomeStats{
+			{ServerName: "movies", ServerType: "radarr", Pending: 2, Completed: 5, Failed: 1, Grabbed: 2},
+			{ServerName: "tv", ServerType: "sonarr"},
+		},
+	})
+	output := m.Format()
+
+	expected := []string{
+		`janitarr_search_outcomes{server="movies",type="radarr",outcome="pending"} 2`,
+		`janitarr_search_outcomes{server="movies",type="radarr",outcome="grabbed"} 2`,
+		`janitarr_search_success_ratio{server="movies",type="radarr"} 0.2500`,
+		`janitarr_search_success_ratio{server="tv",type="sonarr"} 0.0000`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(output, line) {
+			t.Errorf("output missing %q", line)
+		}
+	}
+}
+
 func TestDatabaseMetrics_Disconnected(t *testing.T) {
 	m := NewMetrics()
 
@@ -527,7 +555,7 @@ func TestFormat_WithAllMetrics(t *testing.T) {
 	m.SetDatabase(mockDB)
 
 	m.IncrementCycles(false)
-	m.IncrementSearches("radarr", "missing", false)
+	m.IncrementSearches("Radarr", "radarr", "missing", 1, false)
 	m.RecordHTTPRequest("GET", "/metrics", 200, 10*time.Millisecond)
 
 	output := m.Format()
@@ -556,3 +584,42 @@ func TestFormat_WithAllMetrics(t *testing.T) {
 		}
 	}
 }
+
+func TestAutomationMetrics(t *testing.T) {
+	m := NewMetrics()
+
+	m.ObserveCycle(45*time.Second, false)
+	m.ObserveCycle(10*time.Minute, true)
+	m.ObserveDetection("Movies", "radarr", 2*time.Second)
+	m.SetBacklog("Movies", "radarr", 120, 30)
+	m.SetBacklog("Movies", "radarr", 110, 28) // Latest detection replaces the gauge
+	m.IncrementRateLimitLockouts("TV \"4K\"", "sonarr")
+
+	output := m.Format()
+
+	expected := []string{
+		"janitarr_cycles_total 2",
+		"janitarr_cycles_failed_total 1",
+		"# TYPE janitarr_cycle_duration_seconds histogram",
+		"janitarr_cycle_duration_seconds_bucket{le=\"60\"} 1",
+		"janitarr_cycle_duration_seconds_bucket{le=\"600\"} 2",
+		"janitarr_cycle_duration_seconds_bucket{le=\"+Inf\"} 2",
+		"janitarr_cycle_duration_seconds_sum 645.000000",
+		"janitarr_cycle_duration_seconds_count 2",
+		"# TYPE janitarr_detection_duration_seconds histogram",
+		"janitarr_detection_duration_seconds_bucket{server=\"Movies\",type=\"radarr\",le=\"1\"} 0",
+		"janitarr_detection_duration_seconds_bucket{server=\"Movies\",type=\"radarr\",le=\"2.5\"} 1",
+		"janitarr_detection_duration_seconds_count{server=\"Movies\",type=\"radarr\"} 1",
+		"# TYPE janitarr_backlog_items gauge",
+		"janitarr_backlog_items{server=\"Movies\",type=\"radarr\",category=\"missing\"} 110",
+		"janitarr_backlog_items{server=\"Movies\",type=\"radarr\",category=\"cutoff\"} 28",
+		"# TYPE janitarr_rate_limit_lockouts_total counter",
+		"janitarr_rate_limit_lockouts_total{server=\"TV \\\"4K\\\"\",type=\"sonarr\"} 1",
+	}
+
+	for _, s := range expected {
+		if !strings.Contains(output, s) {
+			t.Errorf("output missing expected string: %q", s)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/logger"
+)
+
+// sendTimeout bounds how long a single provider may take to accept a notification.
+const sendTimeout = 10 * time.Second
+
+// DispatcherDB defines the database operations needed by the Dispatcher.
+type DispatcherDB interface {
+	GetNotificationChannels() ([]database.NotificationChannel, error)
+}
+
+// DispatcherLogger defines the logger operations needed by the Dispatcher.
+// Delivery failures are only written to the console so they cannot trigger further notifications.
+type DispatcherLogger interface {
+	Subscribe() <-chan logger.LogEntry
+	Warn(msg string, keyvals ...interface{})
+}
+
+// Dispatcher listens to log entries and sends matching events to the configured channels.
+type Dispatcher struct {
+	db     DispatcherDB
+	logger DispatcherLogger
+	client *http.Client
+}
+
+// NewDispatcher creates a new Dispatcher.
+func NewDispatcher(db DispatcherDB, log DispatcherLogger) *Dispatcher {
+	return &Dispatcher{
+		db:     db,
+		logger: log,
+		client: &http.Client{Timeout: sendTimeout},
+	}
+}
+
+// Run forwards log entries to notification channels until the context is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	entries := d.logger.Subscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case entry, ok := <-entries:
+			if !ok {
+				return
+			}
+			if event, ok := EventFromLogEntry(entry); ok {
+				d.Notify(ctx, event)
+			}
+		}
+	}
+}
+
+// Notify sends an event to every enabled channel subscribed to it.
+func (d *Dispatcher) Notify(ctx context.Context, event Event) {
+	channels, err := d.db.GetNotificationChannels()
+	if err != nil {
+		d.logger.Warn("failed to load notification channels", "error", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, channel := range channels {
+		if !channel.Enabled || !channel.Subscribes(event.Type) {
+			continue
+		}
+		wg.Add(1)
+		go func(channel database.NotificationChannel) {
+			defer wg.Done()
+			if err := send(ctx, d.client, channel, event); err != nil {
+				d.logger.Warn("failed to send notification",
+					"channel", channel.Name,
+					"provider", channel.Provider,
+					"event", event.Type,
+					"error", err)
+			}
+		}(channel)
+	}
+	wg.Wait()
+}
+
+// SendTest sends a sample cycle summary to a channel so its settings can be checked.
+func SendTest(ctx context.Context, channel database.NotificationChannel) error {
+	event := Event{
+		Type:      database.NotificationEventCycleEnd,
+		Timestamp: time.Now(),
+		Message:   "This is a test notification from Janitarr.",
+		Manual:    true,
+		Cycle: &logger.CycleSummary{
+			Searches:         12,
+			MissingTriggered: 8,
+			CutoffTriggered:  4,
+			ServersChecked:   2,
+			Duration:         42 * time.Second,
+		},
+	}
+	return send(ctx, &http.Client{Timeout: sendTimeout}, channel, event)
+}
+
+// send renders an event with the channel's templates and delivers it.
+func send(ctx context.Context, client *http.Client, channel database.NotificationChannel, event Event) error {
+	provider, err := NewProvider(channel, client)
+	if err != nil {
+		return err
+	}
+
+	title, body := Render(channel, event)
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	if err := provider.Send(ctx, Message{Title: title, Body: body, Event: event}); err != nil {
+		return fmt.Errorf("%s: %w", channel.Provider, err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Package notifications sends alerts about automation activity to external services.
+package notifications
+
+import (
+	"strings"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/logger"
+)
+
+// Event is something that happened in Janitarr that channels can be notified about.
+// It is the data available to title and body templates.
+type Event struct {
+	Type       database.NotificationEvent `json:"event"`
+	Timestamp  time.Time                  `json:"timestamp"`
+	ServerName string                     `json:"serverName,omitempty"`
+	ServerType string                     `json:"serverType,omitempty"`
+	Category   string                     `json:"category,omitempty"`
+	Message    string                     `json:"message"`
+	Manual     bool                       `json:"manual"`
+	Cycle      *logger.CycleSummary       `json:"cycle,omitempty"` // Set for cycle_end events
+}
+
+// EventFromLogEntry converts a log entry into a notification event. It returns false for
+// entries that do not correspond to a notification event.
+func EventFromLogEntry(entry logger.LogEntry) (Event, bool) {
+	event := Event{
+		Timestamp:  entry.Timestamp,
+		ServerName: entry.ServerName,
+		ServerType: entry.ServerType,
+		Category:   entry.Category,
+		Message:    entry.Message,
+		Manual:     entry.IsManual,
+	}
+
+	switch entry.Type {
+	case logger.LogTypeCycleEnd:
+		event.Type = database.NotificationEventCycleEnd
+		event.Cycle = cycleSummaryFromMetadata(entry.Metadata)
+	case logger.LogTypeError:
+		if entry.ServerName == "" {
+			return Event{}, false
+		}
+		switch {
+		case isRateLimitLockout(entry.Metadata):
+			event.Type = database.NotificationEventRateLimit
+			event.Message = "rate limited by server"
+		case isRateLimitMessage(entry.Message):
+			// Single 429s are retried; only the lockout after repeated ones is worth a notification
+			return Event{}, false
+		default:
+			event.Type = database.NotificationEventServerError
+		}
+	default:
+		return Event{}, false
+	}
+
+	return event, true
+}
+
+// isRateLimitMessage reports whether an error message came from an HTTP 429 response.
+func isRateLimitMessage(msg string) bool {
+	return msg == "rate_limit" || strings.Contains(msg, "rate limited")
+}
+
+// isRateLimitLockout reports whether an error entry records a server being skipped after
+// repeated rate limiting.
+func isRateLimitLockout(metadata map[string]interface{}) bool {
+	lockout, _ := metadata["rate_limit_lockout"].(bool)
+	return lockout
+}
+
+// cycleSummaryFromMetadata rebuilds the cycle totals stored in a cycle end log entry.
+func cycleSummaryFromMetadata(metadata map[string]interface{}) *logger.CycleSummary {
+	summary := &logger.CycleSummary{
+		Searches:         metadataInt(metadata, "searches"),
+		MissingTriggered: metadataInt(metadata, "missing"),
+		CutoffTriggered:  metadataInt(metadata, "cutoff"),
+		Failures:         metadataInt(metadata, "failures"),
+		ServersChecked:   metadataInt(metadata, "servers"),
+		Duration:         (time.Duration(metadataInt(metadata, "duration_ms")) * time.Millisecond).Round(time.Second),
+	}
+	summary.DryRun, _ = metadata["dry_run"].(bool)
+	switch errs := metadata["errors"].(type) {
+	case []string:
+		summary.Errors = errs
+	case []interface{}:
+		for _, e := range errs {
+			if s, ok := e.(string); ok {
+				summary.Errors = append(summary.Errors, s)
+			}
+		}
+	}
+	return summary
+}
+
+// metadataInt reads a number from log metadata, which holds ints when logged and float64s
+// after a JSON round trip.
+func metadataInt(metadata map[string]interface{}, key string) int {
+	switch v := metadata[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
@@ -0,0 +1,206 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/logger"
+)
+
+func TestEventFromLogEntry(t *testing.T) {
+	tests := []struct {
+		name     string
+		entry    logger.LogEntry
+		expected database.NotificationEvent
+		ok       bool
+	}{
+		{"cycle end", logger.LogEntry{Type: logger.LogTypeCycleEnd, Metadata: map[string]interface{}{"searches": 5}}, database.NotificationEventCycleEnd, true},
+		{"server error", logger.LogEntry{Type: logger.LogTypeError, ServerName: "radarr", Message: "detection error: timeout"}, database.NotificationEventServerError, true},
+		{"rate limit lockout", logger.LogEntry{Type: logger.LogTypeError, ServerName: "radarr", Message: "rate limited on 3 consecutive searches", Metadata: map[string]interface{}{"rate_limit_lockout": true}}, database.NotificationEventRateLimit, true},
+		{"single rate limit", logger.LogEntry{Type: logger.LogTypeError, ServerName: "radarr", Message: "rate_limit"}, "", false},
+		{"search", logger.LogEntry{Type: logger.LogTypeSearch, ServerName: "radarr"}, "", false},
+		{"error without server", logger.LogEntry{Type: logger.LogTypeError, Message: "boom"}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, ok := EventFromLogEntry(tt.entry)
+			if ok != tt.ok || event.Type != tt.expected {
+				t.Errorf("expected (%q, %v), got (%q, %v)", tt.expected, tt.ok, event.Type, ok)
+			}
+		})
+	}
+
+	// Cycle totals survive a JSON round trip through the logs table
+	event, _ := EventFromLogEntry(logger.LogEntry{Type: logger.LogTypeCycleEnd, Metadata: map[string]interface{}{
+		"searches": float64(7), "failures": float64(1), "duration_ms": float64(61400), "errors": []interface{}{"boom"},
+	}})
+	if event.Cycle.Searches != 7 || event.Cycle.Failures != 1 || event.Cycle.Duration != 61*time.Second || len(event.Cycle.Errors) != 1 {
+		t.Errorf("unexpected cycle summary: %+v", event.Cycle)
+	}
+}
+
+func TestRender(t *testing.T) {
+	event := Event{
+		Type:  database.NotificationEventCycleEnd,
+		Cycle: &logger.CycleSummary{Searches: 3, MissingTriggered: 2, CutoffTriggered: 1, ServersChecked: 1, Duration: 5 * time.Second},
+	}
+
+	title, body := Render(database.NotificationChannel{}, event)
+	if title != "Janitarr: automation cycle finished" {
+		t.Errorf("unexpected default title %q", title)
+	}
+	if body != "3 searches triggered (2 missing, 1 upgrades) across 1 servers in 5s." {
+		t.Errorf("unexpected default body %q", body)
+	}
+
+	channel := database.NotificationChannel{BodyTemplate: "{{.Cycle.Searches}} searches"}
+	if _, body := Render(channel, event); body != "3 searches" {
+		t.Errorf("expected custom body, got %q", body)
+	}
+
+	// Custom templates that cannot render for an event fall back to the default
+	errorEvent := Event{Type: database.NotificationEventServerError, ServerName: "sonarr", ServerType: "sonarr", Message: "timeout"}
+	if _, body := Render(channel, errorEvent); body != "sonarr (sonarr): timeout" {
+		t.Errorf("expected default body, got %q", body)
+	}
+
+	if err := ValidateTemplate("{{.Cycle.Searches"); err == nil {
+		t.Error("expected invalid template to fail validation")
+	}
+}
+
+func TestProviders(t *testing.T) {
+	type captured struct {
+		path    string
+		headers http.Header
+		body    string
+	}
+	var got captured
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = captured{path: r.URL.Path, headers: r.Header, body: string(body)}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	msg := Message{Title: "Hello", Body: "World", Event: Event{Type: database.NotificationEventServerError}}
+
+	tests := []struct {
+		provider database.NotificationProvider
+		token    string
+		check    func(t *testing.T, c captured)
+	}{
+		{database.NotificationWebhook, "secret", func(t *testing.T, c captured) {
+			var payload map[string]any
+			_ = json.Unmarshal([]byte(c.body), &payload)
+			if payload["title"] != "Hello" || c.headers.Get("Authorization") != "Bearer secret" {
+				t.Errorf("unexpected webhook request: %s %v", c.body, c.headers)
+			}
+		}},
+		{database.NotificationNtfy, "", func(t *testing.T, c captured) {
+			if c.body != "World" || c.headers.Get("Title") != "Hello" || c.headers.Get("Priority") != "high" {
+				t.Errorf("unexpected ntfy request: %s %v", c.body, c.headers)
+			}
+		}},
+		{database.NotificationGotify, "apptoken", func(t *testing.T, c captured) {
+			if c.path != "/message" || c.headers.Get("X-Gotify-Key") != "apptoken" || !strings.Contains(c.body, `"priority":8`) {
+				t.Errorf("unexpected gotify request: %s %s %v", c.path, c.body, c.headers)
+			}
+		}},
+		{database.NotificationApprise, "mailto://me@example.com", func(t *testing.T, c captured) {
+			if !strings.Contains(c.body, `"urls":"mailto://me@example.com"`) || !strings.Contains(c.body, `"type":"warning"`) {
+				t.Errorf("unexpected apprise request: %s", c.body)
+			}
+		}},
+		{database.NotificationDiscord, "", func(t *testing.T, c captured) {
+			if !strings.Contains(c.body, `"embeds"`) || !strings.Contains(c.body, `"description":"World"`) {
+				t.Errorf("unexpected discord request: %s", c.body)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.provider), func(t *testing.T) {
+			provider, err := NewProvider(database.NotificationChannel{Provider: tt.provider, URL: srv.URL, Token: tt.token}, srv.Client())
+			if err != nil {
+				t.Fatalf("NewProvider failed: %v", err)
+			}
+			if err := provider.Send(context.Background(), msg); err != nil {
+				t.Fatalf("Send failed: %v", err)
+			}
+			tt.check(t, got)
+		})
+	}
+}
+
+func TestProviders_RejectedStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	err := SendTest(context.Background(), database.NotificationChannel{Provider: database.NotificationWebhook, URL: srv.URL})
+	if err == nil || !strings.Contains(err.Error(), "status 401") {
+		t.Errorf("expected status error, got %v", err)
+	}
+}
+
+type mockDispatcherDB struct {
+	channels []database.NotificationChannel
+}
+
+func (m *mockDispatcherDB) GetNotificationChannels() ([]database.NotificationChannel, error) {
+	return m.channels, nil
+}
+
+type mockDispatcherLogger struct {
+	entries chan logger.LogEntry
+}
+
+func (m *mockDispatcherLogger) Subscribe() <-chan logger.LogEntry       { return m.entries }
+func (m *mockDispatcherLogger) Warn(msg string, keyvals ...interface{}) {}
+
+func TestDispatcher_RoutesEventsToSubscribedChannels(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received[r.URL.Path]++
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	db := &mockDispatcherDB{channels: []database.NotificationChannel{
+		{Name: "cycles", Provider: database.NotificationWebhook, URL: srv.URL + "/cycles", Enabled: true,
+			Events: []database.NotificationEvent{database.NotificationEventCycleEnd}},
+		{Name: "errors", Provider: database.NotificationWebhook, URL: srv.URL + "/errors", Enabled: true,
+			Events: []database.NotificationEvent{database.NotificationEventServerError, database.NotificationEventRateLimit}},
+		{Name: "disabled", Provider: database.NotificationWebhook, URL: srv.URL + "/disabled", Enabled: false,
+			Events: []database.NotificationEvent{database.NotificationEventCycleEnd}},
+	}}
+	log := &mockDispatcherLogger{entries: make(chan logger.LogEntry, 10)}
+	dispatcher := NewDispatcher(db, log)
+
+	log.entries <- logger.LogEntry{Type: logger.LogTypeCycleEnd}
+	log.entries <- logger.LogEntry{Type: logger.LogTypeError, ServerName: "radarr", Message: "rate_limit"}
+	log.entries <- logger.LogEntry{Type: logger.LogTypeError, ServerName: "radarr", Message: "rate limited", Metadata: map[string]interface{}{"rate_limit_lockout": true}}
+	log.entries <- logger.LogEntry{Type: logger.LogTypeSearch, ServerName: "radarr"}
+	close(log.entries)
+
+	dispatcher.Run(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	if received["/cycles"] != 1 || received["/errors"] != 1 || received["/disabled"] != 0 {
+		t.Errorf("unexpected deliveries: %v", received)
+	}
+}
@@ -0,0 +1,192 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// Message is a rendered notification ready to send.
+type Message struct {
+	Title string
+	Body  string
+	Event Event
+}
+
+// isProblem reports whether the message is about something going wrong, which providers
+// use to raise priority or change colour.
+func (m Message) isProblem() bool {
+	return m.Event.Type != database.NotificationEventCycleEnd ||
+		(m.Event.Cycle != nil && m.Event.Cycle.Failures > 0)
+}
+
+// Provider delivers messages to one external service.
+type Provider interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewProvider returns the provider for a channel's configured service.
+func NewProvider(channel database.NotificationChannel, client *http.Client) (Provider, error) {
+	switch channel.Provider {
+	case database.NotificationWebhook:
+		return &webhookProvider{url: channel.URL, token: channel.Token, client: client}, nil
+	case database.NotificationNtfy:
+		return &ntfyProvider{url: channel.URL, token: channel.Token, client: client}, nil
+	case database.NotificationGotify:
+		return &gotifyProvider{url: strings.TrimRight(channel.URL, "/"), token: channel.Token, client: client}, nil
+	case database.NotificationApprise:
+		return &appriseProvider{url: channel.URL, urls: channel.Token, client: client}, nil
+	case database.NotificationDiscord:
+		return &discordProvider{url: channel.URL, client: client}, nil
+	default:
+		return nil, fmt.Errorf("unknown notification provider: %s", channel.Provider)
+	}
+}
+
+// webhookProvider POSTs the rendered message and raw event as JSON.
+type webhookProvider struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func (p *webhookProvider) Send(ctx context.Context, msg Message) error {
+	payload := map[string]any{
+		"title": msg.Title,
+		"body":  msg.Body,
+		"event": msg.Event,
+	}
+	headers := map[string]string{}
+	if p.token != "" {
+		headers["Authorization"] = "Bearer " + p.token
+	}
+	return postJSON(ctx, p.client, p.url, payload, headers)
+}
+
+// ntfyProvider publishes to an ntfy topic URL such as https://ntfy.sh/janitarr.
+type ntfyProvider struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func (p *ntfyProvider) Send(ctx context.Context, msg Message) error {
+	headers := map[string]string{"Title": msg.Title}
+	if msg.isProblem() {
+		headers["Priority"] = "high"
+		headers["Tags"] = "warning"
+	}
+	if p.token != "" {
+		headers["Authorization"] = "Bearer " + p.token
+	}
+	return post(ctx, p.client, p.url, "text/plain", strings.NewReader(msg.Body), headers)
+}
+
+// gotifyProvider sends to a Gotify server using an application token.
+type gotifyProvider struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func (p *gotifyProvider) Send(ctx context.Context, msg Message) error {
+	priority := 5
+	if msg.isProblem() {
+		priority = 8
+	}
+	payload := map[string]any{
+		"title":    msg.Title,
+		"message":  msg.Body,
+		"priority": priority,
+	}
+	return postJSON(ctx, p.client, p.url+"/message", payload, map[string]string{"X-Gotify-Key": p.token})
+}
+
+// appriseProvider sends to an Apprise API server. The URL is either a stateful endpoint
+// (/notify/{key}) or the stateless /notify endpoint with the Apprise URLs stored as the token.
+type appriseProvider struct {
+	url    string
+	urls   string
+	client *http.Client
+}
+
+func (p *appriseProvider) Send(ctx context.Context, msg Message) error {
+	notifyType := "info"
+	if msg.isProblem() {
+		notifyType = "warning"
+	}
+	payload := map[string]any{
+		"title": msg.Title,
+		"body":  msg.Body,
+		"type":  notifyType,
+	}
+	if p.urls != "" {
+		payload["urls"] = p.urls
+	}
+	return postJSON(ctx, p.client, p.url, payload, nil)
+}
+
+// discordProvider posts an embed to a Discord channel webhook.
+type discordProvider struct {
+	url    string
+	client *http.Client
+}
+
+const (
+	discordColorInfo    = 0x2ecc71
+	discordColorProblem = 0xe67e22
+)
+
+func (p *discordProvider) Send(ctx context.Context, msg Message) error {
+	color := discordColorInfo
+	if msg.isProblem() {
+		color = discordColorProblem
+	}
+	payload := map[string]any{
+		"username": "Janitarr",
+		"embeds": []map[string]any{{
+			"title":       msg.Title,
+			"description": msg.Body,
+			"color":       color,
+			"timestamp":   msg.Event.Timestamp.Format("2006-01-02T15:04:05Z07:00"),
+		}},
+	}
+	return postJSON(ctx, p.client, p.url, payload, nil)
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, payload any, headers map[string]string) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding notification: %w", err)
+	}
+	return post(ctx, client, url, "application/json", bytes.NewReader(body), headers)
+}
+
+func post(ctx context.Context, client *http.Client, url, contentType string, body io.Reader, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending notification: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification rejected: status %d", resp.StatusCode)
+	}
+	return nil
+}
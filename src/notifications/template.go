@@ -0,0 +1,74 @@
+package notifications
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// defaultTemplates are used when a channel has no custom title or body template.
+var defaultTemplates = map[database.NotificationEvent]struct{ title, body string }{
+	database.NotificationEventCycleEnd: {
+		title: "Janitarr: automation cycle finished",
+		body: "{{.Cycle.Searches}} searches triggered ({{.Cycle.MissingTriggered}} missing, {{.Cycle.CutoffTriggered}} upgrades) " +
+			"across {{.Cycle.ServersChecked}} servers in {{.Cycle.Duration}}." +
+			"{{if .Cycle.Failures}} {{.Cycle.Failures}} failures.{{end}}",
+	},
+	database.NotificationEventServerError: {
+		title: "Janitarr: error on {{.ServerName}}",
+		body:  "{{.ServerName}} ({{.ServerType}}): {{.Message}}",
+	},
+	database.NotificationEventRateLimit: {
+		title: "Janitarr: {{.ServerName}} is rate limiting searches",
+		body:  "{{.ServerName}} ({{.ServerType}}) returned HTTP 429 for 3 searches in a row. Further searches on this server are skipped until the next cycle.",
+	},
+}
+
+// Render builds a channel's notification title and body for an event. Custom templates that
+// fail to render (e.g. referencing .Cycle on an error event) fall back to the defaults.
+func Render(channel database.NotificationChannel, event Event) (title, body string) {
+	defaults := defaultTemplates[event.Type]
+
+	title = renderOr(channel.TitleTemplate, defaults.title, event)
+	body = renderOr(channel.BodyTemplate, defaults.body, event)
+	return title, body
+}
+
+// ValidateTemplate reports whether a custom template parses.
+func ValidateTemplate(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	if _, err := template.New("notification").Option("missingkey=error").Parse(text); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	return nil
+}
+
+func renderOr(custom, fallback string, event Event) string {
+	if strings.TrimSpace(custom) != "" {
+		if out, err := render(custom, event); err == nil {
+			return out
+		}
+	}
+	out, err := render(fallback, event)
+	if err != nil {
+		return event.Message
+	}
+	return out
+}
+
+func render(text string, event Event) (string, error) {
+	tmpl, err := template.New("notification").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, event); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
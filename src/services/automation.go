@@ -7,6 +7,9 @@ import (
 
 	"github.com/edrobertsrayne/janitarr/src/database"
 	"github.com/edrobertsrayne/janitarr/src/logger"
+	"github.com/edrobertsrayne/janitarr/src/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // AutomationDetector defines the interface for content detection.
@@ -22,7 +25,7 @@ type AutomationSearchTrigger interface {
 // AutomationLogger defines the interface for logging automation events.
 type AutomationLogger interface {
 	LogCycleStart(isManual bool) *logger.LogEntry
-	LogCycleEnd(totalSearches, failures int, isManual bool) *logger.LogEntry
+	LogCycleEnd(summary logger.CycleSummary, isManual bool) *logger.LogEntry
 	LogDetectionComplete(serverName, serverType string, missing, cutoffUnmet int) *logger.LogEntry
 	LogSearches(serverName, serverType, category string, count int, isManual bool) *logger.LogEntry
 	LogServerError(serverName, serverType, reason string) *logger.LogEntry
@@ -42,6 +45,7 @@ type Automation struct {
 	detector AutomationDetector
 	trigger  AutomationSearchTrigger
 	logger   AutomationLogger
+	metrics  MetricsRecorder
 }
 
 // NewAutomation creates a new Automation service.
@@ -53,16 +57,32 @@ func NewAutomation(db AutomationDB, detector AutomationDetector, trigger Automat
 		detector: detector,
 		trigger:  trigger,
 		logger:   appLogger,
+		metrics:  noopMetrics{},
 	}
 }
 
+// WithMetrics records cycle durations and outcomes to the given recorder.
+func (a *Automation) WithMetrics(recorder MetricsRecorder) *Automation {
+	a.metrics = recorder
+	return a
+}
+
 // RunCycle executes a full automation cycle: detect, trigger searches, and log results.
-func (a *Automation) RunCycle(ctx context.Context, isManual, dryRun bool) (*CycleResult, error) {
+func (a *Automation) RunCycle(ctx context.Context, isManual, dryRun bool) (_ *CycleResult, err error) {
 	startTime := time.Now()
+	ctx, span := tracing.Tracer().Start(ctx, "Automation.RunCycle", trace.WithAttributes(
+		attribute.Bool("janitarr.manual", isManual),
+		attribute.Bool("janitarr.dry_run", dryRun),
+	))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
 	a.logger.LogCycleStart(isManual)
 
 	cycleResult := &CycleResult{
 		Success: true,
+		DryRun:  dryRun,
 		Errors:  []string{},
 	}
 
@@ -80,7 +100,10 @@ func (a *Automation) RunCycle(ctx context.Context, isManual, dryRun bool) (*Cycl
 
 	// Log detection completion and errors for each server
 	for _, res := range detectionResults.Results {
-		if res.Error != "" {
+		if res.Skipped != "" {
+			// Skipped servers already failed repeatedly; don't log the same error every cycle
+			a.logger.Warn("skipping server", "server", res.ServerName, "type", res.ServerType, "reason", res.Skipped)
+		} else if res.Error != "" {
 			if !dryRun { // Added condition for dryRun
 				a.logger.LogServerError(res.ServerName, res.ServerType, fmt.Sprintf("detection error: %s", res.Error))
 			}
@@ -109,7 +132,7 @@ func (a *Automation) RunCycle(ctx context.Context, isManual, dryRun bool) (*Cycl
 		for _, result := range triggerResults.Results {
 			if result.Success {
 				if len(result.ItemIDs) > 0 {
-					a.logger.LogSearches(result.ServerName, result.ServerType, result.Category, len(result.ItemIDs), isManual)
+					a.logger.LogSearches(result.ServerName, result.ServerType, result.Category, result.SearchCount(), isManual)
 				}
 			} else {
 				a.logger.LogSearchError(result.ServerName, result.ServerType, result.Category, result.Error)
@@ -119,7 +142,14 @@ func (a *Automation) RunCycle(ctx context.Context, isManual, dryRun bool) (*Cycl
 	}
 
 	cycleResult.Duration = time.Since(startTime)
-	a.logger.LogCycleEnd(cycleResult.TotalSearches, cycleResult.TotalFailures, isManual)
+	a.logger.LogCycleEnd(cycleResult.Summary(), isManual)
+	if !dryRun {
+		a.metrics.ObserveCycle(cycleResult.Duration, len(cycleResult.Errors) > 0)
+	}
+	span.SetAttributes(
+		attribute.Int("janitarr.searches", cycleResult.TotalSearches),
+		attribute.Int("janitarr.failures", cycleResult.TotalFailures),
+	)
 
 	// Warn if cycle duration exceeds 5 minutes target
 	if cycleResult.Duration > 5*time.Minute {
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
 )
 
 // FormatCycleResult generates a human-readable summary of an automation cycle result.
@@ -28,6 +30,14 @@ func FormatCycleResult(result *CycleResult) string {
 			}
 		}
 	}
+	if result.DetectionResults.SkippedCount > 0 {
+		sb.WriteString("  Skipped Servers:\n")
+		for _, dr := range result.DetectionResults.Results {
+			if dr.Skipped != "" {
+				sb.WriteString(fmt.Sprintf("    - Server %s (%s): %s\n", dr.ServerName, dr.ServerType, dr.Skipped))
+			}
+		}
+	}
 	sb.WriteString("\n")
 
 	// Search Trigger Summary
@@ -37,6 +47,30 @@ func FormatCycleResult(result *CycleResult) string {
 	sb.WriteString(fmt.Sprintf("  Cutoff Items Triggered: %d\n", result.SearchResults.CutoffTriggered))
 	sb.WriteString(fmt.Sprintf("  Successful Triggers: %d\n", result.SearchResults.SuccessCount))
 	sb.WriteString(fmt.Sprintf("  Failed Triggers: %d\n", result.SearchResults.FailureCount))
+	if result.SearchResults.RequestedTriggered > 0 {
+		sb.WriteString(fmt.Sprintf("  Requested Items Searched: %d\n", result.SearchResults.RequestedTriggered))
+	}
+	if result.SearchResults.CooldownSkipped > 0 {
+		sb.WriteString(fmt.Sprintf("  Skipped (Recently Searched): %d\n", result.SearchResults.CooldownSkipped))
+	}
+	if result.SearchResults.QueueSkipped > 0 {
+		sb.WriteString(fmt.Sprintf("  Skipped (Already Downloading): %d\n", result.SearchResults.QueueSkipped))
+	}
+	if result.SearchResults.GiveUpSkipped > 0 {
+		sb.WriteString(fmt.Sprintf("  Skipped (Given Up): %d\n", result.SearchResults.GiveUpSkipped))
+	}
+	if len(result.SearchResults.Deduplicated) > 0 {
+		sb.WriteString(fmt.Sprintf("  Skipped (Searched on Paired Server): %d\n", len(result.SearchResults.Deduplicated)))
+	}
+	for _, name := range result.SearchResults.QueueFullServers {
+		sb.WriteString(fmt.Sprintf("  Skipped Server %s: download queue full\n", name))
+	}
+	if len(result.SearchResults.Budgets) > 0 {
+		sb.WriteString("  Search Limits Used:\n")
+		for _, budget := range result.SearchResults.Budgets {
+			sb.WriteString(fmt.Sprintf("    - %s: %d of %d\n", budget.Limit, budget.Used, budget.Available))
+		}
+	}
 	if result.SearchResults.FailureCount > 0 {
 		sb.WriteString("  Trigger Errors:\n")
 		for _, tr := range result.SearchResults.Results {
@@ -47,6 +81,32 @@ func FormatCycleResult(result *CycleResult) string {
 	}
 	sb.WriteString("\n")
 
+	// In dry-run mode, show exactly which items would be searched and which strategy chose them
+	if result.DryRun && len(result.SearchResults.Results) > 0 {
+		sb.WriteString("Planned Searches (Dry Run):\n")
+		for _, tr := range result.SearchResults.Results {
+			sb.WriteString(fmt.Sprintf("  %s (%s, %s) - picked by %s", tr.ServerName, tr.ServerType, tr.Category, tr.Strategy))
+			switch tr.Command {
+			case api.CommandSeasonSearch:
+				sb.WriteString(fmt.Sprintf(", one %s for %s season %d", tr.Command, tr.SeriesTitle, tr.SeasonNumber))
+			case api.CommandSeriesSearch:
+				sb.WriteString(fmt.Sprintf(", one %s for %s", tr.Command, tr.SeriesTitle))
+			}
+			sb.WriteString(":\n")
+			for _, id := range tr.ItemIDs {
+				sb.WriteString(fmt.Sprintf("    - %s\n", plannedItemTitle(&result.DetectionResults, tr, id)))
+			}
+		}
+		sb.WriteString("\n")
+	}
+	if result.DryRun && len(result.SearchResults.Deduplicated) > 0 {
+		sb.WriteString("Left to Paired Servers (Dry Run):\n")
+		for _, item := range result.SearchResults.Deduplicated {
+			sb.WriteString(fmt.Sprintf("  - %s on %s (%s): searched on %s (%s)\n", item.Title, item.ServerName, item.Category, item.PairedWith, item.Policy))
+		}
+		sb.WriteString("\n")
+	}
+
 	// Overall Status
 	if result.Success {
 		sb.WriteString("Overall Status: SUCCESS\n")
@@ -61,6 +121,33 @@ func FormatCycleResult(result *CycleResult) string {
 	return sb.String()
 }
 
+// plannedItemTitle returns a display title for a planned search item, falling back to its ID.
+func plannedItemTitle(detection *DetectionResults, tr TriggerResult, id int) string {
+	for _, dr := range detection.Results {
+		if dr.ServerID != tr.ServerID {
+			continue
+		}
+		items := dr.MissingItems
+		if tr.Category == "cutoff" {
+			items = dr.CutoffItems
+		}
+		if item, ok := items[id]; ok {
+			title := item.Title
+			switch item.Type {
+			case "album":
+				title = fmt.Sprintf("%s - %s", item.ArtistName, item.Title)
+			case "book":
+				title = fmt.Sprintf("%s - %s", item.AuthorName, item.Title)
+			}
+			if item.Type != "episode" && item.Year > 0 {
+				return fmt.Sprintf("%s (%d)", title, item.Year)
+			}
+			return title
+		}
+	}
+	return fmt.Sprintf("Item #%d", id)
+}
+
 // formatDuration formats a time.Duration into a human-readable string.
 func formatDuration(d time.Duration) string {
 	if d < time.Second {
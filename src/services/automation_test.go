@@ -42,8 +42,8 @@ func (m *MockLogger) LogCycleStart(isManual bool) *logger.LogEntry {
 	return args.Get(0).(*logger.LogEntry)
 }
 
-func (m *MockLogger) LogCycleEnd(totalSearches, failures int, isManual bool) *logger.LogEntry {
-	args := m.Called(totalSearches, failures, isManual)
+func (m *MockLogger) LogCycleEnd(summary logger.CycleSummary, isManual bool) *logger.LogEntry {
+	args := m.Called(summary.Searches, summary.Failures, isManual)
 	return args.Get(0).(*logger.LogEntry)
 }
 
@@ -188,7 +188,7 @@ func TestRunCycle_Success(t *testing.T) {
 		SuccessCount: 1,
 		FailureCount: 0,
 	}
-	mockDetector.On("DetectAll", ctx).Return(detectionResults, nil).Once()
+	mockDetector.On("DetectAll", mock.Anything).Return(detectionResults, nil).Once()
 
 	// Mock SearchTrigger calls
 	triggerResults := &TriggerResults{
@@ -215,7 +215,7 @@ func TestRunCycle_Success(t *testing.T) {
 		SuccessCount:     2,
 		FailureCount:     0,
 	}
-	mockSearchTrigger.On("TriggerSearches", ctx, detectionResults, appConfig.SearchLimits, false).Return(triggerResults, nil).Once()
+	mockSearchTrigger.On("TriggerSearches", mock.Anything, detectionResults, appConfig.SearchLimits, false).Return(triggerResults, nil).Once()
 
 	automation := NewAutomation(mockDB, mockDetector, mockSearchTrigger, mockLogger)
 	result, err := automation.RunCycle(ctx, true, false)
@@ -273,11 +273,11 @@ func TestRunCycle_DetectionFailure(t *testing.T) {
 		SuccessCount: 0,
 		FailureCount: 1,
 	}
-	mockDetector.On("DetectAll", ctx).Return(detectionResults, detectionErr).Once()
+	mockDetector.On("DetectAll", mock.Anything).Return(detectionResults, detectionErr).Once()
 
 	// SearchTrigger should still be called, but with the (empty) detection results
 	triggerResults := &TriggerResults{Results: []TriggerResult{}} // Empty as no successful detections
-	mockSearchTrigger.On("TriggerSearches", ctx, detectionResults, appConfig.SearchLimits, false).Return(triggerResults, nil).Once()
+	mockSearchTrigger.On("TriggerSearches", mock.Anything, detectionResults, appConfig.SearchLimits, false).Return(triggerResults, nil).Once()
 
 	automation := NewAutomation(mockDB, mockDetector, mockSearchTrigger, mockLogger)
 	result, err := automation.RunCycle(ctx, false, false)
@@ -334,7 +334,7 @@ func TestRunCycle_TriggerFailure(t *testing.T) {
 		SuccessCount: 1,
 		FailureCount: 0,
 	}
-	mockDetector.On("DetectAll", ctx).Return(detectionResults, nil).Once()
+	mockDetector.On("DetectAll", mock.Anything).Return(detectionResults, nil).Once()
 
 	// Mock SearchTrigger call to return an error, and also a partial result with a server error
 	triggerErr := errors.New("failed to trigger")
@@ -355,7 +355,7 @@ func TestRunCycle_TriggerFailure(t *testing.T) {
 		SuccessCount:     0,
 		FailureCount:     1,
 	}
-	mockSearchTrigger.On("TriggerSearches", ctx, detectionResults, appConfig.SearchLimits, false).Return(triggerResults, triggerErr).Once()
+	mockSearchTrigger.On("TriggerSearches", mock.Anything, detectionResults, appConfig.SearchLimits, false).Return(triggerResults, triggerErr).Once()
 
 	automation := NewAutomation(mockDB, mockDetector, mockSearchTrigger, mockLogger)
 	result, err := automation.RunCycle(ctx, true, false)
@@ -410,7 +410,7 @@ func TestRunCycle_DryRun(t *testing.T) {
 		SuccessCount: 1,
 		FailureCount: 0,
 	}
-	mockDetector.On("DetectAll", ctx).Return(detectionResults, nil).Once()
+	mockDetector.On("DetectAll", mock.Anything).Return(detectionResults, nil).Once()
 
 	// Mock SearchTrigger calls - dryRun should be true
 	triggerResults := &TriggerResults{
@@ -437,7 +437,7 @@ func TestRunCycle_DryRun(t *testing.T) {
 		SuccessCount:     2,
 		FailureCount:     0,
 	}
-	mockSearchTrigger.On("TriggerSearches", ctx, detectionResults, appConfig.SearchLimits, true).Return(triggerResults, nil).Once()
+	mockSearchTrigger.On("TriggerSearches", mock.Anything, detectionResults, appConfig.SearchLimits, true).Return(triggerResults, nil).Once()
 
 	automation := NewAutomation(mockDB, mockDetector, mockSearchTrigger, mockLogger)
 	result, err := automation.RunCycle(ctx, true, true) // Dry-run is true
@@ -498,7 +498,7 @@ func TestRunCycle_ManualScheduledLogging(t *testing.T) {
 				TotalMissing: 2,
 				SuccessCount: 1,
 			}
-			mockDetector.On("DetectAll", ctx).Return(detectionResults, nil).Once()
+			mockDetector.On("DetectAll", mock.Anything).Return(detectionResults, nil).Once()
 
 			// Mock SearchTrigger calls
 			triggerResults := &TriggerResults{
@@ -515,7 +515,7 @@ func TestRunCycle_ManualScheduledLogging(t *testing.T) {
 				MissingTriggered: 2,
 				SuccessCount:     1,
 			}
-			mockSearchTrigger.On("TriggerSearches", ctx, detectionResults, appConfig.SearchLimits, false).Return(triggerResults, nil).Once()
+			mockSearchTrigger.On("TriggerSearches", mock.Anything, detectionResults, appConfig.SearchLimits, false).Return(triggerResults, nil).Once()
 
 			automation := NewAutomation(mockDB, mockDetector, mockSearchTrigger, mockLogger)
 			_, err := automation.RunCycle(ctx, tt.isManual, false) // isManual based on test case
@@ -549,11 +549,11 @@ func TestRunCycle_EmptyResults(t *testing.T) {
 
 	// Mock Detector call - returns empty results
 	detectionResults := &DetectionResults{Results: []DetectionResult{}}
-	mockDetector.On("DetectAll", ctx).Return(detectionResults, nil).Once()
+	mockDetector.On("DetectAll", mock.Anything).Return(detectionResults, nil).Once()
 
 	// Mock SearchTrigger call - expects empty detection results
 	triggerResults := &TriggerResults{Results: []TriggerResult{}}
-	mockSearchTrigger.On("TriggerSearches", ctx, detectionResults, appConfig.SearchLimits, false).Return(triggerResults, nil).Once()
+	mockSearchTrigger.On("TriggerSearches", mock.Anything, detectionResults, appConfig.SearchLimits, false).Return(triggerResults, nil).Once()
 
 	automation := NewAutomation(mockDB, mockDetector, mockSearchTrigger, mockLogger)
 	result, err := automation.RunCycle(ctx, false, false)
@@ -618,7 +618,7 @@ func TestRunCycle_LongDurationWarning(t *testing.T) {
 		TotalMissing: 1,
 		SuccessCount: 1,
 	}
-	mockDetector.On("DetectAll", ctx).Return(detectionResults, nil).Run(func(args mock.Arguments) {
+	mockDetector.On("DetectAll", mock.Anything).Return(detectionResults, nil).Run(func(args mock.Arguments) {
 		// Sleep for slightly over 5 minutes to trigger warning
 		time.Sleep(5*time.Minute + 100*time.Millisecond)
 	}).Once()
@@ -638,7 +638,7 @@ func TestRunCycle_LongDurationWarning(t *testing.T) {
 		MissingTriggered: 1,
 		SuccessCount:     1,
 	}
-	mockSearchTrigger.On("TriggerSearches", ctx, detectionResults, appConfig.SearchLimits, false).Return(triggerResults, nil).Once()
+	mockSearchTrigger.On("TriggerSearches", mock.Anything, detectionResults, appConfig.SearchLimits, false).Return(triggerResults, nil).Once()
 
 	automation := NewAutomation(mockDB, mockDetector, mockSearchTrigger, mockLogger)
 	result, err := automation.RunCycle(ctx, false, false)
@@ -687,7 +687,7 @@ func TestRunCycle_ShortDurationNoWarning(t *testing.T) {
 		TotalMissing: 1,
 		SuccessCount: 1,
 	}
-	mockDetector.On("DetectAll", ctx).Return(detectionResults, nil).Once()
+	mockDetector.On("DetectAll", mock.Anything).Return(detectionResults, nil).Once()
 
 	// Mock SearchTrigger calls
 	triggerResults := &TriggerResults{
@@ -704,7 +704,7 @@ func TestRunCycle_ShortDurationNoWarning(t *testing.T) {
 		MissingTriggered: 1,
 		SuccessCount:     1,
 	}
-	mockSearchTrigger.On("TriggerSearches", ctx, detectionResults, appConfig.SearchLimits, false).Return(triggerResults, nil).Once()
+	mockSearchTrigger.On("TriggerSearches", mock.Anything, detectionResults, appConfig.SearchLimits, false).Return(triggerResults, nil).Once()
 
 	automation := NewAutomation(mockDB, mockDetector, mockSearchTrigger, mockLogger)
 	result, err := automation.RunCycle(ctx, false, false)
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+const (
+	// circuitFailureThreshold is the number of consecutive failures that opens a server's circuit.
+	circuitFailureThreshold = 3
+
+	// circuitBaseCooldown is how long a server is skipped once its circuit opens.
+	// Each further failure doubles the cooldown, up to circuitMaxCooldown.
+	circuitBaseCooldown = time.Hour
+	circuitMaxCooldown  = 24 * time.Hour
+)
+
+// ConnectionProber tests whether a server can be reached.
+type ConnectionProber interface {
+	TestConnection(ctx context.Context) (*api.SystemStatus, error)
+}
+
+// CircuitBreaker tracks the health of each server across automation cycles.
+// Servers that keep failing are skipped for an increasing cooldown instead of failing every
+// cycle, and are probed with TestConnection once the cooldown has passed.
+// Health is stored in the database so it survives restarts and is shared by every service.
+type CircuitBreaker struct {
+	db  *database.DB
+	now func() time.Time
+	mu  sync.Mutex // Serialises read-modify-write updates of a server's health
+}
+
+// NewCircuitBreaker creates a CircuitBreaker backed by the given database.
+func NewCircuitBreaker(db *database.DB) *CircuitBreaker {
+	return &CircuitBreaker{db: db, now: time.Now}
+}
+
+// Allow reports whether a server should be contacted. Servers with an open circuit are skipped
+// until their cooldown has passed and then probed; the returned reason explains a skip.
+// If the server's health cannot be read it is always contacted.
+func (b *CircuitBreaker) Allow(ctx context.Context, serverID string, prober ConnectionProber) (bool, string) {
+	if b == nil || b.db == nil {
+		return true, ""
+	}
+
+	health, err := b.db.GetServerHealth(serverID)
+	if err != nil || health.State != database.ServerCircuitOpen {
+		return true, ""
+	}
+
+	if health.OpenUntil != nil && b.now().Before(*health.OpenUntil) {
+		return false, fmt.Sprintf("skipped after %d consecutive failures until %s (last error: %s)",
+			health.ConsecutiveFailures, health.OpenUntil.Local().Format("2006-01-02 15:04"), health.LastError)
+	}
+
+	// The cooldown has passed: make one cheap request before resuming detection
+	if _, err := prober.TestConnection(ctx); err != nil {
+		reason := fmt.Sprintf("connection probe failed: %v", err)
+		health = b.RecordFailure(serverID, reason)
+		if health.OpenUntil != nil {
+			reason += fmt.Sprintf("; skipped until %s", health.OpenUntil.Local().Format("2006-01-02 15:04"))
+		}
+		return false, reason
+	}
+	return true, ""
+}
+
+// IsOpen reports whether a server's circuit is open. Unlike Allow it never probes the server,
+// so background pollers can skip failing servers and leave recovery to the automation cycle.
+func (b *CircuitBreaker) IsOpen(serverID string) bool {
+	if b == nil || b.db == nil {
+		return false
+	}
+
+	health, err := b.db.GetServerHealth(serverID)
+	return err == nil && health.State == database.ServerCircuitOpen
+}
+
+// RecordSuccess marks a server as healthy, closing its circuit.
+func (b *CircuitBreaker) RecordSuccess(serverID string) {
+	if b == nil || b.db == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	health, err := b.db.GetServerHealth(serverID)
+	if err != nil {
+		return
+	}
+
+	now := b.now()
+	health.State = database.ServerHealthy
+	health.ConsecutiveFailures = 0
+	health.LastError = ""
+	health.LastSuccessAt = &now
+	health.OpenUntil = nil
+	_ = b.db.SetServerHealth(serverID, health)
+}
+
+// RecordFailure counts a failed contact with a server and returns its new health.
+// The circuit opens once the failure threshold is reached.
+func (b *CircuitBreaker) RecordFailure(serverID, reason string) database.ServerHealth {
+	if b == nil || b.db == nil {
+		return database.ServerHealth{State: database.ServerHealthy}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	health, err := b.db.GetServerHealth(serverID)
+	if err != nil {
+		return health
+	}
+
+	now := b.now()
+	health.ConsecutiveFailures++
+	health.LastError = reason
+	health.LastFailureAt = &now
+	health.State = database.ServerDegraded
+	health.OpenUntil = nil
+	if health.ConsecutiveFailures >= circuitFailureThreshold {
+		openUntil := now.Add(circuitCooldown(health.ConsecutiveFailures))
+		health.State = database.ServerCircuitOpen
+		health.OpenUntil = &openUntil
+	}
+	_ = b.db.SetServerHealth(serverID, health)
+	return health
+}
+
+// circuitCooldown returns how long to skip a server after the given number of consecutive failures.
+func circuitCooldown(failures int) time.Duration {
+	cooldown := circuitBaseCooldown
+	for i := circuitFailureThreshold; i < failures && cooldown < circuitMaxCooldown; i++ {
+		cooldown *= 2
+	}
+	return min(cooldown, circuitMaxCooldown)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// probeClient is a ConnectionProber that fails while err is set.
+type probeClient struct {
+	err    error
+	probes int
+}
+
+func (p *probeClient) TestConnection(ctx context.Context) (*api.SystemStatus, error) {
+	p.probes++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &api.SystemStatus{AppName: "Sonarr"}, nil
+}
+
+func TestCircuitCooldown(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{3, time.Hour},
+		{4, 2 * time.Hour},
+		{5, 4 * time.Hour},
+		{7, 16 * time.Hour},
+		{8, 24 * time.Hour},
+		{50, 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := circuitCooldown(tt.failures); got != tt.want {
+			t.Errorf("circuitCooldown(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	db := testDetectorDB(t)
+	server, err := db.AddServer("sonarr", "http://localhost:8989", "key", database.ServerTypeSonarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(db)
+	breaker.now = func() time.Time { return now }
+	prober := &probeClient{}
+	ctx := context.Background()
+
+	// Failures below the threshold degrade the server but it is still contacted
+	for i := 1; i < circuitFailureThreshold; i++ {
+		health := breaker.RecordFailure(server.ID, "connection refused")
+		if health.State != database.ServerDegraded || health.ConsecutiveFailures != i {
+			t.Fatalf("after %d failures expected degraded, got %+v", i, health)
+		}
+		if ok, _ := breaker.Allow(ctx, server.ID, prober); !ok {
+			t.Fatalf("expected degraded server to be allowed")
+		}
+	}
+
+	health := breaker.RecordFailure(server.ID, "connection refused")
+	if health.State != database.ServerCircuitOpen || health.OpenUntil == nil || !health.OpenUntil.Equal(now.Add(time.Hour)) {
+		t.Fatalf("expected circuit to open for an hour, got %+v", health)
+	}
+
+	// Skipped without probing during the cooldown
+	ok, reason := breaker.Allow(ctx, server.ID, prober)
+	if ok || !strings.Contains(reason, "connection refused") {
+		t.Errorf("expected skip mentioning the last error, got %v %q", ok, reason)
+	}
+	if prober.probes != 0 {
+		t.Errorf("expected no probe during the cooldown, got %d", prober.probes)
+	}
+
+	// A failed probe after the cooldown reopens the circuit for longer
+	now = now.Add(time.Hour)
+	prober.err = errors.New("connection refused")
+	if ok, _ := breaker.Allow(ctx, server.ID, prober); ok {
+		t.Error("expected failed probe to skip the server")
+	}
+	health, _ = db.GetServerHealth(server.ID)
+	if health.ConsecutiveFailures != 4 || !health.OpenUntil.Equal(now.Add(2*time.Hour)) {
+		t.Errorf("expected 4 failures and a 2h cooldown, got %+v", health)
+	}
+
+	// A successful probe lets the server be used, and success closes the circuit
+	now = now.Add(2 * time.Hour)
+	prober.err = nil
+	if ok, _ := breaker.Allow(ctx, server.ID, prober); !ok {
+		t.Error("expected successful probe to allow the server")
+	}
+	breaker.RecordSuccess(server.ID)
+	health, _ = db.GetServerHealth(server.ID)
+	if health.State != database.ServerHealthy || health.ConsecutiveFailures != 0 || health.OpenUntil != nil || health.LastSuccessAt == nil {
+		t.Errorf("expected healthy server after success, got %+v", health)
+	}
+}
+
+func TestDetector_SkipsServerWithOpenCircuit(t *testing.T) {
+	db := testDetectorDB(t)
+	ctx := context.Background()
+
+	if _, err := db.AddServer("radarr", "http://localhost:7878", "key", database.ServerTypeRadarr); err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	client := &mockDetectorClient{missingErr: errors.New("connection refused")}
+	detector := NewDetectorWithFactory(db, func(url, apiKey, serverType string) DetectorAPIClient {
+		return client
+	})
+
+	for i := 0; i < circuitFailureThreshold; i++ {
+		results, err := detector.DetectAll(ctx)
+		if err != nil {
+			t.Fatalf("DetectAll failed: %v", err)
+		}
+		if results.FailureCount != 1 {
+			t.Fatalf("cycle %d: expected a failed detection, got %+v", i+1, results)
+		}
+	}
+
+	// The circuit is now open, so the server is skipped rather than failing again
+	results, err := detector.DetectAll(ctx)
+	if err != nil {
+		t.Fatalf("DetectAll failed: %v", err)
+	}
+	if results.SkippedCount != 1 || results.FailureCount != 0 || results.Results[0].Skipped == "" {
+		t.Errorf("expected the server to be skipped, got %+v", results)
+	}
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMacros maps the supported shorthand expressions to their five-field equivalents.
+var cronMacros = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// CronExpression is a parsed five-field cron expression (minute hour day-of-month month day-of-week).
+type CronExpression struct {
+	expr       string
+	minutes    uint64
+	hours      uint64
+	daysOfMon  uint64
+	months     uint64
+	daysOfWeek uint64
+	domAny     bool // Day-of-month field was "*"
+	dowAny     bool // Day-of-week field was "*"
+}
+
+// ParseCron parses a standard five-field cron expression. Each field accepts "*", single
+// values, ranges ("1-5"), lists ("2,14") and steps ("*/4", "0-30/10"). Day-of-week uses
+// 0-6 with Sunday as 0 (7 is also accepted for Sunday).
+func ParseCron(expr string) (*CronExpression, error) {
+	expr = strings.TrimSpace(expr)
+	spec := expr
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	c := &CronExpression{
+		expr:   expr,
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+
+	bounds := []struct {
+		name     string
+		min, max int
+		target   *uint64
+	}{
+		{"minute", 0, 59, &c.minutes},
+		{"hour", 0, 23, &c.hours},
+		{"day of month", 1, 31, &c.daysOfMon},
+		{"month", 1, 12, &c.months},
+		{"day of week", 0, 7, &c.daysOfWeek},
+	}
+
+	for i, b := range bounds {
+		bits, err := parseCronField(fields[i], b.min, b.max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %s: %w", expr, b.name, err)
+		}
+		*b.target = bits
+	}
+
+	// Fold 7 (Sunday) into 0
+	if c.daysOfWeek&(1<<7) != 0 {
+		c.daysOfWeek = c.daysOfWeek&^(1<<7) | 1
+	}
+
+	return c, nil
+}
+
+// parseCronField converts one comma-separated cron field into a bitset of allowed values.
+func parseCronField(field string, minVal, maxVal int) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangePart = part[:i]
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s < 1 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			step = s
+		}
+
+		lo, hi := minVal, maxVal
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid value %q", bounds[0])
+			}
+			if hi, err = strconv.Atoi(bounds[1]); err != nil {
+				return 0, fmt.Errorf("invalid value %q", bounds[1])
+			}
+		default:
+			v, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", rangePart)
+			}
+			lo, hi = v, v
+			if step > 1 {
+				hi = maxVal // "5/15" means every 15 starting at 5
+			}
+		}
+
+		if lo < minVal || hi > maxVal || lo > hi {
+			return 0, fmt.Errorf("%q out of range %d-%d", part, minVal, maxVal)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// String returns the expression as it was written.
+func (c *CronExpression) String() string {
+	return c.expr
+}
+
+// Next returns the first matching time strictly after the given time, in its location.
+// It returns the zero time if nothing matches within five years (e.g. "0 0 31 2 *").
+func (c *CronExpression) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if c.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if c.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches applies cron's day rules: when both day fields are restricted, either may match.
+func (c *CronExpression) dayMatches(t time.Time) bool {
+	domMatch := c.daysOfMon&(1<<uint(t.Day())) != 0
+	dowMatch := c.daysOfWeek&(1<<uint(t.Weekday())) != 0
+
+	if !c.domAny && !c.dowAny {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) expected error", expr)
+		}
+	}
+}
+
+func TestCronExpression_Next(t *testing.T) {
+	// Wednesday 10 January 2024, 09:30
+	base := time.Date(2024, time.January, 10, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"0 2,14 * * *", time.Date(2024, time.January, 10, 14, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.January, 10, 9, 45, 0, 0, time.UTC)},
+		{"30 9 * * *", time.Date(2024, time.January, 11, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2024, time.January, 14, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, time.January, 14, 0, 0, 0, 0, time.UTC)},
+		{"0 3 1 * *", time.Date(2024, time.February, 1, 3, 0, 0, 0, time.UTC)},
+		{"0 3 29 2 *", time.Date(2024, time.February, 29, 3, 0, 0, 0, time.UTC)},
+		{"0 6 15 * 1", time.Date(2024, time.January, 15, 6, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron failed: %v", err)
+			}
+			if got := cron.Next(base); !got.Equal(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCronExpression_NextNeverMatches(t *testing.T) {
+	cron, err := ParseCron("0 0 31 2 *")
+	if err != nil {
+		t.Fatalf("ParseCron failed: %v", err)
+	}
+	if got := cron.Next(time.Now()); !got.IsZero() {
+		t.Errorf("expected zero time for impossible date, got %v", got)
+	}
+}
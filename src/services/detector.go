@@ -2,11 +2,15 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/edrobertsrayne/janitarr/src/api"
 	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // DetectorAPIClient is the interface for API clients used by the Detector.
@@ -14,24 +18,70 @@ type DetectorAPIClient interface {
 	TestConnection(ctx context.Context) (*api.SystemStatus, error)
 	GetAllMissing(ctx context.Context) ([]api.MediaItem, error)
 	GetAllCutoffUnmet(ctx context.Context) ([]api.MediaItem, error)
-	TriggerSearch(ctx context.Context, ids []int) error
+	TriggerSearch(ctx context.Context, ids []int) (*api.CommandResponse, error)
 }
 
+// Ensure every supported API client can be used for detection.
+var (
+	_ DetectorAPIClient = (*api.RadarrClient)(nil)
+	_ DetectorAPIClient = (*api.SonarrClient)(nil)
+	_ DetectorAPIClient = (*api.LidarrClient)(nil)
+	_ DetectorAPIClient = (*api.ReadarrClient)(nil)
+)
+
 // DetectorAPIClientFactory creates API clients for detection.
 type DetectorAPIClientFactory func(url, apiKey, serverType string) DetectorAPIClient
 
 // defaultDetectorAPIClientFactory creates real API clients.
 func defaultDetectorAPIClientFactory(url, apiKey, serverType string) DetectorAPIClient {
-	if serverType == "sonarr" {
+	switch serverType {
+	case "sonarr":
 		return api.NewSonarrClient(url, apiKey)
+	case "lidarr":
+		return api.NewLidarrClient(url, apiKey)
+	case "readarr":
+		return api.NewReadarrClient(url, apiKey)
+	default:
+		return api.NewRadarrClient(url, apiKey)
+	}
+}
+
+// apiClientLogger is implemented by the real API clients, which log requests and retries at debug level.
+type apiClientLogger interface {
+	WithLogger(logger api.DebugLogger, serverName string) *api.Client
+}
+
+// attachAPILogger passes a debug logger to the API client if it supports one.
+func attachAPILogger(client any, logger DebugLogger, serverName string) {
+	if logger == nil {
+		return
+	}
+	if c, ok := client.(apiClientLogger); ok {
+		c.WithLogger(logger, serverName)
+	}
+}
+
+// apiClientPaginator is implemented by the real API clients, which fetch wanted lists a page at a time.
+type apiClientPaginator interface {
+	WithPagination(pageSize, concurrency int) *api.Client
+}
+
+// applyPagination passes a server's pagination overrides to the API client if it supports them.
+func applyPagination(client any, pagination database.ServerPagination) {
+	if c, ok := client.(apiClientPaginator); ok {
+		c.WithPagination(pagination.PageSize, pagination.Concurrency)
 	}
-	return api.NewRadarrClient(url, apiKey)
 }
 
 // Detector detects missing content and content below quality cutoff across all servers.
 type Detector struct {
-	db         *database.DB
-	apiFactory DetectorAPIClientFactory
+	db          *database.DB
+	apiFactory  DetectorAPIClientFactory
+	metrics     MetricsRecorder
+	logger      DebugLogger
+	breaker     *CircuitBreaker
+	now         func() time.Time
+	fullRefresh bool // Read every wanted list in full instead of refreshing the cache
 }
 
 // NewDetector creates a new Detector with the given database.
@@ -39,6 +89,9 @@ func NewDetector(db *database.DB) *Detector {
 	return &Detector{
 		db:         db,
 		apiFactory: defaultDetectorAPIClientFactory,
+		metrics:    noopMetrics{},
+		breaker:    NewCircuitBreaker(db),
+		now:        time.Now,
 	}
 }
 
@@ -48,9 +101,30 @@ func NewDetectorWithFactory(db *database.DB, factory DetectorAPIClientFactory) *
 	return &Detector{
 		db:         db,
 		apiFactory: factory,
+		metrics:    noopMetrics{},
+		breaker:    NewCircuitBreaker(db),
+		now:        time.Now,
 	}
 }
 
+// WithMetrics records detection durations and backlog sizes to the given recorder.
+func (d *Detector) WithMetrics(recorder MetricsRecorder) *Detector {
+	d.metrics = recorder
+	return d
+}
+
+// WithLogger logs API requests and retries made during detection at debug level.
+func (d *Detector) WithLogger(logger DebugLogger) *Detector {
+	d.logger = logger
+	return d
+}
+
+// WithFullRefresh reads every wanted list in full, ignoring the cache kept by earlier detections.
+func (d *Detector) WithFullRefresh() *Detector {
+	d.fullRefresh = true
+	return d
+}
+
 // DetectAll runs detection on all enabled servers concurrently.
 func (d *Detector) DetectAll(ctx context.Context) (*DetectionResults, error) {
 	servers, err := d.db.GetAllServers()
@@ -102,7 +176,9 @@ func (d *Detector) DetectAll(ctx context.Context) (*DetectionResults, error) {
 	for result := range resultCh {
 		results.Results = append(results.Results, result)
 
-		if result.Error != "" {
+		if result.Skipped != "" {
+			results.SkippedCount++
+		} else if result.Error != "" {
 			results.FailureCount++
 		} else {
 			results.SuccessCount++
@@ -116,7 +192,63 @@ func (d *Detector) DetectAll(ctx context.Context) (*DetectionResults, error) {
 
 // detectServer runs detection on a single server.
 func (d *Detector) detectServer(ctx context.Context, server *database.Server) DetectionResult {
-	result := DetectionResult{
+	start := time.Now()
+	ctx, span := tracing.Tracer().Start(ctx, "Detector.detectServer", tracing.ServerAttributes(server.Name, string(server.Type)))
+
+	var result DetectionResult
+	defer func() {
+		if result.Skipped != "" {
+			span.SetAttributes(attribute.String("janitarr.skipped", result.Skipped))
+			span.End()
+			return
+		}
+
+		d.metrics.ObserveDetection(server.Name, string(server.Type), time.Since(start))
+		if result.Error != "" {
+			tracing.RecordError(span, errors.New(result.Error))
+			d.breaker.RecordFailure(server.ID, result.Error)
+		} else {
+			d.breaker.RecordSuccess(server.ID)
+		}
+		span.SetAttributes(
+			attribute.Int("janitarr.missing", len(result.Missing)),
+			attribute.Int("janitarr.cutoff", len(result.Cutoff)),
+			attribute.String("janitarr.refresh", result.Refresh),
+			attribute.Int("janitarr.filtered", result.Filtered),
+			attribute.Int("janitarr.excluded", result.Excluded),
+			attribute.Int("janitarr.unavailable", result.Unavailable),
+			attribute.Int("janitarr.recently_released", result.RecentlyReleased),
+		)
+		span.End()
+	}()
+
+	result = newDetectionResult(server)
+
+	client := d.apiFactory(server.URL, server.APIKey, string(server.Type))
+	attachAPILogger(client, d.logger, server.Name)
+	applyPagination(client, server.Pagination)
+
+	// Servers that keep failing are skipped until their cooldown has passed
+	if ok, reason := d.breaker.Allow(ctx, server.ID, client); !ok {
+		result.Skipped = reason
+		return result
+	}
+
+	missingItems, cutoffItems, refresh, err := d.readWanted(ctx, server, client)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Refresh = refresh
+	result.addFiltered(missingItems, cutoffItems, server.Filters, d.activeExclusions(server.ID), d.db.GetAppConfig().Detection, d.now())
+
+	d.metrics.SetBacklog(server.Name, string(server.Type), len(result.Missing), len(result.Cutoff))
+	return result
+}
+
+// newDetectionResult creates an empty detection result for a server.
+func newDetectionResult(server *database.Server) DetectionResult {
+	return DetectionResult{
 		ServerID:     server.ID,
 		ServerName:   server.Name,
 		ServerType:   string(server.Type),
@@ -125,34 +257,37 @@ func (d *Detector) detectServer(ctx context.Context, server *database.Server) De
 		MissingItems: make(map[int]api.MediaItem),
 		CutoffItems:  make(map[int]api.MediaItem),
 	}
+}
 
-	client := d.apiFactory(server.URL, server.APIKey, string(server.Type))
-
-	// Get missing items
-	missingItems, err := client.GetAllMissing(ctx)
-	if err != nil {
-		result.Error = fmt.Sprintf("missing detection failed: %v", err)
-		return result
+// addItems adds missing and cutoff unmet items to the result, keeping their order.
+func (r *DetectionResult) addItems(missing, cutoff []api.MediaItem) {
+	for _, item := range missing {
+		r.Missing = append(r.Missing, item.ID)
+		r.MissingItems[item.ID] = item
 	}
-
-	for _, item := range missingItems {
-		result.Missing = append(result.Missing, item.ID)
-		result.MissingItems[item.ID] = item
+	for _, item := range cutoff {
+		r.Cutoff = append(r.Cutoff, item.ID)
+		r.CutoffItems[item.ID] = item
 	}
+}
 
-	// Get cutoff unmet items
-	cutoffItems, err := client.GetAllCutoffUnmet(ctx)
-	if err != nil {
-		result.Error = fmt.Sprintf("cutoff detection failed: %v", err)
-		return result
-	}
+// addFiltered adds the missing and cutoff unmet items a server's exclusions, filters and the
+// detection release options allow to the result, counting those left out.
+func (r *DetectionResult) addFiltered(missing, cutoff []api.MediaItem, filters database.ServerFilters, exclusions []database.Exclusion, options database.DetectionConfig, now time.Time) {
+	missing, excludedMissing := withoutExcluded(missing, exclusions)
+	cutoff, excludedCutoff := withoutExcluded(cutoff, exclusions)
+	r.Excluded = excludedMissing + excludedCutoff
 
-	for _, item := range cutoffItems {
-		result.Cutoff = append(result.Cutoff, item.ID)
-		result.CutoffItems[item.ID] = item
-	}
+	missing, filteredMissing := filterWanted(missing, filters, now)
+	cutoff, filteredCutoff := filterWanted(cutoff, filters, now)
+	r.Filtered = filteredMissing + filteredCutoff
 
-	return result
+	missing, unreleasedMissing := excludeUnreleased(missing, options, now)
+	cutoff, unreleasedCutoff := excludeUnreleased(cutoff, options, now)
+	r.Unavailable = unreleasedMissing.unavailable + unreleasedCutoff.unavailable
+	r.RecentlyReleased = unreleasedMissing.recent + unreleasedCutoff.recent
+
+	r.addItems(missing, cutoff)
 }
 
 // DetectServer runs detection on a single server by ID.
@@ -219,7 +354,9 @@ func (d *Detector) DetectByType(ctx context.Context, serverType database.ServerT
 	for result := range resultCh {
 		results.Results = append(results.Results, result)
 
-		if result.Error != "" {
+		if result.Skipped != "" {
+			results.SkippedCount++
+		} else if result.Error != "" {
 			results.FailureCount++
 		} else {
 			results.SuccessCount++
@@ -35,8 +35,8 @@ func (m *mockDetectorClient) GetAllCutoffUnmet(ctx context.Context) ([]api.Media
 	return m.cutoff, nil
 }
 
-func (m *mockDetectorClient) TriggerSearch(ctx context.Context, ids []int) error {
-	return nil
+func (m *mockDetectorClient) TriggerSearch(ctx context.Context, ids []int) (*api.CommandResponse, error) {
+	return &api.CommandResponse{}, nil
 }
 
 // testDetectorDB creates an in-memory test database.
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// ErrExclusionValidation is returned for exclusions that can't be added as given.
+var ErrExclusionValidation = errors.New("invalid exclusion")
+
+// ExclusionInfo is an exclusion with its server's name, for display.
+type ExclusionInfo struct {
+	database.Exclusion
+	ServerName string `json:"serverName"`
+	ServerType string `json:"serverType"`
+}
+
+// ExclusionManager manages the items that must never be searched automatically.
+type ExclusionManager struct {
+	db  *database.DB
+	now func() time.Time
+}
+
+// NewExclusionManager creates a new ExclusionManager with the given database.
+func NewExclusionManager(db *database.DB) *ExclusionManager {
+	return &ExclusionManager{db: db, now: time.Now}
+}
+
+// Add excludes an item, series, TMDB or TVDB ID on a server, given by ID or name, until expiresAt
+// or forever if it is nil. Excluding something that is already excluded replaces its details.
+func (m *ExclusionManager) Add(server, kind string, targetID int, title, reason string, expiresAt *time.Time) (*ExclusionInfo, error) {
+	if !database.IsValidExclusionKind(kind) {
+		return nil, fmt.Errorf("%w: kind %q must be one of %s", ErrExclusionValidation, kind, exclusionKindNames())
+	}
+	if targetID <= 0 {
+		return nil, fmt.Errorf("%w: ID must be a positive number", ErrExclusionValidation)
+	}
+	if expiresAt != nil && !expiresAt.After(m.now()) {
+		return nil, fmt.Errorf("%w: expiry must be in the future", ErrExclusionValidation)
+	}
+
+	srv, err := m.server(server)
+	if err != nil {
+		return nil, err
+	}
+	switch database.ExclusionKind(kind) {
+	case database.ExcludeSeries, database.ExcludeTVDB:
+		if srv.Type != database.ServerTypeSonarr {
+			return nil, fmt.Errorf("%w: %s exclusions need a Sonarr server, but %s is a %s server", ErrExclusionValidation, kind, srv.Name, srv.Type)
+		}
+	case database.ExcludeTMDB:
+		if srv.Type != database.ServerTypeRadarr {
+			return nil, fmt.Errorf("%w: tmdb exclusions need a Radarr server, but %s is a %s server", ErrExclusionValidation, srv.Name, srv.Type)
+		}
+	}
+
+	exclusion, err := m.db.AddExclusion(database.Exclusion{
+		ServerID:  srv.ID,
+		Kind:      database.ExclusionKind(kind),
+		TargetID:  targetID,
+		Title:     strings.TrimSpace(title),
+		Reason:    strings.TrimSpace(reason),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ExclusionInfo{Exclusion: *exclusion, ServerName: srv.Name, ServerType: string(srv.Type)}, nil
+}
+
+// List returns all exclusions that have not expired, most recent first. Expired exclusions are deleted.
+func (m *ExclusionManager) List() ([]ExclusionInfo, error) {
+	if _, err := m.db.DeleteExpiredExclusions(m.now()); err != nil {
+		return nil, err
+	}
+	exclusions, err := m.db.GetExclusions()
+	if err != nil {
+		return nil, err
+	}
+	servers, err := m.db.GetAllServers()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]database.Server, len(servers))
+	for _, server := range servers {
+		byID[server.ID] = server
+	}
+
+	infos := make([]ExclusionInfo, 0, len(exclusions))
+	for _, exclusion := range exclusions {
+		server := byID[exclusion.ServerID]
+		infos = append(infos, ExclusionInfo{Exclusion: exclusion, ServerName: server.Name, ServerType: string(server.Type)})
+	}
+	return infos, nil
+}
+
+// Remove deletes an exclusion by ID, so the items it matched are searched again.
+func (m *ExclusionManager) Remove(id string) error {
+	removed, err := m.db.DeleteExclusion(id)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("exclusion '%s' not found", id)
+	}
+	return nil
+}
+
+// server looks up a server by ID or name.
+func (m *ExclusionManager) server(idOrName string) (*database.Server, error) {
+	server, err := m.db.GetServer(idOrName)
+	if err == nil && server == nil {
+		server, err = m.db.GetServerByName(idOrName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if server == nil {
+		return nil, fmt.Errorf("server '%s' not found", idOrName)
+	}
+	return server, nil
+}
+
+// exclusionKindNames returns the supported exclusion kinds as a comma-separated string.
+func exclusionKindNames() string {
+	names := make([]string, len(database.ExclusionKinds))
+	for i, kind := range database.ExclusionKinds {
+		names[i] = string(kind)
+	}
+	return strings.Join(names, ", ")
+}
+
+// withoutExcluded returns the items no exclusion matches, keeping their order, and how many were left out.
+func withoutExcluded(items []api.MediaItem, exclusions []database.Exclusion) ([]api.MediaItem, int) {
+	if len(exclusions) == 0 {
+		return items, 0
+	}
+
+	kept := make([]api.MediaItem, 0, len(items))
+	for _, item := range items {
+		excluded := false
+		for _, exclusion := range exclusions {
+			if excludes(exclusion, item) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			kept = append(kept, item)
+		}
+	}
+	return kept, len(items) - len(kept)
+}
+
+// excludes reports whether an exclusion matches an item.
+func excludes(exclusion database.Exclusion, item api.MediaItem) bool {
+	switch exclusion.Kind {
+	case database.ExcludeItem:
+		return item.ID == exclusion.TargetID
+	case database.ExcludeSeries:
+		return item.SeriesID == exclusion.TargetID
+	case database.ExcludeTMDB:
+		return item.TmdbID == exclusion.TargetID
+	case database.ExcludeTVDB:
+		return item.SeriesTvdbID == exclusion.TargetID
+	}
+	return false
+}
+
+// activeExclusions returns a server's exclusions that have not expired. If they can't be read,
+// detection carries on without them.
+func (d *Detector) activeExclusions(serverID string) []database.Exclusion {
+	exclusions, err := d.db.GetActiveExclusions(serverID, d.now())
+	if err != nil && d.logger != nil {
+		d.logger.Debug("Failed to read exclusions", "server", serverID, "error", err)
+	}
+	return exclusions
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+func TestExclusionManager_Add(t *testing.T) {
+	db := testDetectorDB(t)
+	if _, err := db.AddServer("radarr", "http://localhost:7878", "key", database.ServerTypeRadarr); err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	manager := NewExclusionManager(db)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		kind      string
+		targetID  int
+		expiresAt *time.Time
+	}{
+		{"unknown kind", "imdb", 1, nil},
+		{"invalid ID", "item", 0, nil},
+		{"expiry in the past", "item", 1, &past},
+		{"series on Radarr", "series", 1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := manager.Add("radarr", tt.kind, tt.targetID, "", "", tt.expiresAt); !errors.Is(err, ErrExclusionValidation) {
+				t.Errorf("expected a validation error, got %v", err)
+			}
+		})
+	}
+
+	exclusion, err := manager.Add("radarr", "tmdb", 603, "The Matrix", "waiting for a remux", nil)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if exclusion.ServerName != "radarr" || exclusion.Kind != database.ExcludeTMDB || exclusion.Title != "The Matrix" {
+		t.Errorf("unexpected exclusion: %+v", exclusion)
+	}
+	if err := manager.Remove(exclusion.ID); err != nil {
+		t.Errorf("Remove failed: %v", err)
+	}
+	if err := manager.Remove(exclusion.ID); err == nil {
+		t.Error("expected removing a missing exclusion to fail")
+	}
+}
+
+func TestDetector_AppliesExclusions(t *testing.T) {
+	db := testDetectorDB(t)
+	ctx := context.Background()
+
+	server, err := db.AddServer("sonarr", "http://localhost:8989", "key", database.ServerTypeSonarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	manager := NewExclusionManager(db)
+	if _, err := manager.Add("sonarr", "series", 10, "Show", "", nil); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := manager.Add("sonarr", "tvdb", 2000, "Other", "", nil); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	soon := time.Now().Add(time.Hour)
+	if _, err := manager.Add("sonarr", "item", 5, "Pilot", "", &soon); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	client := &changeDetectorClient{mockDetectorClient: mockDetectorClient{
+		missing: []api.MediaItem{
+			{ID: 1, Type: "episode", SeriesID: 10},
+			{ID: 2, Type: "episode", SeriesID: 20, SeriesTvdbID: 2000},
+			{ID: 3, Type: "episode", SeriesID: 30},
+			{ID: 5, Type: "episode", SeriesID: 30},
+		},
+		cutoff: []api.MediaItem{{ID: 4, Type: "episode", SeriesID: 10}},
+	}}
+	detector := NewDetectorWithFactory(db, func(url, apiKey, serverType string) DetectorAPIClient {
+		return client
+	})
+
+	result, err := detector.DetectServer(ctx, server.ID)
+	if err != nil || result.Error != "" {
+		t.Fatalf("detection failed: %v %s", err, result.Error)
+	}
+	if !slices.Equal(result.Missing, []int{3}) || len(result.Cutoff) != 0 || result.Excluded != 4 {
+		t.Errorf("expected only episode 3 with 4 excluded, got missing %v, cutoff %v, excluded %d", result.Missing, result.Cutoff, result.Excluded)
+	}
+
+	// Once an exclusion expires its items are searched again
+	detector.now = func() time.Time { return soon.Add(time.Minute) }
+	results, _, err := detector.CachedAll()
+	if err != nil {
+		t.Fatalf("CachedAll failed: %v", err)
+	}
+	if !slices.Equal(results.Results[0].Missing, []int{3, 5}) {
+		t.Errorf("expected episode 5 after its exclusion expired, got %v", results.Results[0].Missing)
+	}
+}
+
+func TestTriggerSearches_SeasonPacksSkipExcludedEpisodes(t *testing.T) {
+	db := testTriggerDB(t)
+	ctx := context.Background()
+
+	server, err := db.AddServer("sonarr", "http://localhost:8989", "key", database.ServerTypeSonarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	config := db.GetAppConfig()
+	config.Search.SeasonPackPercent = 50
+	if err := db.SetAppConfig(config); err != nil {
+		t.Fatalf("setting config: %v", err)
+	}
+	if _, err := NewExclusionManager(db).Add("sonarr", "item", 102, "Bad Episode", "", nil); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	detectorClient := &mockDetectorClient{}
+	for _, id := range []int{101, 102, 103} {
+		detectorClient.missing = append(detectorClient.missing, api.MediaItem{ID: id, Type: "episode", SeriesID: 10, SeasonNumber: 1})
+	}
+	detector := NewDetectorWithFactory(db, func(url, apiKey, serverType string) DetectorAPIClient {
+		return detectorClient
+	})
+	result, err := detector.DetectServer(ctx, server.ID)
+	if err != nil || result.Error != "" {
+		t.Fatalf("detection failed: %v %s", err, result.Error)
+	}
+
+	triggerClient := &mockSeasonSearchClient{
+		mockTriggerAPIClient: mockTriggerAPIClient{serverType: "sonarr"},
+		series:               []api.Series{{ID: 10, Seasons: []api.Season{season(1, 4, 3)}}},
+	}
+	trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+		return triggerClient
+	}, &mockSearchTriggerLogger{})
+
+	// Episodes 101 and 103 are half of season 1, but a season search would also cover the excluded 102
+	detection := &DetectionResults{Results: []DetectionResult{*result}, TotalMissing: len(result.Missing), SuccessCount: 1}
+	if _, err := trigger.TriggerSearches(ctx, detection, database.SearchLimits{MissingEpisodesLimit: 3}, false); err != nil {
+		t.Fatalf("TriggerSearches failed: %v", err)
+	}
+
+	if len(triggerClient.seasonSearches) != 0 {
+		t.Errorf("expected no season search, got %v", triggerClient.seasonSearches)
+	}
+	if calls := triggerClient.getTriggerCalls(); len(calls) != 1 || !slices.Equal(slices.Sorted(slices.Values(calls[0])), []int{101, 103}) {
+		t.Errorf("expected episodes 101 and 103 to be searched individually, got %v", calls)
+	}
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// filterWanted returns the items a server's filters allow, keeping their order, and how many were left out.
+func filterWanted(items []api.MediaItem, filters database.ServerFilters, now time.Time) ([]api.MediaItem, int) {
+	if filters.IsEmpty() {
+		return items, 0
+	}
+
+	kept := make([]api.MediaItem, 0, len(items))
+	for _, item := range items {
+		if allowedByFilters(item, filters, now) {
+			kept = append(kept, item)
+		}
+	}
+	return kept, len(items) - len(kept)
+}
+
+// allowedByFilters reports whether an item passes every one of a server's filters.
+func allowedByFilters(item api.MediaItem, f database.ServerFilters, now time.Time) bool {
+	if f.MonitoredOnly && !item.Monitored {
+		return false
+	}
+
+	if !matchesRule(f.IncludeQualityProfiles, f.ExcludeQualityProfiles, func(name string) bool {
+		return strings.EqualFold(item.QualityProfile, name)
+	}) {
+		return false
+	}
+	if !matchesRule(f.IncludeTags, f.ExcludeTags, func(name string) bool {
+		return slices.ContainsFunc(item.Tags, func(tag string) bool { return strings.EqualFold(tag, name) })
+	}) {
+		return false
+	}
+	if !matchesRule(f.IncludeRootFolders, f.ExcludeRootFolders, func(folder string) bool {
+		return inFolder(item.Path, folder)
+	}) {
+		return false
+	}
+
+	if item.Year != 0 && ((f.MinYear != 0 && item.Year < f.MinYear) || (f.MaxYear != 0 && item.Year > f.MaxYear)) {
+		return false
+	}
+	if item.Status != "" && !matchesRule(f.IncludeStatuses, f.ExcludeStatuses, func(status string) bool {
+		return strings.EqualFold(item.Status, status)
+	}) {
+		return false
+	}
+
+	if item.Type == "episode" {
+		aired := item.ReleaseDate
+		if f.SkipUnaired && (aired.IsZero() || aired.After(now)) {
+			return false
+		}
+		if f.RecentlyAiredHours > 0 && !aired.IsZero() && aired.After(now.Add(-time.Duration(f.RecentlyAiredHours)*time.Hour)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// releaseCounts is how many items were left out by the detection release options.
+type releaseCounts struct {
+	unavailable int
+	recent      int
+}
+
+// excludeUnreleased returns the items that are released and available, keeping their order, along
+// with how many were left out. Movies use Radarr's availability; other items are unavailable until
+// their release or air date. If a grace period is set, items released within it are left out too.
+func excludeUnreleased(items []api.MediaItem, options database.DetectionConfig, now time.Time) ([]api.MediaItem, releaseCounts) {
+	var counts releaseCounts
+	if !options.SkipUnavailable && options.ReleaseGraceHours <= 0 {
+		return items, counts
+	}
+
+	graceStart := now.Add(-time.Duration(options.ReleaseGraceHours) * time.Hour)
+	kept := make([]api.MediaItem, 0, len(items))
+	for _, item := range items {
+		released := !item.ReleaseDate.IsZero() && !item.ReleaseDate.After(now)
+		switch {
+		case options.SkipUnavailable && (item.Unavailable || (item.Type != "movie" && !item.ReleaseDate.IsZero() && !released)):
+			counts.unavailable++
+		case options.ReleaseGraceHours > 0 && released && item.ReleaseDate.After(graceStart):
+			counts.recent++
+		default:
+			kept = append(kept, item)
+		}
+	}
+	return kept, counts
+}
+
+// matchesRule reports whether an include/exclude rule allows an item: it must match one of the
+// included values, if there are any, and none of the excluded ones.
+func matchesRule(include, exclude []string, matches func(string) bool) bool {
+	if len(include) > 0 && !slices.ContainsFunc(include, matches) {
+		return false
+	}
+	return !slices.ContainsFunc(exclude, matches)
+}
+
+// inFolder reports whether a path is the folder or inside it. Windows separators are accepted.
+func inFolder(itemPath, folder string) bool {
+	if itemPath == "" || folder == "" {
+		return false
+	}
+	clean := func(p string) string { return strings.ToLower(path.Clean(strings.ReplaceAll(p, `\`, "/"))) }
+	itemPath, folder = clean(itemPath), clean(folder)
+	return itemPath == folder || strings.HasPrefix(itemPath, strings.TrimSuffix(folder, "/")+"/")
+}
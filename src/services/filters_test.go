@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+func TestAllowedByFilters(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	movie := api.MediaItem{
+		ID: 1, Type: "movie", Year: 2010, Monitored: true,
+		QualityProfile: "HD-1080p", Tags: []string{"4k", "Kids"}, Path: "/movies/Up (2009)",
+	}
+	episode := api.MediaItem{
+		ID: 2, Type: "episode", Year: 2008, Monitored: true, Status: "ended",
+		Path: `D:\TV\Breaking Bad`, ReleaseDate: now.Add(-3 * time.Hour),
+	}
+
+	tests := []struct {
+		name    string
+		item    api.MediaItem
+		filters database.ServerFilters
+		want    bool
+	}{
+		{"no filters", movie, database.ServerFilters{}, true},
+		{"monitored only keeps monitored", movie, database.ServerFilters{MonitoredOnly: true}, true},
+		{"monitored only drops unmonitored", api.MediaItem{Type: "movie"}, database.ServerFilters{MonitoredOnly: true}, false},
+		{"included profile ignores case", movie, database.ServerFilters{IncludeQualityProfiles: []string{"hd-1080p"}}, true},
+		{"profile not included", movie, database.ServerFilters{IncludeQualityProfiles: []string{"Ultra-HD"}}, false},
+		{"excluded profile", movie, database.ServerFilters{ExcludeQualityProfiles: []string{"HD-1080p"}}, false},
+		{"one included tag is enough", movie, database.ServerFilters{IncludeTags: []string{"anime", "4k"}}, true},
+		{"untagged item not included", episode, database.ServerFilters{IncludeTags: []string{"4k"}}, false},
+		{"excluded tag ignores case", movie, database.ServerFilters{ExcludeTags: []string{"kids"}}, false},
+		{"included root folder", movie, database.ServerFilters{IncludeRootFolders: []string{"/movies/"}}, true},
+		{"root folder must be a whole folder", movie, database.ServerFilters{IncludeRootFolders: []string{"/mov"}}, false},
+		{"excluded windows root folder", episode, database.ServerFilters{ExcludeRootFolders: []string{`d:\tv`}}, false},
+		{"before minimum year", movie, database.ServerFilters{MinYear: 2011}, false},
+		{"after maximum year", movie, database.ServerFilters{MaxYear: 2009}, false},
+		{"within year range", movie, database.ServerFilters{MinYear: 2010, MaxYear: 2010}, true},
+		{"unknown year not filtered", api.MediaItem{Type: "movie"}, database.ServerFilters{MinYear: 2000}, true},
+		{"status not included", episode, database.ServerFilters{IncludeStatuses: []string{"continuing"}}, false},
+		{"excluded status", episode, database.ServerFilters{ExcludeStatuses: []string{"Ended"}}, false},
+		{"status rules ignore movies", movie, database.ServerFilters{IncludeStatuses: []string{"continuing"}}, true},
+		{"aired episode", episode, database.ServerFilters{SkipUnaired: true}, true},
+		{"unaired episode", api.MediaItem{Type: "episode", ReleaseDate: now.Add(time.Hour)}, database.ServerFilters{SkipUnaired: true}, false},
+		{"episode without air date", api.MediaItem{Type: "episode"}, database.ServerFilters{SkipUnaired: true}, false},
+		{"recently aired episode", episode, database.ServerFilters{RecentlyAiredHours: 6}, false},
+		{"aired long enough ago", episode, database.ServerFilters{RecentlyAiredHours: 2}, true},
+		{"air date rules ignore movies", api.MediaItem{Type: "movie", ReleaseDate: now}, database.ServerFilters{SkipUnaired: true, RecentlyAiredHours: 6}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := allowedByFilters(tt.item, tt.filters, now); got != tt.want {
+				t.Errorf("allowedByFilters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetector_AppliesServerFilters(t *testing.T) {
+	db := testDetectorDB(t)
+	ctx := context.Background()
+
+	server, err := db.AddServer("radarr", "http://localhost:7878", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	filters := database.ServerFilters{ExcludeTags: []string{"kids"}}
+	if err := db.UpdateServer(server.ID, &database.ServerUpdate{Filters: &filters}); err != nil {
+		t.Fatalf("setting filters: %v", err)
+	}
+
+	client := &changeDetectorClient{mockDetectorClient: mockDetectorClient{
+		missing: []api.MediaItem{{ID: 1, Type: "movie"}, {ID: 2, Type: "movie", Tags: []string{"kids"}}},
+		cutoff:  []api.MediaItem{{ID: 3, Type: "movie", Tags: []string{"kids"}}},
+	}}
+	detector := NewDetectorWithFactory(db, func(url, apiKey, serverType string) DetectorAPIClient {
+		return client
+	})
+
+	result, err := detector.DetectServer(ctx, server.ID)
+	if err != nil || result.Error != "" {
+		t.Fatalf("detection failed: %v %s", err, result.Error)
+	}
+	if len(result.Missing) != 1 || result.Missing[0] != 1 || len(result.Cutoff) != 0 || result.Filtered != 2 {
+		t.Errorf("expected only movie 1 with 2 filtered, got missing %v, cutoff %v, filtered %d", result.Missing, result.Cutoff, result.Filtered)
+	}
+
+	// The cache keeps every item, so changed filters apply without re-reading the lists
+	filters = database.ServerFilters{}
+	if err := db.UpdateServer(server.ID, &database.ServerUpdate{Filters: &filters}); err != nil {
+		t.Fatalf("clearing filters: %v", err)
+	}
+	results, _, err := detector.CachedAll()
+	if err != nil {
+		t.Fatalf("CachedAll failed: %v", err)
+	}
+	if results.TotalMissing != 2 || results.TotalCutoff != 1 || results.Results[0].Filtered != 0 {
+		t.Errorf("expected the unfiltered backlog, got %+v", results.Results[0])
+	}
+}
+
+func TestExcludeUnreleased(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	items := []api.MediaItem{
+		{ID: 1, Type: "movie", ReleaseDate: now.AddDate(-1, 0, 0)},
+		{ID: 2, Type: "movie", Unavailable: true, ReleaseDate: now.AddDate(0, 3, 0)},
+		{ID: 3, Type: "movie", ReleaseDate: now.Add(-time.Hour)},
+		{ID: 4, Type: "episode", ReleaseDate: now.Add(-30 * time.Minute)},
+		{ID: 5, Type: "episode", ReleaseDate: now.Add(time.Hour)},
+		{ID: 6, Type: "episode", ReleaseDate: now.AddDate(0, 0, -2)},
+		{ID: 7, Type: "album"},
+	}
+
+	tests := []struct {
+		name            string
+		options         database.DetectionConfig
+		want            []int
+		wantUnavailable int
+		wantRecent      int
+	}{
+		{"disabled", database.DetectionConfig{}, []int{1, 2, 3, 4, 5, 6, 7}, 0, 0},
+		{"skip unavailable", database.DetectionConfig{SkipUnavailable: true}, []int{1, 3, 4, 6, 7}, 2, 0},
+		{"grace period", database.DetectionConfig{ReleaseGraceHours: 6}, []int{1, 2, 5, 6, 7}, 0, 2},
+		{"both", database.DetectionConfig{SkipUnavailable: true, ReleaseGraceHours: 24}, []int{1, 6, 7}, 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, counts := excludeUnreleased(items, tt.options, now)
+			var ids []int
+			for _, item := range kept {
+				ids = append(ids, item.ID)
+			}
+			if !slices.Equal(ids, tt.want) || counts.unavailable != tt.wantUnavailable || counts.recent != tt.wantRecent {
+				t.Errorf("got %v with %d unavailable and %d recent, want %v with %d and %d",
+					ids, counts.unavailable, counts.recent, tt.want, tt.wantUnavailable, tt.wantRecent)
+			}
+		})
+	}
+}
+
+func TestDetector_ExcludesUnreleasedItems(t *testing.T) {
+	db := testDetectorDB(t)
+	ctx := context.Background()
+
+	server, err := db.AddServer("radarr", "http://localhost:7878", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	client := &mockDetectorClient{
+		missing: []api.MediaItem{{ID: 1, Type: "movie"}, {ID: 2, Type: "movie", Unavailable: true}},
+		cutoff:  []api.MediaItem{{ID: 3, Type: "movie", ReleaseDate: now.Add(-time.Hour)}},
+	}
+	detector := NewDetectorWithFactory(db, func(url, apiKey, serverType string) DetectorAPIClient {
+		return client
+	})
+	detector.now = func() time.Time { return now }
+
+	// Unavailable items are skipped by default
+	result, err := detector.DetectServer(ctx, server.ID)
+	if err != nil || result.Error != "" {
+		t.Fatalf("detection failed: %v %s", err, result.Error)
+	}
+	if len(result.Missing) != 1 || len(result.Cutoff) != 1 || result.Unavailable != 1 || result.RecentlyReleased != 0 {
+		t.Errorf("expected movie 2 to be left out as unavailable, got %+v", result)
+	}
+
+	config := db.GetAppConfig()
+	config.Detection.ReleaseGraceHours = 12
+	if err := db.SetAppConfig(config); err != nil {
+		t.Fatalf("setting config: %v", err)
+	}
+	result, err = detector.DetectServer(ctx, server.ID)
+	if err != nil || result.Error != "" {
+		t.Fatalf("detection failed: %v %s", err, result.Error)
+	}
+	if len(result.Cutoff) != 0 || result.RecentlyReleased != 1 {
+		t.Errorf("expected movie 3 to be left out as recently released, got %+v", result)
+	}
+}
@@ -0,0 +1,341 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// giveUpClient is implemented by API clients that can unmonitor and tag items, like Radarr and Sonarr.
+type giveUpClient interface {
+	SetMonitored(ctx context.Context, itemIDs []int, monitored bool) error
+	SetTag(ctx context.Context, mediaIDs []int, label string, add bool) error
+}
+
+// givenUpKey identifies an item given up on.
+type givenUpKey struct {
+	serverID string
+	category database.SearchCategory
+	itemID   int
+}
+
+// applyGiveUp returns a copy of the detection results without the items Janitarr has given up on
+// for now, along with the number of items skipped. An item is given up on once it has been searched
+// GiveUpAttempts times without a release being grabbed. What happens then depends on the policy:
+//   - backoff: each further search waits twice as long as the last, up to GiveUpDays
+//   - snooze: the item is searched once every GiveUpDays
+//   - tag: the item, or its series, is tagged on its server and no longer searched
+//   - unmonitor: the item is unmonitored on its server and no longer searched
+//
+// Items given up on are recorded so they can be listed and reset. In a dry run nothing is recorded
+// or changed on the servers. If a server's search attempts can't be read, its items are left unfiltered.
+func (s *SearchTrigger) applyGiveUp(ctx context.Context, detectionResults *DetectionResults, serverMap map[string]*database.Server, config database.SearchConfig, dryRun bool) (*DetectionResults, int) {
+	if config.GiveUpAttempts <= 0 {
+		return detectionResults, 0
+	}
+
+	recorded := make(map[givenUpKey]database.GivenUpItem)
+	if items, err := s.db.GetGivenUpItems(); err == nil {
+		for _, item := range items {
+			recorded[givenUpKey{item.ServerID, item.Category, item.ItemID}] = item
+		}
+	}
+
+	now := time.Now()
+	filtered := *detectionResults
+	filtered.Results = make([]DetectionResult, len(detectionResults.Results))
+	skipped := 0
+
+	for i, result := range detectionResults.Results {
+		filtered.Results[i] = result
+		server, ok := serverMap[result.ServerID]
+		if result.Error != "" || !ok {
+			continue
+		}
+
+		var actions []database.GivenUpItem
+		for _, category := range []database.SearchCategory{database.SearchCategoryMissing, database.SearchCategoryCutoff} {
+			ids, items := filtered.Results[i].wanted(category)
+			if len(*ids) == 0 {
+				continue
+			}
+			attempts, err := s.db.GetSearchAttempts(result.ServerID, category, config.GiveUpAttempts)
+			if err != nil || len(attempts) == 0 {
+				continue
+			}
+
+			kept := make([]int, 0, len(*ids))
+			for _, id := range *ids {
+				searched, ok := attempts[id]
+				if !ok {
+					kept = append(kept, id)
+					continue
+				}
+
+				item := database.GivenUpItem{
+					ServerID:  result.ServerID,
+					Category:  category,
+					ItemID:    id,
+					Title:     items[id].Title,
+					Policy:    config.GiveUpPolicy,
+					Attempts:  searched.Attempts,
+					GivenUpAt: now,
+				}
+				switch config.GiveUpPolicy {
+				case database.GiveUpTag, database.GiveUpUnmonitor:
+					skipped++
+					if previous, ok := recorded[givenUpKey{result.ServerID, category, id}]; !ok || previous.Policy != config.GiveUpPolicy {
+						item.MediaID = tagTarget(items[id])
+						actions = append(actions, item)
+					}
+				default:
+					retryAt := searched.LastSearchedAt.Add(giveUpWait(config, searched.Attempts)).Truncate(time.Second)
+					item.RetryAt = &retryAt
+					if previous, ok := recorded[givenUpKey{result.ServerID, category, id}]; !dryRun && (!ok || !sameWait(previous, item)) {
+						s.recordGivenUp(item)
+					}
+					if now.Before(retryAt) {
+						skipped++
+						continue
+					}
+					kept = append(kept, id)
+				}
+			}
+			*ids = kept
+		}
+
+		if len(actions) > 0 && !dryRun {
+			s.giveUpOnServer(ctx, server, config, actions)
+		}
+	}
+
+	return &filtered, skipped
+}
+
+// giveUpOnServer tags or unmonitors items given up on and records them. Items are recorded without
+// changes on servers that can't tag or unmonitor items. If the server can't be updated they are not
+// recorded, so the next cycle tries again.
+func (s *SearchTrigger) giveUpOnServer(ctx context.Context, server *database.Server, config database.SearchConfig, items []database.GivenUpItem) {
+	client := s.apiFactory(server.URL, server.APIKey, string(server.Type))
+	debugLogger, hasDebug := s.logger.(DebugLogger)
+	if hasDebug {
+		attachAPILogger(client, debugLogger, server.Name)
+	}
+
+	if editor, ok := client.(giveUpClient); ok {
+		var err error
+		if config.GiveUpPolicy == database.GiveUpTag {
+			var mediaIDs []int
+			for _, item := range items {
+				if !slices.Contains(mediaIDs, item.MediaID) {
+					mediaIDs = append(mediaIDs, item.MediaID)
+				}
+			}
+			err = editor.SetTag(ctx, mediaIDs, config.GiveUpTagLabel, true)
+		} else {
+			itemIDs := make([]int, len(items))
+			for i, item := range items {
+				itemIDs[i] = item.ItemID
+			}
+			err = editor.SetMonitored(ctx, itemIDs, false)
+		}
+		if err != nil {
+			if hasDebug {
+				debugLogger.Debug("Failed to give up on items", "server", server.Name, "policy", config.GiveUpPolicy, "error", err)
+			}
+			return
+		}
+	}
+
+	for _, item := range items {
+		s.recordGivenUp(item)
+	}
+}
+
+// recordGivenUp saves an item given up on, logging failures at debug level.
+func (s *SearchTrigger) recordGivenUp(item database.GivenUpItem) {
+	if err := s.db.SaveGivenUpItem(item); err != nil {
+		if debugLogger, ok := s.logger.(DebugLogger); ok {
+			debugLogger.Debug("Failed to record given up item", "server", item.ServerID, "item", item.ItemID, "error", err)
+		}
+	}
+}
+
+// sameWait reports whether an item given up on was already recorded with the same wait, so it
+// doesn't have to be saved again.
+func sameWait(previous, item database.GivenUpItem) bool {
+	return previous.Policy == item.Policy && previous.Attempts == item.Attempts &&
+		previous.RetryAt != nil && previous.RetryAt.Equal(*item.RetryAt)
+}
+
+// giveUpWait returns how long after its last search an item given up on waits before it is searched
+// again. With backoff the wait starts at twice the search cooldown, or two days without one, and
+// doubles with every further search; it never exceeds GiveUpDays.
+func giveUpWait(config database.SearchConfig, attempts int) time.Duration {
+	longest := time.Duration(config.GiveUpDays) * 24 * time.Hour
+	if config.GiveUpPolicy == database.GiveUpSnooze {
+		return longest
+	}
+
+	wait := time.Duration(config.CooldownHours) * time.Hour
+	if wait <= 0 {
+		wait = 24 * time.Hour
+	}
+	for n := config.GiveUpAttempts; n <= attempts && wait < longest; n++ {
+		wait *= 2
+	}
+	return min(wait, longest)
+}
+
+// tagTarget returns the ID of what a tag is added to for an item: the series for an episode,
+// otherwise the item itself.
+func tagTarget(item api.MediaItem) int {
+	if item.Type == "episode" && item.SeriesID != 0 {
+		return item.SeriesID
+	}
+	return item.ID
+}
+
+// GivenUpInfo is an item Janitarr has given up on, for display.
+type GivenUpInfo struct {
+	database.GivenUpItem
+	ServerName string `json:"serverName"`
+	ServerType string `json:"serverType"`
+}
+
+// GiveUpManager lists and resets the items Janitarr has given up searching.
+type GiveUpManager struct {
+	db         *database.DB
+	apiFactory SearchTriggerAPIClientFactory
+}
+
+// NewGiveUpManager creates a new GiveUpManager with the given database.
+func NewGiveUpManager(db *database.DB) *GiveUpManager {
+	return &GiveUpManager{db: db, apiFactory: defaultSearchTriggerAPIClientFactory}
+}
+
+// NewGiveUpManagerWithFactory creates a new GiveUpManager with a custom API factory.
+// Useful for testing.
+func NewGiveUpManagerWithFactory(db *database.DB, factory SearchTriggerAPIClientFactory) *GiveUpManager {
+	return &GiveUpManager{db: db, apiFactory: factory}
+}
+
+// List returns the items given up on across all servers, most recent first.
+func (m *GiveUpManager) List() ([]GivenUpInfo, error) {
+	items, err := m.db.GetGivenUpItems()
+	if err != nil {
+		return nil, err
+	}
+	servers, err := m.db.GetAllServers()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]database.Server, len(servers))
+	for _, server := range servers {
+		byID[server.ID] = server
+	}
+
+	infos := make([]GivenUpInfo, 0, len(items))
+	for _, item := range items {
+		server := byID[item.ServerID]
+		infos = append(infos, GivenUpInfo{GivenUpItem: item, ServerName: server.Name, ServerType: string(server.Type)})
+	}
+	return infos, nil
+}
+
+// Reset searches an item given up on like any other item again. Items that were tagged or
+// unmonitored are untagged or monitored again on their server first. The server is given by ID or name.
+func (m *GiveUpManager) Reset(ctx context.Context, serverIDOrName string, category database.SearchCategory, itemID int) error {
+	server, err := m.db.GetServer(serverIDOrName)
+	if err == nil && server == nil {
+		server, err = m.db.GetServerByName(serverIDOrName)
+	}
+	if err != nil {
+		return err
+	}
+	if server == nil {
+		return fmt.Errorf("server '%s' not found", serverIDOrName)
+	}
+
+	item, err := m.db.GetGivenUpItem(server.ID, category, itemID)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return fmt.Errorf("%s item %d on '%s' not found among given up items", category, itemID, server.Name)
+	}
+	return m.reset(ctx, server, *item)
+}
+
+// ResetAll resets every item given up on, returning how many were reset.
+func (m *GiveUpManager) ResetAll(ctx context.Context) (int, error) {
+	items, err := m.db.GetGivenUpItems()
+	if err != nil {
+		return 0, err
+	}
+
+	reset := 0
+	for _, item := range items {
+		server, err := m.db.GetServer(item.ServerID)
+		if err != nil {
+			return reset, err
+		}
+		if server == nil {
+			continue
+		}
+		if err := m.reset(ctx, server, item); err != nil {
+			return reset, err
+		}
+		reset++
+	}
+	return reset, nil
+}
+
+// reset undoes what was done to an item given up on and forgets it was given up on. A tag stays
+// while other items given up on share it, such as other episodes of the same series.
+func (m *GiveUpManager) reset(ctx context.Context, server *database.Server, item database.GivenUpItem) error {
+	restore := item.Policy == database.GiveUpUnmonitor
+	if item.Policy == database.GiveUpTag {
+		shared, err := m.tagShared(item)
+		if err != nil {
+			return err
+		}
+		restore = !shared
+	}
+
+	if restore {
+		if editor, ok := m.apiFactory(server.URL, server.APIKey, string(server.Type)).(giveUpClient); ok {
+			var err error
+			if item.Policy == database.GiveUpTag {
+				err = editor.SetTag(ctx, []int{item.MediaID}, m.db.GetAppConfig().Search.GiveUpTagLabel, false)
+			} else {
+				err = editor.SetMonitored(ctx, []int{item.ItemID}, true)
+			}
+			if err != nil {
+				return fmt.Errorf("restoring %s on '%s': %w", item.Title, server.Name, err)
+			}
+		}
+	}
+
+	_, err := m.db.ResetGivenUpItem(item.ServerID, item.Category, item.ItemID)
+	return err
+}
+
+// tagShared reports whether another tagged item given up on has its tag on the same movie or series.
+func (m *GiveUpManager) tagShared(item database.GivenUpItem) (bool, error) {
+	items, err := m.db.GetGivenUpItems()
+	if err != nil {
+		return false, err
+	}
+	for _, other := range items {
+		self := other.Category == item.Category && other.ItemID == item.ItemID
+		if other.ServerID == item.ServerID && other.Policy == database.GiveUpTag && other.MediaID == item.MediaID && !self {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// mockGiveUpClient is a mockTriggerAPIClient that can also unmonitor and tag items.
+type mockGiveUpClient struct {
+	mockTriggerAPIClient
+	monitored map[int]bool
+	tagged    map[int]bool
+}
+
+func (m *mockGiveUpClient) SetMonitored(ctx context.Context, itemIDs []int, monitored bool) error {
+	for _, id := range itemIDs {
+		m.monitored[id] = monitored
+	}
+	return nil
+}
+
+func (m *mockGiveUpClient) SetTag(ctx context.Context, mediaIDs []int, label string, add bool) error {
+	for _, id := range mediaIDs {
+		m.tagged[id] = add
+	}
+	return nil
+}
+
+// searchTimes records n searches of an item, the last one at searchedAt.
+func searchTimes(t *testing.T, db *database.DB, serverID string, itemID, n int, searchedAt time.Time) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if err := db.RecordSearches(serverID, database.SearchCategoryMissing, []int{itemID}, 0, searchedAt); err != nil {
+			t.Fatalf("recording searches: %v", err)
+		}
+	}
+}
+
+func TestTriggerSearches_GiveUp(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    database.GiveUpPolicy
+		monitored bool // Whether item 1 stays monitored
+		tagged    bool // Whether series 10 is tagged
+	}{
+		{"unmonitor", database.GiveUpUnmonitor, false, false},
+		{"tag", database.GiveUpTag, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testTriggerDB(t)
+			server, err := db.AddServer("sonarr", "http://localhost:8989", "key", database.ServerTypeSonarr)
+			if err != nil {
+				t.Fatalf("adding server: %v", err)
+			}
+			config := db.GetAppConfig()
+			config.Search.CooldownHours = 0
+			config.Search.GiveUpAttempts = 3
+			config.Search.GiveUpPolicy = tt.policy
+			if err := db.SetAppConfig(config); err != nil {
+				t.Fatalf("setting config: %v", err)
+			}
+			searchTimes(t, db, server.ID, 1, 3, time.Now().Add(-time.Hour))
+
+			client := &mockGiveUpClient{
+				mockTriggerAPIClient: mockTriggerAPIClient{serverType: "sonarr"},
+				monitored:            map[int]bool{1: true},
+				tagged:               map[int]bool{},
+			}
+			factory := func(url, apiKey, serverType string) SearchTriggerAPIClient { return client }
+			trigger := NewSearchTriggerWithFactory(db, factory, &mockSearchTriggerLogger{})
+
+			detection := func(dryRun bool) *TriggerResults {
+				t.Helper()
+				client.triggerCalls = nil
+				detectionResults := &DetectionResults{
+					Results: []DetectionResult{{
+						ServerID:   server.ID,
+						ServerName: "sonarr",
+						ServerType: "sonarr",
+						Missing:    []int{1, 2},
+						Cutoff:     []int{},
+						MissingItems: map[int]api.MediaItem{
+							1: {ID: 1, Title: "Pilot", Type: "episode", SeriesID: 10},
+							2: {ID: 2, Title: "Second", Type: "episode", SeriesID: 10},
+						},
+					}},
+					TotalMissing: 2,
+					SuccessCount: 1,
+				}
+				results, err := trigger.TriggerSearches(context.Background(), detectionResults, database.SearchLimits{MissingEpisodesLimit: 10}, dryRun)
+				if err != nil {
+					t.Fatalf("TriggerSearches failed: %v", err)
+				}
+				return results
+			}
+
+			// A dry run reports the item as given up without changing anything
+			results := detection(true)
+			if results.GiveUpSkipped != 1 || !client.monitored[1] || len(client.tagged) != 0 {
+				t.Errorf("expected a dry run to skip item 1 only, got %d skipped (monitored %v, tagged %v)", results.GiveUpSkipped, client.monitored, client.tagged)
+			}
+			if items, _ := db.GetGivenUpItems(); len(items) != 0 {
+				t.Errorf("expected nothing recorded in a dry run, got %v", items)
+			}
+
+			results = detection(false)
+			if calls := client.getTriggerCalls(); results.GiveUpSkipped != 1 || len(calls) != 1 || !slices.Equal(calls[0], []int{2}) {
+				t.Errorf("expected only item 2 to be searched, got %v", calls)
+			}
+			if client.monitored[1] != tt.monitored || client.tagged[10] != tt.tagged {
+				t.Errorf("expected monitored %v and tagged %v, got %v and %v", tt.monitored, tt.tagged, client.monitored[1], client.tagged[10])
+			}
+			item, err := db.GetGivenUpItem(server.ID, database.SearchCategoryMissing, 1)
+			if err != nil || item == nil || item.Policy != tt.policy || item.Title != "Pilot" || item.MediaID == 0 {
+				t.Fatalf("expected item 1 to be recorded as given up, got %+v (%v)", item, err)
+			}
+
+			// Resetting restores the item on the server and searches it again
+			manager := NewGiveUpManagerWithFactory(db, factory)
+			if err := manager.Reset(context.Background(), "sonarr", database.SearchCategoryMissing, 1); err != nil {
+				t.Fatalf("Reset failed: %v", err)
+			}
+			if !client.monitored[1] || client.tagged[10] {
+				t.Errorf("expected item 1 to be restored, got monitored %v and tagged %v", client.monitored[1], client.tagged[10])
+			}
+			if err := manager.Reset(context.Background(), "sonarr", database.SearchCategoryMissing, 1); err == nil {
+				t.Error("expected resetting an item that isn't given up to fail")
+			}
+
+			results = detection(false)
+			if calls := client.getTriggerCalls(); results.GiveUpSkipped != 0 || len(calls) != 1 || !slices.Equal(calls[0], []int{1, 2}) {
+				t.Errorf("expected both items to be searched after a reset, got %v", calls)
+			}
+		})
+	}
+}
+
+func TestTriggerSearches_GiveUpBackoff(t *testing.T) {
+	db := testTriggerDB(t)
+	server, err := db.AddServer("radarr", "http://localhost:7878", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	config := db.GetAppConfig()
+	config.Search.CooldownHours = 0
+	config.Search.GiveUpAttempts = 2
+	if err := db.SetAppConfig(config); err != nil {
+		t.Fatalf("setting config: %v", err)
+	}
+
+	// Waits start at two days, so item 1 searched yesterday waits and item 3 searched three days ago doesn't
+	searchTimes(t, db, server.ID, 1, 2, time.Now().Add(-24*time.Hour))
+	searchTimes(t, db, server.ID, 2, 1, time.Now().Add(-time.Hour))
+	searchTimes(t, db, server.ID, 3, 2, time.Now().Add(-72*time.Hour))
+
+	client := &mockTriggerAPIClient{serverType: "radarr"}
+	trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+		return client
+	}, &mockSearchTriggerLogger{})
+
+	detectionResults := &DetectionResults{
+		Results:      []DetectionResult{{ServerID: server.ID, ServerName: "radarr", ServerType: "radarr", Missing: []int{1, 2, 3}, Cutoff: []int{}}},
+		TotalMissing: 3,
+		SuccessCount: 1,
+	}
+	results, err := trigger.TriggerSearches(context.Background(), detectionResults, database.SearchLimits{MissingMoviesLimit: 10}, false)
+	if err != nil {
+		t.Fatalf("TriggerSearches failed: %v", err)
+	}
+
+	if calls := client.getTriggerCalls(); results.GiveUpSkipped != 1 || len(calls) != 1 || !slices.Equal(slices.Sorted(slices.Values(calls[0])), []int{2, 3}) {
+		t.Errorf("expected items 2 and 3 to be searched, got %v (%d skipped)", calls, results.GiveUpSkipped)
+	}
+	items, err := db.GetGivenUpItems()
+	if err != nil {
+		t.Fatalf("GetGivenUpItems failed: %v", err)
+	}
+	if len(items) != 2 || items[0].RetryAt == nil {
+		t.Errorf("expected items 1 and 3 to be recorded with a retry time, got %+v", items)
+	}
+
+	// Items are only saved again when their wait changes, as for item 3 after its search
+	item1, _ := db.GetGivenUpItem(server.ID, database.SearchCategoryMissing, 1)
+	item3, _ := db.GetGivenUpItem(server.ID, database.SearchCategoryMissing, 3)
+	item1.Title = "Unchanged"
+	if err := db.SaveGivenUpItem(*item1); err != nil {
+		t.Fatalf("SaveGivenUpItem failed: %v", err)
+	}
+	if _, err := trigger.TriggerSearches(context.Background(), detectionResults, database.SearchLimits{MissingMoviesLimit: 10}, false); err != nil {
+		t.Fatalf("TriggerSearches failed: %v", err)
+	}
+	if item, _ := db.GetGivenUpItem(server.ID, database.SearchCategoryMissing, 1); item.Title != "Unchanged" {
+		t.Errorf("expected item 1 not to be saved again, got %+v", item)
+	}
+	if item, _ := db.GetGivenUpItem(server.ID, database.SearchCategoryMissing, 3); item.Attempts != item3.Attempts+1 || !item.RetryAt.After(*item3.RetryAt) {
+		t.Errorf("expected item 3 to be saved with its new wait, got %+v (was %+v)", item, item3)
+	}
+}
+
+func TestGiveUpManager_ResetKeepsSharedTag(t *testing.T) {
+	db := testTriggerDB(t)
+	server, err := db.AddServer("sonarr", "http://localhost:8989", "key", database.ServerTypeSonarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	// Two episodes of series 10 were given up on, which tagged the series once
+	for _, id := range []int{1, 2} {
+		item := database.GivenUpItem{ServerID: server.ID, Category: database.SearchCategoryMissing, ItemID: id,
+			Policy: database.GiveUpTag, MediaID: 10, GivenUpAt: time.Now()}
+		if err := db.SaveGivenUpItem(item); err != nil {
+			t.Fatalf("SaveGivenUpItem failed: %v", err)
+		}
+	}
+	client := &mockGiveUpClient{
+		mockTriggerAPIClient: mockTriggerAPIClient{serverType: "sonarr"},
+		monitored:            map[int]bool{},
+		tagged:               map[int]bool{10: true},
+	}
+	manager := NewGiveUpManagerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient { return client })
+
+	if err := manager.Reset(context.Background(), server.ID, database.SearchCategoryMissing, 1); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if !client.tagged[10] {
+		t.Error("expected the series to stay tagged while episode 2 is given up on")
+	}
+
+	if err := manager.Reset(context.Background(), server.ID, database.SearchCategoryMissing, 2); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if client.tagged[10] {
+		t.Error("expected the series to be untagged once no episode is given up on")
+	}
+}
+
+func TestGiveUpWait(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		name     string
+		policy   database.GiveUpPolicy
+		cooldown int
+		attempts int
+		want     time.Duration
+	}{
+		{"backoff starts at twice the cooldown", database.GiveUpBackoff, 12, 3, day},
+		{"backoff doubles with each search", database.GiveUpBackoff, 12, 5, 4 * day},
+		{"backoff without a cooldown", database.GiveUpBackoff, 0, 3, 2 * day},
+		{"backoff is capped", database.GiveUpBackoff, 12, 20, 30 * day},
+		{"snooze", database.GiveUpSnooze, 12, 3, 30 * day},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := database.SearchConfig{CooldownHours: tt.cooldown, GiveUpAttempts: 3, GiveUpPolicy: tt.policy, GiveUpDays: 30}
+			if got := giveUpWait(config, tt.attempts); got != tt.want {
+				t.Errorf("giveUpWait() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package services
+
+import "time"
+
+// MetricsRecorder receives measurements from the automation pipeline.
+// It is implemented by metrics.Metrics; services default to a recorder that discards everything.
+type MetricsRecorder interface {
+	ObserveCycle(duration time.Duration, failed bool)
+	ObserveDetection(serverName, serverType string, duration time.Duration)
+	SetBacklog(serverName, serverType string, missing, cutoff int)
+	IncrementSearches(serverName, serverType, category string, count int, failed bool)
+	IncrementRateLimitLockouts(serverName, serverType string)
+}
+
+// noopMetrics is the default MetricsRecorder.
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveCycle(time.Duration, bool)                    {}
+func (noopMetrics) ObserveDetection(string, string, time.Duration)      {}
+func (noopMetrics) SetBacklog(string, string, int, int)                 {}
+func (noopMetrics) IncrementSearches(string, string, string, int, bool) {}
+func (noopMetrics) IncrementRateLimitLockouts(string, string)           {}
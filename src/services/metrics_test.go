@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/logger"
+	"github.com/stretchr/testify/mock"
+)
+
+// recordingMetrics implements MetricsRecorder and keeps everything it is given.
+type recordingMetrics struct {
+	mu           sync.Mutex
+	cycles       []bool // failed flag of each cycle
+	detections   []string
+	backlogs     map[string][2]int
+	searches     map[string]int
+	failed       map[string]int
+	rateLimited  map[string]int
+	lastDuration time.Duration
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{
+		backlogs:    make(map[string][2]int),
+		searches:    make(map[string]int),
+		failed:      make(map[string]int),
+		rateLimited: make(map[string]int),
+	}
+}
+
+func (r *recordingMetrics) ObserveCycle(duration time.Duration, failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cycles = append(r.cycles, failed)
+	r.lastDuration = duration
+}
+
+func (r *recordingMetrics) ObserveDetection(serverName, serverType string, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.detections = append(r.detections, serverName)
+}
+
+func (r *recordingMetrics) SetBacklog(serverName, serverType string, missing, cutoff int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.backlogs[serverName] = [2]int{missing, cutoff}
+}
+
+func (r *recordingMetrics) IncrementSearches(serverName, serverType, category string, count int, failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.searches[serverName+":"+category] += count
+	if failed {
+		r.failed[serverName+":"+category] += count
+	}
+}
+
+func (r *recordingMetrics) IncrementRateLimitLockouts(serverName, serverType string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rateLimited[serverName]++
+}
+
+func TestDetector_RecordsMetrics(t *testing.T) {
+	db := testDetectorDB(t)
+
+	if _, err := db.AddServer("radarr1", "http://localhost:7878", "key", database.ServerTypeRadarr); err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	if _, err := db.AddServer("radarr2", "http://localhost:7879", "key", database.ServerTypeRadarr); err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	recorder := newRecordingMetrics()
+	detector := NewDetectorWithFactory(db, func(url, apiKey, serverType string) DetectorAPIClient {
+		if url == "http://localhost:7879" {
+			return &mockDetectorClient{missingErr: errors.New("connection refused")}
+		}
+		return &mockDetectorClient{
+			missing: []api.MediaItem{{ID: 1}, {ID: 2}},
+			cutoff:  []api.MediaItem{{ID: 3}},
+		}
+	}).WithMetrics(recorder)
+
+	if _, err := detector.DetectAll(context.Background()); err != nil {
+		t.Fatalf("DetectAll failed: %v", err)
+	}
+
+	// Both servers are timed, but only the successful one updates its backlog
+	if len(recorder.detections) != 2 {
+		t.Errorf("expected 2 detection timings, got %v", recorder.detections)
+	}
+	if got := recorder.backlogs["radarr1"]; got != [2]int{2, 1} {
+		t.Errorf("expected backlog [2 1] for radarr1, got %v", got)
+	}
+	if _, ok := recorder.backlogs["radarr2"]; ok {
+		t.Error("expected no backlog for the failed server")
+	}
+}
+
+func TestSearchTrigger_RecordsMetrics(t *testing.T) {
+	db := testTriggerDB(t)
+
+	server, err := db.AddServer("radarr1", "http://localhost:7878", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	client := &mockTriggerAPIClient{serverType: "radarr"}
+	recorder := newRecordingMetrics()
+	trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+		return client
+	}, &mockSearchTriggerLogger{}).WithMetrics(recorder)
+
+	detectionResults := &DetectionResults{
+		Results: []DetectionResult{
+			{ServerID: server.ID, ServerName: "radarr1", ServerType: "radarr", Missing: []int{1, 2, 3}, Cutoff: []int{4}},
+		},
+	}
+	limits := database.SearchLimits{MissingMoviesLimit: 10, CutoffMoviesLimit: 10}
+
+	// Dry runs search nothing and record nothing
+	if _, err := trigger.TriggerSearches(context.Background(), detectionResults, limits, true); err != nil {
+		t.Fatalf("TriggerSearches failed: %v", err)
+	}
+	if len(recorder.searches) != 0 {
+		t.Errorf("expected no searches recorded for a dry run, got %v", recorder.searches)
+	}
+
+	if _, err := trigger.TriggerSearches(context.Background(), detectionResults, limits, false); err != nil {
+		t.Fatalf("TriggerSearches failed: %v", err)
+	}
+	if recorder.searches["radarr1:missing"] != 3 || recorder.searches["radarr1:cutoff"] != 1 {
+		t.Errorf("unexpected searches recorded: %v", recorder.searches)
+	}
+	if len(recorder.failed) != 0 {
+		t.Errorf("expected no failed searches, got %v", recorder.failed)
+	}
+}
+
+func TestSearchTrigger_RecordsRateLimitLockout(t *testing.T) {
+	db := testTriggerDB(t)
+
+	servers := make([]*database.Server, 0, 2)
+	for _, name := range []string{"radarr1", "radarr2"} {
+		server, err := db.AddServer(name, "http://"+name, "key", database.ServerTypeRadarr)
+		if err != nil {
+			t.Fatalf("adding server: %v", err)
+		}
+		servers = append(servers, server)
+	}
+
+	client := &mockTriggerAPIClient{
+		serverType: "radarr",
+		triggerErr: &api.RateLimitError{RetryAfter: 30 * time.Second},
+	}
+	recorder := newRecordingMetrics()
+	trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+		return client
+	}, &mockSearchTriggerLogger{}).WithMetrics(recorder)
+
+	// radarr1 already has one strike, so both of its batches being rate limited locks it out;
+	// radarr2 is rate limited only once
+	allocations := []serverItemAllocation{
+		{serverID: servers[0].ID, serverName: "radarr1", serverType: "radarr", missing: []int{1}, cutoff: []int{2}, rateLimitCount: 1},
+		{serverID: servers[1].ID, serverName: "radarr2", serverType: "radarr", missing: []int{3}},
+	}
+
+	if _, err := trigger.executeAllocations(context.Background(), allocations, false); err != nil {
+		t.Fatalf("executeAllocations failed: %v", err)
+	}
+
+	if recorder.rateLimited["radarr1"] != 1 {
+		t.Errorf("expected 1 lockout for radarr1, got %v", recorder.rateLimited)
+	}
+	if recorder.rateLimited["radarr2"] != 0 {
+		t.Errorf("expected no lockout for radarr2, got %v", recorder.rateLimited)
+	}
+	if recorder.failed["radarr1:missing"] != 1 || recorder.failed["radarr1:cutoff"] != 1 || recorder.failed["radarr2:missing"] != 1 {
+		t.Errorf("unexpected failed searches: %v", recorder.failed)
+	}
+}
+
+func TestAutomation_RecordsCycleMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	mockDB := new(MockDB)
+	mockDB.On("GetAppConfig").Return(*defaultAppConfig())
+
+	mockLogger := new(MockLogger)
+	mockLogger.On("LogCycleStart", mock.Anything).Return(&logger.LogEntry{})
+	mockLogger.On("LogCycleEnd", mock.Anything, mock.Anything, mock.Anything).Return(&logger.LogEntry{})
+	mockLogger.On("LogServerError", mock.Anything, mock.Anything, mock.Anything).Return(&logger.LogEntry{})
+
+	detectionResults := &DetectionResults{
+		Results: []DetectionResult{{ServerName: "Server1", ServerType: "radarr", Error: "connection refused"}},
+	}
+	mockDetector := new(MockDetector)
+	mockDetector.On("DetectAll", mock.Anything).Return(detectionResults, nil)
+
+	mockSearchTrigger := new(MockSearchTrigger)
+	mockSearchTrigger.On("TriggerSearches", mock.Anything, detectionResults, mock.Anything, mock.Anything).Return(&TriggerResults{}, nil)
+
+	recorder := newRecordingMetrics()
+	automation := NewAutomation(mockDB, mockDetector, mockSearchTrigger, mockLogger).WithMetrics(recorder)
+
+	// Dry runs are not counted as cycles
+	_, _ = automation.RunCycle(ctx, false, true)
+	if len(recorder.cycles) != 0 {
+		t.Errorf("expected no cycles recorded for a dry run, got %v", recorder.cycles)
+	}
+
+	_, _ = automation.RunCycle(ctx, false, false)
+	if len(recorder.cycles) != 1 || !recorder.cycles[0] {
+		t.Errorf("expected one failed cycle, got %v", recorder.cycles)
+	}
+	if recorder.lastDuration <= 0 {
+		t.Error("expected cycle duration to be recorded")
+	}
+}
@@ -37,7 +37,9 @@ func (m *MockDetectorAPIClient) GetAllCutoffUnmet(ctx context.Context) ([]api.Me
 	}
 	return items, nil
 }
-func (m *MockDetectorAPIClient) TriggerSearch(ctx context.Context, ids []int) error { return nil }
+func (m *MockDetectorAPIClient) TriggerSearch(ctx context.Context, ids []int) (*api.CommandResponse, error) {
+	return &api.CommandResponse{}, nil
+}
 
 // MockTriggerAPIClient is a mock API client for testing SearchTrigger.
 type MockTriggerAPIClient struct {
@@ -66,11 +68,14 @@ func (m *MockTriggerAPIClient) GetAllCutoffUnmet(ctx context.Context) ([]api.Med
 	return m.CutoffItems, nil
 }
 
-func (m *MockTriggerAPIClient) TriggerSearch(ctx context.Context, ids []int) error {
+func (m *MockTriggerAPIClient) TriggerSearch(ctx context.Context, ids []int) (*api.CommandResponse, error) {
 	m.Mu.Lock()
 	defer m.Mu.Unlock()
 	m.TriggerCalls = append(m.TriggerCalls, ids)
-	return m.TriggerErr
+	if m.TriggerErr != nil {
+		return nil, m.TriggerErr
+	}
+	return &api.CommandResponse{ID: len(m.TriggerCalls), Status: api.CommandStatusQueued}, nil
 }
 
 func (m *MockTriggerAPIClient) GetTriggerCalls() [][]int {
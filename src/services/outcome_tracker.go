@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/logger"
+)
+
+const (
+	// OutcomePollInterval is how often search outcomes are checked.
+	OutcomePollInterval = 2 * time.Minute
+
+	// outcomeCommandTimeout is how long a search command may stay unfinished before it counts as failed.
+	// Servers forget commands on restart, so this also covers commands that no longer exist.
+	outcomeCommandTimeout = 6 * time.Hour
+
+	// outcomeGrabWindow is how long after a search a grab is still credited to it.
+	outcomeGrabWindow = 24 * time.Hour
+)
+
+// OutcomeAPIClient is the interface for API clients used by the OutcomeTracker.
+type OutcomeAPIClient interface {
+	GetCommand(ctx context.Context, id int) (*api.CommandResponse, error)
+	GetGrabsSince(ctx context.Context, since time.Time) ([]api.HistoryRecord, error)
+}
+
+// OutcomeAPIClientFactory creates API clients for outcome tracking.
+type OutcomeAPIClientFactory func(url, apiKey, serverType string) OutcomeAPIClient
+
+// defaultOutcomeAPIClientFactory creates real API clients.
+func defaultOutcomeAPIClientFactory(url, apiKey, serverType string) OutcomeAPIClient {
+	switch serverType {
+	case "sonarr":
+		return api.NewSonarrClient(url, apiKey)
+	case "lidarr":
+		return api.NewLidarrClient(url, apiKey)
+	case "readarr":
+		return api.NewReadarrClient(url, apiKey)
+	default:
+		return api.NewRadarrClient(url, apiKey)
+	}
+}
+
+// OutcomeLogger is the interface for logging search outcomes.
+type OutcomeLogger interface {
+	LogSearchOutcome(serverName, serverType, outcome string, count int) *logger.LogEntry
+	Warn(msg string, keyvals ...interface{})
+}
+
+// OutcomeTracker follows triggered searches by polling each server's command status and
+// grab history, recording whether every search completed, failed or grabbed a release.
+type OutcomeTracker struct {
+	db         *database.DB
+	apiFactory OutcomeAPIClientFactory
+	logger     OutcomeLogger
+	breaker    *CircuitBreaker
+
+	mu             sync.Mutex
+	grabsCheckedAt map[string]time.Time // When each server's grab history was last read
+}
+
+// NewOutcomeTracker creates a new OutcomeTracker.
+func NewOutcomeTracker(db *database.DB, appLogger OutcomeLogger) *OutcomeTracker {
+	return NewOutcomeTrackerWithFactory(db, defaultOutcomeAPIClientFactory, appLogger)
+}
+
+// NewOutcomeTrackerWithFactory creates a new OutcomeTracker with a custom API factory.
+// Useful for testing.
+func NewOutcomeTrackerWithFactory(db *database.DB, factory OutcomeAPIClientFactory, appLogger OutcomeLogger) *OutcomeTracker {
+	return &OutcomeTracker{
+		db:             db,
+		apiFactory:     factory,
+		logger:         appLogger,
+		breaker:        NewCircuitBreaker(db),
+		grabsCheckedAt: make(map[string]time.Time),
+	}
+}
+
+// Run polls for outcomes at the given interval until the context is cancelled.
+func (t *OutcomeTracker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := t.Poll(ctx); err != nil {
+				t.logger.Warn("Search outcome tracking failed", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Poll checks the outcome of pending searches on every enabled server once.
+// Servers whose circuit is open are left alone until an automation cycle finds them healthy again;
+// other servers that cannot be reached are skipped and reported in the returned error.
+func (t *OutcomeTracker) Poll(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	servers, err := t.db.GetAllServers()
+	if err != nil {
+		return fmt.Errorf("getting servers: %w", err)
+	}
+
+	var errs []error
+	for i := range servers {
+		if !servers[i].Enabled || t.breaker.IsOpen(servers[i].ID) {
+			continue
+		}
+		if err := t.pollServer(ctx, &servers[i]); err != nil {
+			errs = append(errs, fmt.Errorf("server %s: %w", servers[i].Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// pollServer records the outcomes of one server's finished commands and recent grabs.
+func (t *OutcomeTracker) pollServer(ctx context.Context, server *database.Server) error {
+	client := t.apiFactory(server.URL, server.APIKey, string(server.Type))
+	now := time.Now()
+	counts := make(map[database.SearchOutcome]int)
+
+	// Credit grabs first so searches that found a release aren't logged as completed without one
+	grabbed, grabErr := t.recordGrabs(ctx, client, server, now)
+	counts[database.SearchOutcomeGrabbed] += grabbed
+
+	pending, err := t.db.GetPendingSearchCommands(server.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, command := range pending {
+		status, err := client.GetCommand(ctx, command.CommandID)
+
+		var outcome database.SearchOutcome
+		switch {
+		case err == nil && status.Finished() && status.Succeeded():
+			outcome = database.SearchOutcomeCompleted
+		case err == nil && status.Finished():
+			outcome = database.SearchOutcomeFailed
+		case now.Sub(command.SearchedAt) > outcomeCommandTimeout:
+			outcome = database.SearchOutcomeFailed
+		default:
+			continue // Still running, or the server is unreachable for now
+		}
+
+		updated, err := t.db.SetSearchCommandOutcome(server.ID, command.CommandID, outcome, now)
+		if err != nil {
+			return err
+		}
+		counts[outcome] += updated
+	}
+
+	for _, outcome := range []database.SearchOutcome{database.SearchOutcomeGrabbed, database.SearchOutcomeCompleted, database.SearchOutcomeFailed} {
+		if counts[outcome] > 0 {
+			t.logger.LogSearchOutcome(server.Name, string(server.Type), string(outcome), counts[outcome])
+		}
+	}
+
+	return grabErr
+}
+
+// recordGrabs credits releases grabbed since the last check to the searches that preceded them.
+func (t *OutcomeTracker) recordGrabs(ctx context.Context, client OutcomeAPIClient, server *database.Server, now time.Time) (int, error) {
+	since := t.grabsCheckedAt[server.ID]
+	if earliest := now.Add(-outcomeGrabWindow); since.Before(earliest) {
+		since = earliest
+	}
+
+	records, err := client.GetGrabsSince(ctx, since)
+	if err != nil {
+		return 0, fmt.Errorf("getting grab history: %w", err)
+	}
+
+	grabs := make(map[int]time.Time)
+	for _, record := range records {
+		if id := record.ItemID(); id != 0 && record.Date.After(grabs[id]) {
+			grabs[id] = record.Date
+		}
+	}
+
+	updated, err := t.db.MarkSearchesGrabbed(server.ID, grabs, outcomeGrabWindow)
+	if err != nil {
+		return 0, err
+	}
+
+	t.grabsCheckedAt[server.ID] = now
+	return updated, nil
+}
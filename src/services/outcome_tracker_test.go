@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/logger"
+)
+
+// mockOutcomeClient implements OutcomeAPIClient for testing.
+type mockOutcomeClient struct {
+	commands map[int]api.CommandResponse
+	grabs    []api.HistoryRecord
+	grabsErr error
+}
+
+func (m *mockOutcomeClient) GetCommand(ctx context.Context, id int) (*api.CommandResponse, error) {
+	command, ok := m.commands[id]
+	if !ok {
+		return nil, errors.New("not found: check server URL")
+	}
+	return &command, nil
+}
+
+func (m *mockOutcomeClient) GetGrabsSince(ctx context.Context, since time.Time) ([]api.HistoryRecord, error) {
+	return m.grabs, m.grabsErr
+}
+
+// mockOutcomeLogger records logged outcomes for testing.
+type mockOutcomeLogger struct {
+	outcomes map[string]int
+}
+
+func (m *mockOutcomeLogger) LogSearchOutcome(serverName, serverType, outcome string, count int) *logger.LogEntry {
+	m.outcomes[outcome] += count
+	return nil
+}
+
+func (m *mockOutcomeLogger) Warn(msg string, keyvals ...interface{}) {}
+
+func TestOutcomeTracker_Poll(t *testing.T) {
+	db := testTriggerDB(t)
+
+	server, err := db.AddServer("radarr1", "http://localhost:7878", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	recent := time.Now().Add(-10 * time.Minute)
+	_ = db.RecordSearches(server.ID, database.SearchCategoryMissing, []int{1, 2}, 100, recent)
+	_ = db.RecordSearches(server.ID, database.SearchCategoryMissing, []int{3}, 101, recent)
+	_ = db.RecordSearches(server.ID, database.SearchCategoryCutoff, []int{4}, 102, recent)
+	_ = db.RecordSearches(server.ID, database.SearchCategoryCutoff, []int{5}, 103, time.Now().Add(-7*time.Hour))
+
+	client := &mockOutcomeClient{
+		commands: map[int]api.CommandResponse{
+			100: {ID: 100, Status: api.CommandStatusCompleted, Result: "successful"},
+			101: {ID: 101, Status: api.CommandStatusFailed},
+			102: {ID: 102, Status: api.CommandStatusStarted},
+			// 103 is no longer known to the server and has timed out
+		},
+		grabs: []api.HistoryRecord{
+			{EventType: "grabbed", Date: time.Now(), MovieID: 2},
+		},
+	}
+	log := &mockOutcomeLogger{outcomes: make(map[string]int)}
+	tracker := NewOutcomeTrackerWithFactory(db, func(url, apiKey, serverType string) OutcomeAPIClient {
+		return client
+	}, log)
+
+	if err := tracker.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+
+	stats, err := db.GetSearchOutcomeStats()
+	if err != nil || len(stats) != 1 {
+		t.Fatalf("GetSearchOutcomeStats failed: %v", err)
+	}
+	got := stats[0]
+	if got.Grabbed != 1 || got.Completed != 1 || got.Failed != 2 || got.Pending != 1 {
+		t.Errorf("unexpected outcome counts: %+v", got)
+	}
+	if log.outcomes["grabbed"] != 1 || log.outcomes["completed"] != 1 || log.outcomes["failed"] != 2 {
+		t.Errorf("unexpected logged outcomes: %v", log.outcomes)
+	}
+
+	// The running command finishes on the next poll
+	client.commands[102] = api.CommandResponse{ID: 102, Status: api.CommandStatusCompleted}
+	client.grabs = nil
+	if err := tracker.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	pending, _ := db.GetPendingSearchCommands(server.ID)
+	if len(pending) != 0 {
+		t.Errorf("expected no pending commands, got %+v", pending)
+	}
+}
+
+func TestOutcomeTracker_SkipsOpenCircuit(t *testing.T) {
+	db := testTriggerDB(t)
+
+	server, err := db.AddServer("radarr1", "http://localhost:7878", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	_ = db.RecordSearches(server.ID, database.SearchCategoryMissing, []int{1}, 100, time.Now())
+
+	breaker := NewCircuitBreaker(db)
+	for i := 0; i < circuitFailureThreshold; i++ {
+		breaker.RecordFailure(server.ID, "connection refused")
+	}
+
+	polled := 0
+	client := &mockOutcomeClient{commands: map[int]api.CommandResponse{
+		100: {ID: 100, Status: api.CommandStatusCompleted, Result: "successful"},
+	}}
+	tracker := NewOutcomeTrackerWithFactory(db, func(url, apiKey, serverType string) OutcomeAPIClient {
+		polled++
+		return client
+	}, &mockOutcomeLogger{outcomes: make(map[string]int)})
+
+	if err := tracker.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if polled != 0 {
+		t.Errorf("expected a server with an open circuit not to be polled, got %d polls", polled)
+	}
+
+	// Polling resumes once the circuit closes
+	breaker.RecordSuccess(server.ID)
+	if err := tracker.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if pending, _ := db.GetPendingSearchCommands(server.ID); polled != 1 || len(pending) != 0 {
+		t.Errorf("expected the command to be resolved after the circuit closed, got %d polls and %d pending", polled, len(pending))
+	}
+}
+
+func TestOutcomeTracker_HistoryError(t *testing.T) {
+	db := testTriggerDB(t)
+
+	server, err := db.AddServer("sonarr1", "http://localhost:8989", "key", database.ServerTypeSonarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	_ = db.RecordSearches(server.ID, database.SearchCategoryMissing, []int{7}, 5, time.Now())
+
+	client := &mockOutcomeClient{
+		commands: map[int]api.CommandResponse{5: {ID: 5, Status: api.CommandStatusCompleted}},
+		grabsErr: errors.New("server error: status 500"),
+	}
+	tracker := NewOutcomeTrackerWithFactory(db, func(url, apiKey, serverType string) OutcomeAPIClient {
+		return client
+	}, &mockOutcomeLogger{outcomes: make(map[string]int)})
+
+	// Command outcomes are still recorded when the history cannot be read
+	if err := tracker.Poll(context.Background()); err == nil {
+		t.Error("expected error when grab history fails")
+	}
+	stats, _ := db.GetSearchOutcomeStats()
+	if len(stats) != 1 || stats[0].Completed != 1 {
+		t.Errorf("expected 1 completed search, got %+v", stats)
+	}
+}
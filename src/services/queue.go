@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// queueClient is implemented by API clients that can list the downloads in a server's queue.
+type queueClient interface {
+	GetQueue(ctx context.Context) ([]api.QueueItem, error)
+}
+
+// applyQueue returns a copy of the detection results without items that are already downloading,
+// along with the number of items skipped. If maxQueue is set, servers with more active downloads
+// than that are not searched at all and their names are returned.
+// If a server's queue cannot be read, its items are left unfiltered.
+func (s *SearchTrigger) applyQueue(ctx context.Context, detectionResults *DetectionResults, serverMap map[string]*database.Server, maxQueue int) (*DetectionResults, int, []string) {
+	filtered := *detectionResults
+	filtered.Results = make([]DetectionResult, len(detectionResults.Results))
+	skipped := 0
+	var full []string
+
+	for i, result := range detectionResults.Results {
+		filtered.Results[i] = result
+		server, ok := serverMap[result.ServerID]
+		if result.Error != "" || !ok || len(result.Missing)+len(result.Cutoff) == 0 {
+			continue
+		}
+
+		queued, downloads, ok := s.activeDownloads(ctx, server)
+		if !ok {
+			continue
+		}
+		if maxQueue > 0 && downloads > maxQueue {
+			filtered.Results[i].Missing = []int{}
+			filtered.Results[i].Cutoff = []int{}
+			full = append(full, result.ServerName)
+			continue
+		}
+
+		var removed int
+		filtered.Results[i].Missing, removed = withoutQueued(result.Missing, queued)
+		skipped += removed
+		filtered.Results[i].Cutoff, removed = withoutQueued(result.Cutoff, queued)
+		skipped += removed
+	}
+
+	return &filtered, skipped, full
+}
+
+// activeDownloads returns the IDs of the items a server is downloading and the number of active
+// downloads in its queue. It reports false if the client can't read queues or the queue could not be read.
+func (s *SearchTrigger) activeDownloads(ctx context.Context, server *database.Server) (map[int]bool, int, bool) {
+	client := s.apiFactory(server.URL, server.APIKey, string(server.Type))
+	queue, ok := client.(queueClient)
+	if !ok {
+		return nil, 0, false
+	}
+	debugLogger, hasDebug := s.logger.(DebugLogger)
+	if hasDebug {
+		attachAPILogger(client, debugLogger, server.Name)
+	}
+	applyPagination(client, server.Pagination)
+
+	items, err := queue.GetQueue(ctx)
+	if err != nil {
+		if hasDebug {
+			debugLogger.Debug("Failed to read download queue, searching queued items anyway", "server", server.Name, "error", err)
+		}
+		return nil, 0, false
+	}
+
+	queued := make(map[int]bool)
+	downloads := 0
+	for _, item := range items {
+		if item.Active() {
+			queued[item.ItemID()] = true
+			downloads++
+		}
+	}
+	return queued, downloads, true
+}
+
+// withoutQueued removes item IDs with an active download, preserving order.
+func withoutQueued(itemIDs []int, queued map[int]bool) ([]int, int) {
+	if len(itemIDs) == 0 || len(queued) == 0 {
+		return itemIDs, 0
+	}
+
+	eligible := make([]int, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		if !queued[id] {
+			eligible = append(eligible, id)
+		}
+	}
+	return eligible, len(itemIDs) - len(eligible)
+}
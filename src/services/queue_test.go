@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// mockQueueClient is a mockTriggerAPIClient that also reports its download queue.
+type mockQueueClient struct {
+	mockTriggerAPIClient
+	queue []api.QueueItem
+}
+
+func (m *mockQueueClient) GetQueue(ctx context.Context) ([]api.QueueItem, error) {
+	return m.queue, nil
+}
+
+func TestTriggerSearches_SkipsQueuedItems(t *testing.T) {
+	db := testTriggerDB(t)
+	radarr, err := db.AddServer("radarr", "http://localhost:7878", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	busy, err := db.AddServer("busy", "http://localhost:7879", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	clients := map[string]*mockQueueClient{
+		radarr.URL: {queue: []api.QueueItem{
+			{ID: 1, Status: "downloading", MovieID: 2},
+			{ID: 2, Status: "failed", MovieID: 3},
+			{ID: 3, Status: "queued", MovieID: 5},
+		}},
+		busy.URL: {queue: []api.QueueItem{
+			{ID: 1, Status: "downloading", MovieID: 20},
+			{ID: 2, Status: "downloading", MovieID: 21},
+			{ID: 3, Status: "downloading", MovieID: 22},
+		}},
+	}
+	trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+		return clients[url]
+	}, &mockSearchTriggerLogger{})
+
+	detection := func(maxQueue int) *TriggerResults {
+		t.Helper()
+		config := db.GetAppConfig()
+		config.Search.MaxQueueSize = maxQueue
+		config.Search.CooldownHours = 0
+		if err := db.SetAppConfig(config); err != nil {
+			t.Fatalf("setting config: %v", err)
+		}
+		for _, client := range clients {
+			client.triggerCalls = nil
+		}
+
+		detectionResults := &DetectionResults{
+			Results: []DetectionResult{
+				{ServerID: radarr.ID, ServerName: "radarr", ServerType: "radarr", Missing: []int{1, 2, 3}, Cutoff: []int{4, 5}},
+				{ServerID: busy.ID, ServerName: "busy", ServerType: "radarr", Missing: []int{30}, Cutoff: []int{}},
+			},
+			TotalMissing: 4,
+			TotalCutoff:  2,
+			SuccessCount: 2,
+		}
+		limits := database.SearchLimits{MissingMoviesLimit: 10, CutoffMoviesLimit: 10}
+		results, err := trigger.TriggerSearches(context.Background(), detectionResults, limits, false)
+		if err != nil {
+			t.Fatalf("TriggerSearches failed: %v", err)
+		}
+		return results
+	}
+
+	// Items with an active download are skipped; failed downloads are searched again
+	results := detection(0)
+	if results.QueueSkipped != 2 || len(results.QueueFullServers) != 0 {
+		t.Errorf("expected 2 queued items skipped, got %d (full: %v)", results.QueueSkipped, results.QueueFullServers)
+	}
+	calls := clients[radarr.URL].getTriggerCalls()
+	if len(calls) != 2 || !slices.Equal(calls[0], []int{1, 3}) || !slices.Equal(calls[1], []int{4}) {
+		t.Errorf("expected missing [1 3] and cutoff [4] to be searched, got %v", calls)
+	}
+	if len(clients[busy.URL].getTriggerCalls()) != 1 {
+		t.Errorf("expected the busy server to be searched without a queue limit")
+	}
+
+	// A server with more downloads than the limit is skipped entirely
+	results = detection(2)
+	if !slices.Equal(results.QueueFullServers, []string{"busy"}) {
+		t.Errorf("expected busy to be skipped for a full queue, got %v", results.QueueFullServers)
+	}
+	if len(clients[busy.URL].getTriggerCalls()) != 0 || len(clients[radarr.URL].getTriggerCalls()) != 2 {
+		t.Errorf("expected only radarr to be searched")
+	}
+}
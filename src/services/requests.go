@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// requestedItems indexes outstanding Overseerr or Jellyseerr requests by the IDs Radarr and Sonarr know them by.
+type requestedItems struct {
+	movies map[int]string        // Who requested each movie, by TMDB ID
+	series map[int]seriesRequest // Requested series by TVDB ID
+}
+
+// seriesRequest records the requests for one series.
+type seriesRequest struct {
+	requestedBy string
+	seasons     map[int]bool // Requested seasons (nil = every season)
+}
+
+// newRequestedItems indexes requests. When several requests are for the same title, the first
+// one's user is kept, and a series' requested seasons are combined.
+func newRequestedItems(requests []api.MediaRequest) *requestedItems {
+	index := &requestedItems{movies: make(map[int]string), series: make(map[int]seriesRequest)}
+	for _, request := range requests {
+		requestedBy := request.RequestedBy.DisplayName
+		if requestedBy == "" {
+			requestedBy = "unknown user"
+		}
+
+		switch request.Media.MediaType {
+		case "movie":
+			if _, ok := index.movies[request.Media.TmdbID]; !ok && request.Media.TmdbID != 0 {
+				index.movies[request.Media.TmdbID] = requestedBy
+			}
+		case "tv":
+			if request.Media.TvdbID == 0 {
+				continue
+			}
+			series, ok := index.series[request.Media.TvdbID]
+			if !ok {
+				series = seriesRequest{requestedBy: requestedBy, seasons: make(map[int]bool)}
+			}
+			if len(request.Seasons) == 0 {
+				series.seasons = nil
+			} else if series.seasons != nil {
+				for _, season := range request.Seasons {
+					series.seasons[season.SeasonNumber] = true
+				}
+			}
+			index.series[request.Media.TvdbID] = series
+		}
+	}
+	return index
+}
+
+// requestedBy returns who requested an item, or "" if nobody did.
+func (r *requestedItems) requestedBy(item api.MediaItem) string {
+	switch item.Type {
+	case "movie":
+		if item.TmdbID != 0 {
+			return r.movies[item.TmdbID]
+		}
+	case "episode":
+		if series, ok := r.series[item.SeriesTvdbID]; ok && item.SeriesTvdbID != 0 && (series.seasons == nil || series.seasons[item.SeasonNumber]) {
+			return series.requestedBy
+		}
+	}
+	return ""
+}
+
+// outstandingRequests reads the approved requests whose media isn't available yet from Overseerr or
+// Jellyseerr. It returns nil if neither is configured or the requests cannot be read, in which case
+// items are searched in the usual order.
+func (s *SearchTrigger) outstandingRequests(ctx context.Context, config database.RequestsConfig) *requestedItems {
+	apiKey := s.db.GetRequestsAPIKey()
+	if config.URL == "" || apiKey == "" {
+		return nil
+	}
+
+	client := api.NewOverseerrClient(config.URL, apiKey)
+	debugLogger, hasDebug := s.logger.(DebugLogger)
+	if hasDebug {
+		attachAPILogger(client, debugLogger, "requests")
+	}
+
+	requests, err := client.GetOutstandingRequests(ctx)
+	if err != nil {
+		if hasDebug {
+			debugLogger.Debug("Failed to read requests, searching in the usual order", "url", config.URL, "error", err)
+		}
+		return nil
+	}
+	return newRequestedItems(requests)
+}
+
+// applyRequests returns a copy of the detection results with each server's requested items moved
+// to the front, otherwise keeping the order chosen by the selection strategy. It also returns who
+// requested each item, by server ID.
+func (s *SearchTrigger) applyRequests(detectionResults *DetectionResults, requested *requestedItems) (*DetectionResults, map[string]map[int]string) {
+	if requested == nil {
+		return detectionResults, nil
+	}
+
+	ordered := *detectionResults
+	ordered.Results = make([]DetectionResult, len(detectionResults.Results))
+	requesters := make(map[string]map[int]string)
+
+	for i, result := range detectionResults.Results {
+		ordered.Results[i] = result
+		if result.Error != "" {
+			continue
+		}
+
+		serverRequesters := make(map[int]string)
+		ordered.Results[i].Missing = requestedFirst(result.Missing, result.MissingItems, requested, serverRequesters)
+		ordered.Results[i].Cutoff = requestedFirst(result.Cutoff, result.CutoffItems, requested, serverRequesters)
+		if len(serverRequesters) > 0 {
+			requesters[result.ServerID] = serverRequesters
+		}
+	}
+
+	return &ordered, requesters
+}
+
+// requestedFirst moves requested items to the front, preserving order otherwise, and records who requested them.
+func requestedFirst(itemIDs []int, items map[int]api.MediaItem, requested *requestedItems, requesters map[int]string) []int {
+	front := make([]int, 0, len(itemIDs))
+	var back []int
+	for _, id := range itemIDs {
+		if requestedBy := requested.requestedBy(items[id]); requestedBy != "" {
+			requesters[id] = requestedBy
+			front = append(front, id)
+		} else {
+			back = append(back, id)
+		}
+	}
+	return append(front, back...)
+}
+
+// packRequestedBy returns who requested any of the episodes a season or series search covers.
+func (alloc *serverItemAllocation) packRequestedBy(pack searchPack) string {
+	for _, id := range pack.episodeIDs {
+		if requestedBy := alloc.requested[id]; requestedBy != "" {
+			return requestedBy
+		}
+	}
+	return ""
+}
+
+// requestedSearches counts the requested items among successful searches.
+func requestedSearches(results []TriggerResult, requested map[string]map[int]string) int {
+	count := 0
+	for _, result := range results {
+		if !result.Success {
+			continue
+		}
+		for _, id := range result.ItemIDs {
+			if requested[result.ServerID][id] != "" {
+				count++
+			}
+		}
+	}
+	return count
+}
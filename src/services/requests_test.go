@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/logger"
+)
+
+// requestLogger is a mockSearchTriggerLogger that records who requested each searched item.
+type requestLogger struct {
+	mockSearchTriggerLogger
+	requestedBy map[int]string
+}
+
+func (l *requestLogger) LogMovieSearch(serverName, serverType string, movieID int, title string, year int, qualityProfile, category, requestedBy string) *logger.LogEntry {
+	l.requestedBy[movieID] = requestedBy
+	return nil
+}
+
+func (l *requestLogger) LogEpisodeSearch(serverName, serverType string, episodeID, seriesID int, seriesTitle, episodeTitle string, season, episode int, qualityProfile, category, requestedBy string) *logger.LogEntry {
+	l.requestedBy[episodeID] = requestedBy
+	return nil
+}
+
+// overseerrStandIn serves the given requests like Overseerr's request list, or fails with status if it is set.
+func overseerrStandIn(t *testing.T, requests []api.MediaRequest, status int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/request" || r.Header.Get("X-Api-Key") != "seerr-key" {
+			t.Errorf("unexpected request %s with key %q", r.URL.Path, r.Header.Get("X-Api-Key"))
+		}
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		var page api.RequestPage
+		page.PageInfo.Results = len(requests)
+		page.Results = requests
+		json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTriggerSearches_RequestedFirst(t *testing.T) {
+	requests := []api.MediaRequest{
+		{Status: api.RequestStatusApproved, Media: api.RequestedMedia{MediaType: "movie", TmdbID: 103, Status: 3}, RequestedBy: api.RequestUser{DisplayName: "Alice"}},
+		{Status: api.RequestStatusApproved, Media: api.RequestedMedia{MediaType: "movie", TmdbID: 102, Status: api.MediaStatusAvailable}, RequestedBy: api.RequestUser{DisplayName: "Carol"}},
+		{Status: api.RequestStatusApproved, Media: api.RequestedMedia{MediaType: "tv", TmdbID: 9, TvdbID: 500, Status: 4},
+			Seasons: []api.RequestedSeason{{SeasonNumber: 2}}, RequestedBy: api.RequestUser{DisplayName: "Bob"}},
+	}
+
+	tests := []struct {
+		name            string
+		status          int // Stand-in failure status (0 = serve the requests)
+		wantMovies      []int
+		wantEpisodes    []int
+		wantRequested   int
+		wantRequestedBy map[int]string
+	}{
+		{"requests first", 0, []int{3}, []int{12}, 2, map[int]string{3: "Alice", 12: "Bob"}},
+		{"requests unavailable", http.StatusUnauthorized, []int{1}, []int{11}, 0, map[int]string{1: "", 11: ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testTriggerDB(t)
+			radarr, err := db.AddServer("radarr", "http://localhost:7878", "key", database.ServerTypeRadarr)
+			if err != nil {
+				t.Fatalf("adding server: %v", err)
+			}
+			sonarr, err := db.AddServer("sonarr", "http://localhost:8989", "key", database.ServerTypeSonarr)
+			if err != nil {
+				t.Fatalf("adding server: %v", err)
+			}
+
+			standIn := overseerrStandIn(t, requests, tt.status)
+			config := db.GetAppConfig()
+			config.Requests.URL = standIn.URL
+			config.Search.SeasonPackPercent = 0
+			if err := db.SetAppConfig(config); err != nil {
+				t.Fatalf("setting config: %v", err)
+			}
+			if err := db.SetRequestsAPIKey("seerr-key"); err != nil {
+				t.Fatalf("setting API key: %v", err)
+			}
+
+			clients := map[string]*mockTriggerAPIClient{radarr.URL: {serverType: "radarr"}, sonarr.URL: {serverType: "sonarr"}}
+			log := &requestLogger{requestedBy: map[int]string{}}
+			trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+				return clients[url]
+			}, log)
+
+			detection := &DetectionResults{
+				Results: []DetectionResult{
+					{ServerID: radarr.ID, ServerName: "radarr", ServerType: "radarr", Missing: []int{1, 2, 3}, Cutoff: []int{}, MissingItems: map[int]api.MediaItem{
+						1: {ID: 1, Type: "movie", TmdbID: 101},
+						2: {ID: 2, Type: "movie", TmdbID: 102},
+						3: {ID: 3, Type: "movie", TmdbID: 103},
+					}},
+					{ServerID: sonarr.ID, ServerName: "sonarr", ServerType: "sonarr", Missing: []int{11, 12, 13}, Cutoff: []int{}, MissingItems: map[int]api.MediaItem{
+						11: {ID: 11, Type: "episode", SeriesTvdbID: 500, SeasonNumber: 1},
+						12: {ID: 12, Type: "episode", SeriesTvdbID: 500, SeasonNumber: 2},
+						13: {ID: 13, Type: "episode", SeriesTvdbID: 600, SeasonNumber: 2},
+					}},
+				},
+				TotalMissing: 6,
+				SuccessCount: 2,
+			}
+			limits := database.SearchLimits{MissingMoviesLimit: 1, MissingEpisodesLimit: 1}
+			results, err := trigger.TriggerSearches(context.Background(), detection, limits, false)
+			if err != nil {
+				t.Fatalf("TriggerSearches failed: %v", err)
+			}
+
+			if calls := clients[radarr.URL].getTriggerCalls(); len(calls) != 1 || !slices.Equal(calls[0], tt.wantMovies) {
+				t.Errorf("expected movies %v to be searched, got %v", tt.wantMovies, calls)
+			}
+			if calls := clients[sonarr.URL].getTriggerCalls(); len(calls) != 1 || !slices.Equal(calls[0], tt.wantEpisodes) {
+				t.Errorf("expected episodes %v to be searched, got %v", tt.wantEpisodes, calls)
+			}
+			if results.RequestedTriggered != tt.wantRequested {
+				t.Errorf("expected %d requested items searched, got %d", tt.wantRequested, results.RequestedTriggered)
+			}
+			for id, want := range tt.wantRequestedBy {
+				if got, ok := log.requestedBy[id]; !ok || got != want {
+					t.Errorf("expected item %d to be logged as requested by %q, got %q", id, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestedItems(t *testing.T) {
+	requested := newRequestedItems([]api.MediaRequest{
+		{Media: api.RequestedMedia{MediaType: "tv", TvdbID: 500}, Seasons: []api.RequestedSeason{{SeasonNumber: 1}}, RequestedBy: api.RequestUser{DisplayName: "Alice"}},
+		{Media: api.RequestedMedia{MediaType: "tv", TvdbID: 500}, Seasons: []api.RequestedSeason{{SeasonNumber: 3}}, RequestedBy: api.RequestUser{DisplayName: "Bob"}},
+		{Media: api.RequestedMedia{MediaType: "tv", TvdbID: 600}},
+		{Media: api.RequestedMedia{MediaType: "movie", TmdbID: 0}},
+	})
+
+	tests := []struct {
+		item api.MediaItem
+		want string
+	}{
+		{api.MediaItem{Type: "episode", SeriesTvdbID: 500, SeasonNumber: 1}, "Alice"},
+		{api.MediaItem{Type: "episode", SeriesTvdbID: 500, SeasonNumber: 2}, ""},
+		{api.MediaItem{Type: "episode", SeriesTvdbID: 500, SeasonNumber: 3}, "Alice"},
+		{api.MediaItem{Type: "episode", SeriesTvdbID: 600, SeasonNumber: 7}, "unknown user"},
+		{api.MediaItem{Type: "movie"}, ""},
+	}
+	for _, tt := range tests {
+		if got := requested.requestedBy(tt.item); got != tt.want {
+			t.Errorf("requestedBy(%+v) = %q, want %q", tt.item, got, tt.want)
+		}
+	}
+}
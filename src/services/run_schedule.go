@@ -0,0 +1,191 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// maxScheduleSearch bounds how far ahead a schedule looks for a run inside the allowed windows.
+const maxScheduleSearch = 7 * 24 * time.Hour
+
+// TimeWindow is a daily time-of-day range in local time. Windows may wrap past midnight.
+type TimeWindow struct {
+	Start int // Minutes after midnight (inclusive)
+	End   int // Minutes after midnight (exclusive)
+}
+
+// ParseTimeWindows parses a comma-separated list of "HH:MM-HH:MM" ranges. An empty string yields no windows.
+func ParseTimeWindows(spec string) ([]TimeWindow, error) {
+	var windows []TimeWindow
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		bounds := strings.Split(part, "-")
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("invalid time window %q: expected HH:MM-HH:MM", part)
+		}
+
+		start, err := parseClock(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid time window %q: %w", part, err)
+		}
+		end, err := parseClock(bounds[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid time window %q: %w", part, err)
+		}
+		if start == end {
+			return nil, fmt.Errorf("invalid time window %q: start and end are the same", part)
+		}
+
+		windows = append(windows, TimeWindow{Start: start, End: end})
+	}
+
+	return windows, nil
+}
+
+// parseClock converts "HH:MM" into minutes after midnight.
+func parseClock(s string) (int, error) {
+	hours, minutes, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	h, err := strconv.Atoi(hours)
+	if err != nil || h < 0 || h > 23 {
+		return 0, fmt.Errorf("invalid hour in %q", s)
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 || m > 59 {
+		return 0, fmt.Errorf("invalid minute in %q", s)
+	}
+	return h*60 + m, nil
+}
+
+// Contains reports whether the time of day falls inside the window.
+func (w TimeWindow) Contains(t time.Time) bool {
+	m := t.Hour()*60 + t.Minute()
+	if w.Start < w.End {
+		return m >= w.Start && m < w.End
+	}
+	return m >= w.Start || m < w.End
+}
+
+// RunSchedule decides when automation cycles run: either a fixed interval or a cron
+// expression, restricted to the allowed windows and excluding blackout periods.
+type RunSchedule struct {
+	interval  time.Duration
+	cron      *CronExpression
+	windows   []TimeWindow
+	blackouts []TimeWindow
+}
+
+// NewRunSchedule builds a schedule from configuration. A non-empty cron expression takes
+// precedence over the interval.
+func NewRunSchedule(config database.ScheduleConfig) (*RunSchedule, error) {
+	schedule := &RunSchedule{
+		interval: time.Duration(config.IntervalHours) * time.Hour,
+	}
+
+	if strings.TrimSpace(config.Cron) != "" {
+		cron, err := ParseCron(config.Cron)
+		if err != nil {
+			return nil, err
+		}
+		schedule.cron = cron
+	} else if config.IntervalHours < 1 {
+		return nil, fmt.Errorf("interval must be at least 1 hour")
+	}
+
+	var err error
+	if schedule.windows, err = ParseTimeWindows(config.Windows); err != nil {
+		return nil, err
+	}
+	if schedule.blackouts, err = ParseTimeWindows(config.Blackouts); err != nil {
+		return nil, err
+	}
+
+	if schedule.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("schedule never runs: no scheduled time falls inside the allowed windows")
+	}
+
+	return schedule, nil
+}
+
+// ValidateScheduleConfig reports whether the schedule settings can produce runs.
+func ValidateScheduleConfig(config database.ScheduleConfig) error {
+	_, err := NewRunSchedule(config)
+	return err
+}
+
+// Allowed reports whether a run may start at the given time.
+func (r *RunSchedule) Allowed(t time.Time) bool {
+	for _, b := range r.blackouts {
+		if b.Contains(t) {
+			return false
+		}
+	}
+	if len(r.windows) == 0 {
+		return true
+	}
+	for _, w := range r.windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the next run time after the given time, or the zero time if none exists.
+func (r *RunSchedule) Next(after time.Time) time.Time {
+	if r.cron != nil {
+		limit := after.Add(maxScheduleSearch)
+		for t := r.cron.Next(after); !t.IsZero() && t.Before(limit); t = r.cron.Next(t) {
+			if r.Allowed(t) {
+				return t
+			}
+		}
+		return time.Time{}
+	}
+
+	// Interval runs that land outside the windows wait for the next allowed minute
+	return r.EarliestAllowed(after.Add(r.interval))
+}
+
+// EarliestAllowed returns the given time if a run may start then, otherwise the next
+// allowed minute. It returns the zero time if nothing is allowed within a week.
+func (r *RunSchedule) EarliestAllowed(from time.Time) time.Time {
+	if r.Allowed(from) {
+		return from
+	}
+	limit := from.Add(maxScheduleSearch)
+	for t := from.Truncate(time.Minute).Add(time.Minute); t.Before(limit); t = t.Add(time.Minute) {
+		if r.Allowed(t) {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Upcoming returns up to n run times, starting with the first run after the given time.
+func (r *RunSchedule) Upcoming(after time.Time, n int) []time.Time {
+	runs := make([]time.Time, 0, n)
+	for t := r.Next(after); !t.IsZero() && len(runs) < n; t = r.Next(t) {
+		runs = append(runs, t)
+	}
+	return runs
+}
+
+// Cron returns the cron expression driving the schedule, or "" for interval schedules.
+func (r *RunSchedule) Cron() string {
+	if r.cron == nil {
+		return ""
+	}
+	return r.cron.String()
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+func TestParseTimeWindows(t *testing.T) {
+	windows, err := ParseTimeWindows("01:00-06:00, 22:30-02:00")
+	if err != nil {
+		t.Fatalf("ParseTimeWindows failed: %v", err)
+	}
+	if len(windows) != 2 {
+		t.Fatalf("expected 2 windows, got %d", len(windows))
+	}
+
+	overnight := windows[1]
+	day := time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC)
+	if !overnight.Contains(day.Add(23 * time.Hour)) {
+		t.Error("expected 23:00 inside 22:30-02:00")
+	}
+	if !overnight.Contains(day.Add(90 * time.Minute)) {
+		t.Error("expected 01:30 inside 22:30-02:00")
+	}
+	if overnight.Contains(day.Add(2 * time.Hour)) {
+		t.Error("expected 02:00 outside 22:30-02:00 (end is exclusive)")
+	}
+
+	for _, spec := range []string{"01:00", "25:00-02:00", "01:00-01:00", "1-2"} {
+		if _, err := ParseTimeWindows(spec); err == nil {
+			t.Errorf("ParseTimeWindows(%q) expected error", spec)
+		}
+	}
+}
+
+func TestRunSchedule_Next(t *testing.T) {
+	base := time.Date(2024, time.January, 10, 9, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		config   database.ScheduleConfig
+		expected time.Time
+	}{
+		{
+			name:     "interval",
+			config:   database.ScheduleConfig{IntervalHours: 6},
+			expected: base.Add(6 * time.Hour),
+		},
+		{
+			name:     "interval waits for window",
+			config:   database.ScheduleConfig{IntervalHours: 1, Windows: "22:00-06:00"},
+			expected: time.Date(2024, time.January, 10, 22, 0, 0, 0, time.Local),
+		},
+		{
+			name:     "cron skips blackout",
+			config:   database.ScheduleConfig{Cron: "0 2,14 * * *", Blackouts: "12:00-18:00"},
+			expected: time.Date(2024, time.January, 11, 2, 0, 0, 0, time.Local),
+		},
+		{
+			name:     "cron overrides interval",
+			config:   database.ScheduleConfig{IntervalHours: 1, Cron: "0 14 * * *"},
+			expected: time.Date(2024, time.January, 10, 14, 0, 0, 0, time.Local),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewRunSchedule(tt.config)
+			if err != nil {
+				t.Fatalf("NewRunSchedule failed: %v", err)
+			}
+			if got := schedule.Next(base); !got.Equal(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRunSchedule_Upcoming(t *testing.T) {
+	schedule, err := NewRunSchedule(database.ScheduleConfig{Cron: "0 2,14 * * *"})
+	if err != nil {
+		t.Fatalf("NewRunSchedule failed: %v", err)
+	}
+
+	base := time.Date(2024, time.January, 10, 9, 30, 0, 0, time.Local)
+	runs := schedule.Upcoming(base, 3)
+	expected := []time.Time{
+		time.Date(2024, time.January, 10, 14, 0, 0, 0, time.Local),
+		time.Date(2024, time.January, 11, 2, 0, 0, 0, time.Local),
+		time.Date(2024, time.January, 11, 14, 0, 0, 0, time.Local),
+	}
+	if len(runs) != len(expected) {
+		t.Fatalf("expected %d runs, got %d", len(expected), len(runs))
+	}
+	for i := range expected {
+		if !runs[i].Equal(expected[i]) {
+			t.Errorf("run %d: expected %v, got %v", i, expected[i], runs[i])
+		}
+	}
+}
+
+func TestNewRunSchedule_Invalid(t *testing.T) {
+	tests := []database.ScheduleConfig{
+		{IntervalHours: 0},
+		{Cron: "not a cron"},
+		{IntervalHours: 6, Windows: "bad"},
+		{Cron: "0 14 * * *", Windows: "01:00-06:00"}, // Never inside the window
+	}
+
+	for _, config := range tests {
+		if _, err := NewRunSchedule(config); err == nil {
+			t.Errorf("NewRunSchedule(%+v) expected error", config)
+		}
+	}
+}
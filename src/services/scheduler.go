@@ -16,15 +16,21 @@ type DebugLogger interface {
 	Error(msg string, keyvals ...interface{})
 }
 
-// Scheduler runs a callback function at a given interval.
+// upcomingRunCount is how many future runs GetStatus reports.
+const upcomingRunCount = 5
+
+// Scheduler runs a callback function at a given interval or on a cron schedule.
 type Scheduler struct {
 	mu              sync.Mutex
 	running         bool
 	cycleActive     bool
+	cycleDone       chan struct{} // Closed when the active cycle finishes so queued work can start
 	timer           *time.Timer
 	stopCh          chan struct{}
 	callback        func(ctx context.Context, isManual bool) error
 	intervalHrs     int
+	schedule        *RunSchedule           // Optional cron/window schedule; nil runs every intervalHrs
+	catchUp         database.CatchUpPolicy // What to do when the persisted next run was missed
 	nextRun         time.Time
 	lastRun         time.Time
 	db              *database.DB // Add DB for GetSchedulerStatusFunc
@@ -48,6 +54,12 @@ func (s *Scheduler) WithLogger(logger DebugLogger) *Scheduler {
 	return s
 }
 
+// WithSchedule makes the scheduler follow a cron expression and time windows instead of a plain interval.
+func (s *Scheduler) WithSchedule(schedule *RunSchedule) *Scheduler {
+	s.schedule = schedule
+	return s
+}
+
 // Start starts the scheduler.
 func (s *Scheduler) Start(ctx context.Context) error {
 	s.mu.Lock()
@@ -58,6 +70,7 @@ func (s *Scheduler) Start(ctx context.Context) error {
 	}
 
 	s.running = true
+	s.restoreState()
 	s.scheduleNextRun()
 
 	go s.run(ctx)
@@ -88,18 +101,66 @@ func (s *Scheduler) TriggerManual(ctx context.Context) error {
 		s.mu.Unlock()
 		return fmt.Errorf("cycle already active")
 	}
-	s.cycleActive = true
+	s.beginCycle()
 	s.mu.Unlock()
 
-	defer func() {
-		s.mu.Lock()
-		s.cycleActive = false
-		s.mu.Unlock()
-	}()
+	defer s.releaseCycle()
 
 	return s.callback(ctx, true)
 }
 
+// RunExclusive runs fn once no automation cycle is active, waiting for the current one to
+// finish rather than rejecting the request. Scheduled and manual cycles cannot start while
+// fn is running. It returns ctx.Err() if the context ends before fn gets to run.
+func (s *Scheduler) RunExclusive(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := s.acquireCycle(ctx); err != nil {
+		return err
+	}
+	defer s.releaseCycle()
+
+	return fn(ctx)
+}
+
+// acquireCycle waits until no cycle is active and then marks one as active.
+func (s *Scheduler) acquireCycle(ctx context.Context) error {
+	s.mu.Lock()
+	for s.cycleActive {
+		done := s.cycleDone
+		s.mu.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		s.mu.Lock()
+	}
+	s.beginCycle()
+	s.mu.Unlock()
+	return nil
+}
+
+// releaseCycle marks the active cycle as finished and wakes anything queued behind it.
+func (s *Scheduler) releaseCycle() {
+	s.mu.Lock()
+	s.endCycle()
+	s.mu.Unlock()
+}
+
+// beginCycle marks a cycle as active. Callers must hold s.mu.
+func (s *Scheduler) beginCycle() {
+	s.cycleActive = true
+	s.cycleDone = make(chan struct{})
+}
+
+// endCycle clears the active cycle and wakes waiters. Callers must hold s.mu.
+func (s *Scheduler) endCycle() {
+	s.cycleActive = false
+	if s.cycleDone != nil {
+		close(s.cycleDone)
+		s.cycleDone = nil
+	}
+}
+
 // GetSchedulerStatusFunc is a variable that holds the function to retrieve the current status of the scheduler.
 // It can be overridden in tests to inject mock implementations.
 var GetSchedulerStatusFunc = func(db *database.DB) SchedulerStatus {
@@ -107,13 +168,16 @@ var GetSchedulerStatusFunc = func(db *database.DB) SchedulerStatus {
 	// we cannot determine if the scheduler is actually running.
 	// The scheduler is only running when 'janitarr start' or 'janitarr dev' is active.
 	// This function returns default values indicating the scheduler is NOT running.
+	// Run times come from what the scheduler last persisted.
 	config := db.GetAppConfig()
+	lastRun, nextRun := db.GetSchedulerRunTimes()
 	return SchedulerStatus{
 		IsRunning:     false, // Cannot determine actual running state without the scheduler instance
 		IsCycleActive: false, // Cannot determine from config alone
-		NextRun:       nil,
-		LastRun:       nil,
+		NextRun:       nextRun,
+		LastRun:       lastRun,
 		IntervalHours: config.Schedule.IntervalHours,
+		Cron:          config.Schedule.Cron,
 	}
 }
 
@@ -124,20 +188,31 @@ func (s *Scheduler) GetStatus() SchedulerStatus {
 
 	var nextRun *time.Time
 	var lastRun *time.Time
+	var upcoming []time.Time
 
 	if !s.nextRun.IsZero() {
 		nextRun = &s.nextRun
+		if s.running {
+			upcoming = append([]time.Time{s.nextRun}, s.upcomingAfter(s.nextRun, upcomingRunCount-1)...)
+		}
 	}
 	if !s.lastRun.IsZero() {
 		lastRun = &s.lastRun
 	}
 
+	var cron string
+	if s.schedule != nil {
+		cron = s.schedule.Cron()
+	}
+
 	return SchedulerStatus{
 		IsRunning:     s.running,
 		IsCycleActive: s.cycleActive,
 		NextRun:       nextRun,
 		LastRun:       lastRun,
 		IntervalHours: s.intervalHrs,
+		Cron:          cron,
+		UpcomingRuns:  upcoming,
 	}
 }
 
@@ -173,9 +248,10 @@ func (s *Scheduler) run(ctx context.Context) {
 				s.logger.Debug("Scheduler woke up", "reason", "timer")
 			}
 
-			s.mu.Lock()
-			s.cycleActive = true
-			s.mu.Unlock()
+			// Wait for any manual or webhook-triggered work to finish first
+			if err := s.acquireCycle(ctx); err != nil {
+				return
+			}
 
 			// Run the main callback (automation cycle)
 			_ = s.callback(ctx, false)
@@ -184,8 +260,9 @@ func (s *Scheduler) run(ctx context.Context) {
 			s.runDailyCleanup(ctx)
 
 			s.mu.Lock()
-			s.cycleActive = false
+			s.endCycle()
 			s.lastRun = time.Now()
+			s.persistLastRun()
 			s.scheduleNextRun()
 			s.mu.Unlock()
 
@@ -214,11 +291,85 @@ func (s *Scheduler) runDailyCleanup(ctx context.Context) {
 	}()
 }
 
+// nextRunAfter returns when the next cycle should start after the given time.
+func (s *Scheduler) nextRunAfter(after time.Time) time.Time {
+	if s.schedule != nil {
+		if next := s.schedule.Next(after); !next.IsZero() {
+			return next
+		}
+	}
+	return after.Add(time.Duration(s.intervalHrs) * time.Hour)
+}
+
+// upcomingAfter lists the n runs that follow the given time.
+func (s *Scheduler) upcomingAfter(after time.Time, n int) []time.Time {
+	runs := make([]time.Time, 0, n)
+	for t := after; len(runs) < n; {
+		t = s.nextRunAfter(t)
+		runs = append(runs, t)
+	}
+	return runs
+}
+
+// restoreState loads the persisted last run and catch-up policy so restarts keep the schedule.
+func (s *Scheduler) restoreState() {
+	if s.db == nil {
+		return
+	}
+
+	s.catchUp = s.db.GetAppConfig().Schedule.CatchUp
+	if lastRun, _ := s.db.GetSchedulerRunTimes(); lastRun != nil {
+		s.lastRun = *lastRun
+	}
+}
+
+// persistLastRun saves the last run time so it survives restarts.
+func (s *Scheduler) persistLastRun() {
+	if s.db == nil {
+		return
+	}
+	if err := s.db.SetSchedulerLastRun(s.lastRun); err != nil && s.logger != nil {
+		s.logger.Error("Failed to persist scheduler last run", "error", err)
+	}
+}
+
 func (s *Scheduler) scheduleNextRun() {
-	s.nextRun = time.Now().Add(time.Duration(s.intervalHrs) * time.Hour)
+	now := time.Now()
+	if s.lastRun.IsZero() {
+		s.nextRun = s.nextRunAfter(now)
+	} else {
+		s.nextRun = s.nextRunAfter(s.lastRun)
+		if s.nextRun.Before(now) {
+			// The run was missed while Janitarr was stopped
+			if s.catchUp == database.CatchUpSkip {
+				s.nextRun = s.nextRunAfter(now)
+			} else {
+				s.nextRun = s.earliestAllowed(now)
+			}
+			if s.logger != nil {
+				s.logger.Info("Scheduler run overdue", "lastRun", s.lastRun.Format(time.RFC3339), "catchUp", s.catchUp, "nextRun", s.nextRun.Format(time.RFC3339))
+			}
+		}
+	}
 	s.timer = time.NewTimer(time.Until(s.nextRun))
 
+	if s.db != nil {
+		if err := s.db.SetSchedulerNextRun(s.nextRun); err != nil && s.logger != nil {
+			s.logger.Error("Failed to persist scheduler next run", "error", err)
+		}
+	}
+
 	if s.logger != nil {
 		s.logger.Debug("Scheduler sleeping", "until", s.nextRun.Format(time.RFC3339))
 	}
 }
+
+// earliestAllowed returns the first time from now that the schedule's windows allow a run.
+func (s *Scheduler) earliestAllowed(from time.Time) time.Time {
+	if s.schedule != nil {
+		if t := s.schedule.EarliestAllowed(from); !t.IsZero() {
+			return t
+		}
+	}
+	return from
+}
@@ -6,6 +6,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
 )
 
 func TestScheduler_StartStop(t *testing.T) {
@@ -73,6 +75,97 @@ func TestScheduler_IntervalConfig(t *testing.T) {
 	}
 }
 
+func TestScheduler_WithSchedule(t *testing.T) {
+	cb := func(ctx context.Context, isManual bool) error {
+		return nil
+	}
+
+	schedule, err := NewRunSchedule(database.ScheduleConfig{Cron: "0 2,14 * * *"})
+	if err != nil {
+		t.Fatalf("NewRunSchedule failed: %v", err)
+	}
+
+	scheduler := NewScheduler(nil, 6, cb).WithSchedule(schedule)
+	_ = scheduler.Start(context.Background())
+	defer scheduler.Stop()
+
+	status := scheduler.GetStatus()
+	if status.Cron != "0 2,14 * * *" {
+		t.Errorf("expected cron in status, got %q", status.Cron)
+	}
+	if status.NextRun == nil || (status.NextRun.Hour() != 2 && status.NextRun.Hour() != 14) || status.NextRun.Minute() != 0 {
+		t.Fatalf("expected next run at 02:00 or 14:00, got %v", status.NextRun)
+	}
+	if len(status.UpcomingRuns) != upcomingRunCount {
+		t.Fatalf("expected %d upcoming runs, got %d", upcomingRunCount, len(status.UpcomingRuns))
+	}
+	if !status.UpcomingRuns[0].Equal(*status.NextRun) {
+		t.Errorf("expected first upcoming run to be next run")
+	}
+	for i := 1; i < len(status.UpcomingRuns); i++ {
+		if gap := status.UpcomingRuns[i].Sub(status.UpcomingRuns[i-1]); gap != 12*time.Hour {
+			t.Errorf("expected 12h between runs, got %v", gap)
+		}
+	}
+}
+
+func TestScheduler_RestoresLastRun(t *testing.T) {
+	// Hold any cycle that fires so the restored run times are not overwritten mid-test
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	cb := func(ctx context.Context, isManual bool) error {
+		<-release
+		return nil
+	}
+
+	tests := []struct {
+		name        string
+		lastRunAgo  time.Duration
+		catchUp     database.CatchUpPolicy
+		expectUntil time.Duration // Expected time until the next run
+	}{
+		{"not yet due", 2 * time.Hour, database.CatchUpRun, 4 * time.Hour},
+		{"overdue runs now", 10 * time.Hour, database.CatchUpRun, 0},
+		{"overdue skips", 10 * time.Hour, database.CatchUpSkip, 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testDB(t)
+			config := db.GetAppConfig()
+			config.Schedule.CatchUp = tt.catchUp
+			if err := db.SetAppConfig(config); err != nil {
+				t.Fatalf("SetAppConfig failed: %v", err)
+			}
+			lastRun := time.Now().Add(-tt.lastRunAgo)
+			if err := db.SetSchedulerLastRun(lastRun); err != nil {
+				t.Fatalf("SetSchedulerLastRun failed: %v", err)
+			}
+
+			scheduler := NewScheduler(db, 6, cb)
+			if err := scheduler.Start(context.Background()); err != nil {
+				t.Fatalf("Start failed: %v", err)
+			}
+			defer scheduler.Stop()
+
+			status := scheduler.GetStatus()
+			if status.LastRun == nil || status.LastRun.Unix() != lastRun.Unix() {
+				t.Errorf("expected restored last run %v, got %v", lastRun, status.LastRun)
+			}
+			until := time.Until(*status.NextRun)
+			if until < tt.expectUntil-time.Minute || until > tt.expectUntil+time.Minute {
+				t.Errorf("expected next run in about %v, got %v", tt.expectUntil, until)
+			}
+
+			// The planned run is persisted for `janitarr status`
+			_, persisted := db.GetSchedulerRunTimes()
+			if persisted == nil || persisted.Unix() != status.NextRun.Unix() {
+				t.Errorf("expected persisted next run %v, got %v", status.NextRun, persisted)
+			}
+		})
+	}
+}
+
 func TestScheduler_PreventsConcurrent(t *testing.T) {
 	startChan := make(chan struct{})
 	finishChan := make(chan struct{})
@@ -201,3 +294,46 @@ func TestScheduler_GracefulShutdown(t *testing.T) {
 		t.Error("callback did not execute during shutdown")
 	}
 }
+
+func TestScheduler_RunExclusive(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	cb := func(ctx context.Context, isManual bool) error {
+		close(started)
+		<-release
+		return nil
+	}
+
+	scheduler := NewScheduler(nil, 1, cb)
+	go func() { _ = scheduler.TriggerManual(context.Background()) }()
+	<-started
+
+	// A context that ends while waiting gives up without running
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := scheduler.RunExclusive(ctx, func(ctx context.Context) error {
+		t.Error("fn should not run while a cycle is active")
+		return nil
+	}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+
+	// Otherwise the work is queued until the cycle finishes
+	ran := make(chan struct{})
+	go func() {
+		_ = scheduler.RunExclusive(context.Background(), func(ctx context.Context) error {
+			if !scheduler.IsCycleActive() {
+				t.Error("expected cycle to be marked active while fn runs")
+			}
+			close(ran)
+			return nil
+		})
+	}()
+
+	close(release)
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("queued work did not run after the cycle finished")
+	}
+}
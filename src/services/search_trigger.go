@@ -9,6 +9,9 @@ import (
 	"github.com/edrobertsrayne/janitarr/src/api"
 	"github.com/edrobertsrayne/janitarr/src/database"
 	"github.com/edrobertsrayne/janitarr/src/logger"
+	"github.com/edrobertsrayne/janitarr/src/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // SearchTriggerAPIClient is the interface for API clients used by the SearchTrigger.
@@ -16,7 +19,7 @@ type SearchTriggerAPIClient interface {
 	TestConnection(ctx context.Context) (*api.SystemStatus, error)
 	GetAllMissing(ctx context.Context) ([]api.MediaItem, error)
 	GetAllCutoffUnmet(ctx context.Context) ([]api.MediaItem, error)
-	TriggerSearch(ctx context.Context, ids []int) error
+	TriggerSearch(ctx context.Context, ids []int) (*api.CommandResponse, error)
 }
 
 // SearchTriggerAPIClientFactory creates API clients for search triggering.
@@ -26,16 +29,35 @@ type SearchTriggerAPIClientFactory func(url, apiKey, serverType string) SearchTr
 // Note: This factory doesn't have access to logger, so API request logging
 // is attached separately in triggerForServer if needed.
 func defaultSearchTriggerAPIClientFactory(url, apiKey, serverType string) SearchTriggerAPIClient {
-	if serverType == "sonarr" {
+	switch serverType {
+	case "sonarr":
 		return api.NewSonarrClient(url, apiKey)
+	case "lidarr":
+		return api.NewLidarrClient(url, apiKey)
+	case "readarr":
+		return api.NewReadarrClient(url, apiKey)
+	default:
+		return api.NewRadarrClient(url, apiKey)
 	}
-	return api.NewRadarrClient(url, apiKey)
 }
 
 // SearchTriggerLogger is the interface for logging search operations.
 type SearchTriggerLogger interface {
-	LogMovieSearch(serverName, serverType, title string, year int, qualityProfile, category string) *logger.LogEntry
-	LogEpisodeSearch(serverName, serverType, seriesTitle, episodeTitle string, season, episode int, qualityProfile, category string) *logger.LogEntry
+	LogMovieSearch(serverName, serverType string, movieID int, title string, year int, qualityProfile, category, requestedBy string) *logger.LogEntry
+	LogEpisodeSearch(serverName, serverType string, episodeID, seriesID int, seriesTitle, episodeTitle string, season, episode int, qualityProfile, category, requestedBy string) *logger.LogEntry
+	LogAlbumSearch(serverName, serverType string, albumID int, artistName, title string, year int, qualityProfile, category string) *logger.LogEntry
+	LogBookSearch(serverName, serverType string, bookID int, authorName, title string, year int, qualityProfile, category string) *logger.LogEntry
+	LogSeasonSearch(serverName, serverType, command string, seriesID int, seriesTitle string, season, episodes int, qualityProfile, category, requestedBy string) *logger.LogEntry
+}
+
+// warnLogger is implemented by loggers that can report configuration problems.
+type warnLogger interface {
+	Warn(msg string, keyvals ...interface{})
+}
+
+// lockoutLogger is implemented by loggers that record rate limit lockouts for notifications.
+type lockoutLogger interface {
+	LogRateLimitLockout(serverName, serverType string) *logger.LogEntry
 }
 
 // SearchTrigger triggers searches for missing and cutoff content.
@@ -43,6 +65,8 @@ type SearchTrigger struct {
 	db         *database.DB
 	apiFactory SearchTriggerAPIClientFactory
 	logger     SearchTriggerLogger
+	metrics    MetricsRecorder
+	breaker    *CircuitBreaker
 }
 
 // NewSearchTrigger creates a new SearchTrigger with the given database.
@@ -51,6 +75,8 @@ func NewSearchTrigger(db *database.DB, logger SearchTriggerLogger) *SearchTrigge
 		db:         db,
 		apiFactory: defaultSearchTriggerAPIClientFactory,
 		logger:     logger,
+		metrics:    noopMetrics{},
+		breaker:    NewCircuitBreaker(db),
 	}
 }
 
@@ -61,9 +87,17 @@ func NewSearchTriggerWithFactory(db *database.DB, factory SearchTriggerAPIClient
 		db:         db,
 		apiFactory: factory,
 		logger:     logger,
+		metrics:    noopMetrics{},
+		breaker:    NewCircuitBreaker(db),
 	}
 }
 
+// WithMetrics records triggered searches and rate-limit lockouts to the given recorder.
+func (s *SearchTrigger) WithMetrics(recorder MetricsRecorder) *SearchTrigger {
+	s.metrics = recorder
+	return s
+}
+
 // serverItemAllocation tracks items to be triggered for a server.
 type serverItemAllocation struct {
 	serverID       string
@@ -75,6 +109,9 @@ type serverItemAllocation struct {
 	cutoff         []int
 	missingItems   map[int]api.MediaItem // Metadata for missing items
 	cutoffItems    map[int]api.MediaItem // Metadata for cutoff items
+	packs          map[int]searchPack    // Season and series searches, keyed by the missing item standing in for them
+	requested      map[int]string        // Who requested each item in Overseerr or Jellyseerr
+	limits         database.ServerLimits // Per-server allocation overrides
 	rateLimitCount int                   // Consecutive 429 errors
 }
 
@@ -93,11 +130,165 @@ func (s *SearchTrigger) TriggerSearches(ctx context.Context, detectionResults *D
 		serverMap[servers[i].ID] = &servers[i]
 	}
 
+	// Leave titles wanted on both servers of a pair to the server whose turn it is
+	candidates, deduped := s.applyPairs(detectionResults)
+
+	// Drop items that have been searched too often without a grab, for now or for good, and items
+	// searched recently so the rest of the backlog gets a turn. Items named explicitly are searched anyway.
+	config := s.db.GetAppConfig()
+	givenUp, skipped := 0, 0
+	if !candidates.Targeted {
+		candidates, givenUp = s.applyGiveUp(ctx, candidates, serverMap, config.Search, dryRun)
+		candidates, skipped = s.applyCooldown(candidates, config.Search.CooldownHours)
+	}
+
+	// Drop items that are already downloading, and servers with too many downloads queued
+	candidates, queueSkipped, queueFull := s.applyQueue(ctx, candidates, serverMap, config.Search.MaxQueueSize)
+
+	// Order each server's items so allocation picks them according to the configured strategy
+	strategy, err := NewSelectionStrategy(config.Search.Strategy)
+	if err != nil {
+		strategy = oldestSearchedFirst{}
+		if warn, ok := s.logger.(warnLogger); ok {
+			warn.Warn("Unknown selection strategy, using the default", "strategy", config.Search.Strategy, "default", strategy.Mode())
+		}
+	}
+	candidates = s.applyStrategy(candidates, strategy)
+
+	// Move items requested in Overseerr or Jellyseerr to the front of each server's list
+	candidates, requested := s.applyRequests(candidates, s.outstandingRequests(ctx, config.Requests))
+
+	// Search most-missing seasons as a whole, so each season takes one slot
+	candidates, packs := s.applySeasonPacks(ctx, candidates, serverMap, config.Search.SeasonPackPercent)
+
 	// Allocate items to servers respecting limits and using round-robin distribution
-	allocations := s.allocateItems(detectionResults, serverMap, limits)
+	allocations := s.allocateItems(candidates, serverMap, limits)
+	for i := range allocations {
+		allocations[i].packs = packs[allocations[i].serverID]
+		allocations[i].requested = requested[allocations[i].serverID]
+	}
 
 	// Execute triggers (or simulate in dry-run mode)
-	return s.executeAllocations(ctx, allocations, dryRun)
+	results, err := s.executeAllocations(ctx, allocations, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	results.Budgets = searchBudgets(results.Results, limits)
+	results.CooldownSkipped = skipped
+	results.QueueSkipped = queueSkipped
+	results.GiveUpSkipped = givenUp
+	results.QueueFullServers = queueFull
+	results.Deduplicated = deduped
+	results.RequestedTriggered = requestedSearches(results.Results, requested)
+	for i := range results.Results {
+		results.Results[i].Strategy = strategy.Mode()
+	}
+
+	return results, nil
+}
+
+// searchBudgets reports how many searches were triggered against each search limit.
+// Failed searches don't count, as the server didn't run them.
+func searchBudgets(results []TriggerResult, limits database.SearchLimits) []SearchBudget {
+	used := make(map[string]int)
+	for _, result := range results {
+		if result.Success {
+			used[database.LimitKey(database.ServerType(result.ServerType), database.SearchCategory(result.Category))] += result.SearchCount()
+		}
+	}
+
+	budgets := make([]SearchBudget, 0, len(database.SearchLimitKeys))
+	for _, key := range database.SearchLimitKeys {
+		budgets = append(budgets, SearchBudget{Limit: key, Used: used[key], Available: limits.Get(key)})
+	}
+	return budgets
+}
+
+// applyCooldown returns a copy of the detection results without items searched within
+// the cooldown window, along with the number of items skipped.
+// If the search history cannot be read, the server's items are left unfiltered.
+func (s *SearchTrigger) applyCooldown(detectionResults *DetectionResults, cooldownHours int) (*DetectionResults, int) {
+	if cooldownHours <= 0 {
+		return detectionResults, 0
+	}
+
+	since := time.Now().Add(-time.Duration(cooldownHours) * time.Hour)
+	filtered := *detectionResults
+	filtered.Results = make([]DetectionResult, len(detectionResults.Results))
+	skipped := 0
+
+	for i, result := range detectionResults.Results {
+		filtered.Results[i] = result
+		if result.Error != "" {
+			continue
+		}
+
+		var removed int
+		filtered.Results[i].Missing, removed = s.filterRecentlySearched(result.ServerID, database.SearchCategoryMissing, result.Missing, since)
+		skipped += removed
+		filtered.Results[i].Cutoff, removed = s.filterRecentlySearched(result.ServerID, database.SearchCategoryCutoff, result.Cutoff, since)
+		skipped += removed
+	}
+
+	return &filtered, skipped
+}
+
+// filterRecentlySearched removes item IDs searched since the given time, preserving order.
+func (s *SearchTrigger) filterRecentlySearched(serverID string, category database.SearchCategory, itemIDs []int, since time.Time) ([]int, int) {
+	if len(itemIDs) == 0 {
+		return itemIDs, 0
+	}
+
+	recent, err := s.db.GetRecentlySearched(serverID, category, since)
+	if err != nil || len(recent) == 0 {
+		return itemIDs, 0
+	}
+
+	eligible := make([]int, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		if !recent[id] {
+			eligible = append(eligible, id)
+		}
+	}
+
+	return eligible, len(itemIDs) - len(eligible)
+}
+
+// applyStrategy returns a copy of the detection results with each server's items
+// reordered by the selection strategy.
+func (s *SearchTrigger) applyStrategy(detectionResults *DetectionResults, strategy SelectionStrategy) *DetectionResults {
+	ordered := *detectionResults
+	ordered.Results = make([]DetectionResult, len(detectionResults.Results))
+
+	for i, result := range detectionResults.Results {
+		ordered.Results[i] = result
+		if result.Error != "" {
+			continue
+		}
+
+		ordered.Results[i].Missing = strategy.Order(s.selectionCandidates(result.ServerID, database.SearchCategoryMissing, result.Missing, result.MissingItems))
+		ordered.Results[i].Cutoff = strategy.Order(s.selectionCandidates(result.ServerID, database.SearchCategoryCutoff, result.Cutoff, result.CutoffItems))
+	}
+
+	return &ordered
+}
+
+// selectionCandidates gathers the metadata and search history a strategy needs for one server and category.
+// If the search history cannot be read, items are treated as never searched.
+func (s *SearchTrigger) selectionCandidates(serverID string, category database.SearchCategory, itemIDs []int, items map[int]api.MediaItem) SelectionCandidates {
+	candidates := SelectionCandidates{
+		ItemIDs: itemIDs,
+		Items:   items,
+	}
+	if len(itemIDs) == 0 {
+		return candidates
+	}
+
+	if history, err := s.db.GetSearchHistory(serverID, category); err == nil {
+		candidates.LastSearched = history
+	}
+
+	return candidates
 }
 
 // allocateItems distributes items across servers using proportional allocation, respecting limits.
@@ -126,19 +317,29 @@ func (s *SearchTrigger) allocateItems(detectionResults *DetectionResults, server
 			cutoff:       []int{},
 			missingItems: result.MissingItems,
 			cutoffItems:  result.CutoffItems,
+			limits:       server.Limits,
 		}
 	}
 
-	// Distribute missing items with proportional allocation
-	totalMissingLimit := limits.MissingMoviesLimit + limits.MissingEpisodesLimit
-	if totalMissingLimit > 0 {
-		s.distributeProportional(detectionResults, allocations, "missing", totalMissingLimit)
-	}
+	// Distribute each limit with proportional allocation across the servers it applies to
+	for _, category := range []database.SearchCategory{database.SearchCategoryMissing, database.SearchCategoryCutoff} {
+		pools := make(map[string]*DetectionResults)
+		for _, result := range detectionResults.Results {
+			if _, ok := allocations[result.ServerID]; !ok {
+				continue
+			}
+			key := database.LimitKey(database.ServerType(result.ServerType), category)
+			if pools[key] == nil {
+				pools[key] = &DetectionResults{}
+			}
+			pools[key].Results = append(pools[key].Results, result)
+		}
 
-	// Distribute cutoff items with proportional allocation
-	totalCutoffLimit := limits.CutoffMoviesLimit + limits.CutoffEpisodesLimit
-	if totalCutoffLimit > 0 {
-		s.distributeProportional(detectionResults, allocations, "cutoff", totalCutoffLimit)
+		for key, pool := range pools {
+			if limit := limits.Get(key); limit > 0 {
+				s.distributeProportional(pool, allocations, string(category), limit)
+			}
+		}
 	}
 
 	// Convert map to slice
@@ -151,23 +352,29 @@ func (s *SearchTrigger) allocateItems(detectionResults *DetectionResults, server
 }
 
 // distributeProportional distributes items across servers using largest remainder method.
-// Each server receives items proportional to its item count, with a minimum of 1 per server.
+// Each server receives items proportional to its item count multiplied by its weight, with a
+// minimum of 1 per server when the limit allows it; the total never exceeds the limit.
+// Per-server caps are enforced and any slots a capped server cannot use are handed to servers
+// that still have items.
 func (s *SearchTrigger) distributeProportional(detectionResults *DetectionResults, allocations map[string]*serverItemAllocation, category string, limit int) {
 	// Build server item map
 	type serverInfo struct {
 		serverID string
 		items    []int
+		weight   float64
+		capacity int // Most items this server may receive
 	}
 
 	var servers []serverInfo
-	totalItems := 0
+	totalWeighted := 0.0
 
 	for _, result := range detectionResults.Results {
 		// Skip servers with errors or not in allocations
 		if result.Error != "" {
 			continue
 		}
-		if _, ok := allocations[result.ServerID]; !ok {
+		alloc, ok := allocations[result.ServerID]
+		if !ok {
 			continue
 		}
 
@@ -178,16 +385,27 @@ func (s *SearchTrigger) distributeProportional(detectionResults *DetectionResult
 			items = result.Cutoff
 		}
 
-		if len(items) > 0 {
+		capacity := len(items)
+		if category == "cutoff" && alloc.limits.NeverCutoff {
+			capacity = 0
+		}
+		if maxItems := alloc.limits.MaxFor(database.SearchCategory(category)); maxItems != nil && *maxItems < capacity {
+			capacity = *maxItems
+		}
+
+		if capacity > 0 {
+			weight := alloc.limits.EffectiveWeight()
 			servers = append(servers, serverInfo{
 				serverID: result.ServerID,
 				items:    items,
+				weight:   weight,
+				capacity: capacity,
 			})
-			totalItems += len(items)
+			totalWeighted += weight * float64(len(items))
 		}
 	}
 
-	if len(servers) == 0 || totalItems == 0 || limit == 0 {
+	if len(servers) == 0 || totalWeighted == 0 || limit == 0 {
 		return
 	}
 
@@ -204,8 +422,8 @@ func (s *SearchTrigger) distributeProportional(detectionResults *DetectionResult
 	totalFloor := 0
 
 	for i, srv := range servers {
-		// Calculate proportional quota
-		quota := float64(limit) * float64(len(srv.items)) / float64(totalItems)
+		// Calculate weighted proportional quota
+		quota := float64(limit) * srv.weight * float64(len(srv.items)) / totalWeighted
 		floor := int(quota)
 
 		// Ensure minimum of 1 per server (unless limit is very small)
@@ -222,6 +440,18 @@ func (s *SearchTrigger) distributeProportional(detectionResults *DetectionResult
 		totalFloor += floor
 	}
 
+	// The minimum of 1 can push the total over the limit; take the excess from the largest allocations
+	for totalFloor > limit {
+		largest := 0
+		for i := range allocatedCounts {
+			if allocatedCounts[i].floor > allocatedCounts[largest].floor {
+				largest = i
+			}
+		}
+		allocatedCounts[largest].floor--
+		totalFloor--
+	}
+
 	// Distribute remainders to servers with largest fractional parts
 	remainingSlots := limit - totalFloor
 	if remainingSlots > 0 {
@@ -240,24 +470,41 @@ func (s *SearchTrigger) distributeProportional(detectionResults *DetectionResult
 		}
 	}
 
-	// Assign actual items to each server based on calculated allocation
+	// Clamp each server's allocation to its capacity
+	targets := make(map[string]int, len(servers))
+	assigned := 0
 	for _, srv := range servers {
-		// Find this server's allocation
-		var targetCount int
 		for _, alloc := range allocatedCounts {
 			if alloc.serverID == srv.serverID {
-				targetCount = alloc.floor
+				targets[srv.serverID] = min(alloc.floor, srv.capacity)
 				break
 			}
 		}
+		assigned += targets[srv.serverID]
+	}
 
-		// Don't allocate more items than the server has
-		if targetCount > len(srv.items) {
-			targetCount = len(srv.items)
+	// Hand slots that capped servers could not use to servers with spare capacity
+	for leftover := limit - assigned; leftover > 0; {
+		progressed := false
+		for _, srv := range servers {
+			if leftover == 0 {
+				break
+			}
+			if targets[srv.serverID] < srv.capacity {
+				targets[srv.serverID]++
+				leftover--
+				progressed = true
+			}
 		}
+		if !progressed {
+			break
+		}
+	}
 
+	// Assign actual items to each server based on calculated allocation
+	for _, srv := range servers {
 		// Take the first N items from this server
-		itemsToAllocate := srv.items[:targetCount]
+		itemsToAllocate := srv.items[:targets[srv.serverID]]
 
 		// Add to allocations
 		if category == "missing" {
@@ -295,23 +542,19 @@ func (s *SearchTrigger) executeAllocations(ctx context.Context, allocations []se
 		}
 		isFirstBatch = false
 
-		// Handle missing items
-		if len(alloc.missing) > 0 {
-			result := s.triggerForServer(ctx, *alloc, "missing", alloc.missing, dryRun)
-			results.Results = append(results.Results, result)
-
-			if result.Success {
-				results.SuccessCount++
-				results.MissingTriggered += len(result.ItemIDs)
-				// Reset rate limit counter on success
-				rateLimits[alloc.serverID] = 0
-			} else {
-				results.FailureCount++
-				// Check if it's a rate limit error
-				if result.Error != "" && (result.Error == "rate_limit" || isRateLimitError(result.Error)) {
-					rateLimits[alloc.serverID]++
-				}
+		// Handle missing items, searching episodes together and each season or series on its own
+		episodes, packs := alloc.missingBatches()
+		if len(episodes) > 0 {
+			s.recordResult(results, s.triggerForServer(ctx, *alloc, "missing", episodes, dryRun), alloc, rateLimits)
+		}
+		for i, pack := range packs {
+			if rateLimits[alloc.serverID] >= 3 {
+				break
+			}
+			if (i > 0 || len(episodes) > 0) && !dryRun {
+				time.Sleep(100 * time.Millisecond)
 			}
+			s.recordResult(results, s.triggerPack(ctx, *alloc, pack, dryRun), alloc, rateLimits)
 		}
 
 		// Handle cutoff items (only if not rate limited)
@@ -319,27 +562,63 @@ func (s *SearchTrigger) executeAllocations(ctx context.Context, allocations []se
 			if !isFirstBatch && !dryRun {
 				time.Sleep(100 * time.Millisecond)
 			}
-			result := s.triggerForServer(ctx, *alloc, "cutoff", alloc.cutoff, dryRun)
-			results.Results = append(results.Results, result)
-
-			if result.Success {
-				results.SuccessCount++
-				results.CutoffTriggered += len(result.ItemIDs)
-				// Reset rate limit counter on success
-				rateLimits[alloc.serverID] = 0
-			} else {
-				results.FailureCount++
-				// Check if it's a rate limit error
-				if result.Error != "" && (result.Error == "rate_limit" || isRateLimitError(result.Error)) {
-					rateLimits[alloc.serverID]++
-				}
-			}
+			s.recordResult(results, s.triggerForServer(ctx, *alloc, "cutoff", alloc.cutoff, dryRun), alloc, rateLimits)
 		}
 	}
 
 	return results, nil
 }
 
+// missingBatches splits a server's allocated missing items into the episodes searched together and
+// the season and series searches, in allocation order.
+func (alloc *serverItemAllocation) missingBatches() ([]int, []searchPack) {
+	var packs []searchPack
+	episodes := make([]int, 0, len(alloc.missing))
+	for _, id := range alloc.missing {
+		if pack, ok := alloc.packs[id]; ok {
+			packs = append(packs, pack)
+		} else {
+			episodes = append(episodes, id)
+		}
+	}
+	return episodes, packs
+}
+
+// recordResult adds a trigger result to the totals and tracks consecutive rate limits on its server.
+func (s *SearchTrigger) recordResult(results *TriggerResults, result TriggerResult, alloc *serverItemAllocation, rateLimits map[string]int) {
+	results.Results = append(results.Results, result)
+
+	if result.Success {
+		results.SuccessCount++
+		if result.Category == "missing" {
+			results.MissingTriggered += result.SearchCount()
+		} else {
+			results.CutoffTriggered += result.SearchCount()
+		}
+		// Reset rate limit counter on success
+		rateLimits[alloc.serverID] = 0
+		return
+	}
+
+	results.FailureCount++
+	// Check if it's a rate limit error
+	if result.Error != "" && (result.Error == "rate_limit" || isRateLimitError(result.Error)) {
+		rateLimits[alloc.serverID]++
+		if rateLimits[alloc.serverID] == 3 {
+			s.lockOut(alloc)
+		}
+	}
+}
+
+// lockOut records that a server was rate limited three times in a row and is skipped for the rest of the cycle.
+func (s *SearchTrigger) lockOut(alloc *serverItemAllocation) {
+	s.metrics.IncrementRateLimitLockouts(alloc.serverName, alloc.serverType)
+	s.breaker.RecordFailure(alloc.serverID, "rate limited on 3 consecutive searches")
+	if lockout, ok := s.logger.(lockoutLogger); ok {
+		lockout.LogRateLimitLockout(alloc.serverName, alloc.serverType)
+	}
+}
+
 // isRateLimitError checks if an error message indicates a rate limit error.
 func isRateLimitError(errMsg string) bool {
 	return errMsg != "" && (errMsg == "rate_limit" || contains(errMsg, "rate limited") || contains(errMsg, "retry after"))
@@ -368,6 +647,9 @@ func (s *SearchTrigger) triggerForServer(ctx context.Context, alloc serverItemAl
 		ItemIDs:    itemIDs,
 		Success:    true,
 	}
+	if alloc.serverType == string(database.ServerTypeSonarr) {
+		result.Command = api.CommandEpisodeSearch
+	}
 
 	// Get the item metadata map based on category
 	var itemMetadata map[int]api.MediaItem
@@ -385,10 +667,15 @@ func (s *SearchTrigger) triggerForServer(ctx context.Context, alloc serverItemAl
 				continue // Skip if metadata not available
 			}
 
-			if item.Type == "movie" {
-				s.logger.LogMovieSearch(alloc.serverName, alloc.serverType, item.Title, item.Year, item.QualityProfile, category)
-			} else if item.Type == "episode" {
-				s.logger.LogEpisodeSearch(alloc.serverName, alloc.serverType, item.SeriesTitle, item.EpisodeTitle, item.SeasonNumber, item.EpisodeNumber, item.QualityProfile, category)
+			switch item.Type {
+			case "movie":
+				s.logger.LogMovieSearch(alloc.serverName, alloc.serverType, item.ID, item.Title, item.Year, item.QualityProfile, category, alloc.requested[itemID])
+			case "episode":
+				s.logger.LogEpisodeSearch(alloc.serverName, alloc.serverType, item.ID, item.SeriesID, item.SeriesTitle, item.EpisodeTitle, item.SeasonNumber, item.EpisodeNumber, item.QualityProfile, category, alloc.requested[itemID])
+			case "album":
+				s.logger.LogAlbumSearch(alloc.serverName, alloc.serverType, item.ID, item.ArtistName, item.Title, item.Year, item.QualityProfile, category)
+			case "book":
+				s.logger.LogBookSearch(alloc.serverName, alloc.serverType, item.ID, item.AuthorName, item.Title, item.Year, item.QualityProfile, category)
 			}
 		}
 	}
@@ -398,9 +685,71 @@ func (s *SearchTrigger) triggerForServer(ctx context.Context, alloc serverItemAl
 		return result
 	}
 
-	// Create API client and trigger search
+	return s.search(ctx, alloc, result, func(ctx context.Context, client SearchTriggerAPIClient) (*api.CommandResponse, error) {
+		return client.TriggerSearch(ctx, itemIDs)
+	})
+}
+
+// triggerPack triggers a season or series search for missing episodes on a Sonarr server.
+func (s *SearchTrigger) triggerPack(ctx context.Context, alloc serverItemAllocation, pack searchPack, dryRun bool) TriggerResult {
+	item := alloc.missingItems[pack.episodeIDs[0]]
+	result := TriggerResult{
+		ServerID:       alloc.serverID,
+		ServerName:     alloc.serverName,
+		ServerType:     alloc.serverType,
+		Category:       "missing",
+		Command:        pack.command,
+		ItemIDs:        pack.episodeIDs,
+		Success:        true,
+		SeriesTitle:    item.SeriesTitle,
+		SeasonNumber:   pack.season,
+		QualityProfile: item.QualityProfile,
+	}
+
+	if s.logger != nil && !dryRun {
+		s.logger.LogSeasonSearch(alloc.serverName, alloc.serverType, pack.command, pack.seriesID, item.SeriesTitle, pack.season, len(pack.episodeIDs), item.QualityProfile, result.Category, alloc.packRequestedBy(pack))
+	}
+
+	if dryRun {
+		return result
+	}
+
+	return s.search(ctx, alloc, result, func(ctx context.Context, client SearchTriggerAPIClient) (*api.CommandResponse, error) {
+		packClient, ok := client.(seasonSearchClient)
+		if !ok {
+			return nil, fmt.Errorf("%s does not support season searches", alloc.serverType)
+		}
+		if pack.command == api.CommandSeriesSearch {
+			return packClient.TriggerSeriesSearch(ctx, pack.seriesID)
+		}
+		return packClient.TriggerSeasonSearch(ctx, pack.seriesID, pack.season)
+	})
+}
+
+// search sends a search command to a server and records the searched items.
+func (s *SearchTrigger) search(ctx context.Context, alloc serverItemAllocation, result TriggerResult,
+	send func(ctx context.Context, client SearchTriggerAPIClient) (*api.CommandResponse, error)) TriggerResult {
+	attrs := []attribute.KeyValue{
+		attribute.String("janitarr.category", result.Category),
+		attribute.Int("janitarr.items", len(result.ItemIDs)),
+	}
+	if result.Command != "" {
+		attrs = append(attrs, attribute.String("janitarr.command", result.Command))
+	}
+	ctx, span := tracing.Tracer().Start(ctx, "TriggerSearch",
+		tracing.ServerAttributes(alloc.serverName, alloc.serverType),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
 	client := s.apiFactory(alloc.serverURL, alloc.apiKey, alloc.serverType)
-	if err := client.TriggerSearch(ctx, itemIDs); err != nil {
+	if debugLogger, ok := s.logger.(DebugLogger); ok {
+		attachAPILogger(client, debugLogger, alloc.serverName)
+	}
+	command, err := send(ctx, client)
+	s.metrics.IncrementSearches(alloc.serverName, alloc.serverType, result.Category, result.SearchCount(), err != nil)
+	tracing.RecordError(span, err)
+	if err != nil {
 		result.Success = false
 		// Check if it's a rate limit error
 		var rateLimitErr *api.RateLimitError
@@ -409,8 +758,18 @@ func (s *SearchTrigger) triggerForServer(ctx context.Context, alloc serverItemAl
 		} else {
 			result.Error = err.Error()
 		}
+		return result
+	}
+
+	if command != nil {
+		result.CommandID = command.ID
+		span.SetAttributes(attribute.Int("janitarr.command_id", command.ID))
 	}
 
+	// Remember when these items were searched for cooldown and outcome tracking.
+	// A failure here shouldn't fail the search that was already triggered.
+	_ = s.db.RecordSearches(alloc.serverID, database.SearchCategory(result.Category), result.ItemIDs, result.CommandID, time.Now())
+
 	return result
 }
 
@@ -3,22 +3,36 @@ package services
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/edrobertsrayne/janitarr/src/api"
 	"github.com/edrobertsrayne/janitarr/src/database"
 	"github.com/edrobertsrayne/janitarr/src/logger"
+	"github.com/edrobertsrayne/janitarr/src/notifications"
 )
 
 // mockSearchTriggerLogger is a mock implementation of SearchTriggerLogger for testing.
 type mockSearchTriggerLogger struct{}
 
-func (m *mockSearchTriggerLogger) LogMovieSearch(serverName, serverType, title string, year int, qualityProfile, category string) *logger.LogEntry {
+func (m *mockSearchTriggerLogger) LogMovieSearch(serverName, serverType string, movieID int, title string, year int, qualityProfile, category, requestedBy string) *logger.LogEntry {
 	return nil
 }
 
-func (m *mockSearchTriggerLogger) LogEpisodeSearch(serverName, serverType, seriesTitle, episodeTitle string, season, episode int, qualityProfile, category string) *logger.LogEntry {
+func (m *mockSearchTriggerLogger) LogEpisodeSearch(serverName, serverType string, episodeID, seriesID int, seriesTitle, episodeTitle string, season, episode int, qualityProfile, category, requestedBy string) *logger.LogEntry {
+	return nil
+}
+
+func (m *mockSearchTriggerLogger) LogAlbumSearch(serverName, serverType string, albumID int, artistName, title string, year int, qualityProfile, category string) *logger.LogEntry {
+	return nil
+}
+
+func (m *mockSearchTriggerLogger) LogBookSearch(serverName, serverType string, bookID int, authorName, title string, year int, qualityProfile, category string) *logger.LogEntry {
+	return nil
+}
+
+func (m *mockSearchTriggerLogger) LogSeasonSearch(serverName, serverType, command string, seriesID int, seriesTitle string, season, episodes int, qualityProfile, category, requestedBy string) *logger.LogEntry {
 	return nil
 }
 
@@ -41,9 +55,12 @@ func (m *mockTriggerAPIClient) GetAllCutoffUnmet(ctx context.Context) ([]api.Med
 	return []api.MediaItem{}, nil
 }
 
-func (m *mockTriggerAPIClient) TriggerSearch(ctx context.Context, ids []int) error {
+func (m *mockTriggerAPIClient) TriggerSearch(ctx context.Context, ids []int) (*api.CommandResponse, error) {
 	m.triggerCalls = append(m.triggerCalls, ids)
-	return m.triggerErr
+	if m.triggerErr != nil {
+		return nil, m.triggerErr
+	}
+	return &api.CommandResponse{ID: len(m.triggerCalls), Status: api.CommandStatusQueued}, nil
 }
 
 func (m *mockTriggerAPIClient) getTriggerCalls() [][]int {
@@ -625,6 +642,20 @@ func TestDistributeProportional(t *testing.T) {
 				"srv2": 1,
 			},
 		},
+		{
+			name: "minimum 1 per server never exceeds the limit",
+			serverItems: map[string]int{
+				"srv1": 1000,
+				"srv2": 1,
+				"srv3": 1,
+			},
+			limit: 3,
+			expectedAllocation: map[string]int{
+				"srv1": 1,
+				"srv2": 1,
+				"srv3": 1,
+			},
+		},
 		{
 			name: "limit exceeds items",
 			serverItems: map[string]int{
@@ -765,6 +796,159 @@ func TestDistributeProportional(t *testing.T) {
 	}
 }
 
+func TestAllocateItems_ServerLimits(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+	itemRange := func(n int) []int {
+		items := make([]int, n)
+		for i := range items {
+			items[i] = i + 1
+		}
+		return items
+	}
+
+	tests := []struct {
+		name            string
+		limits          map[string]database.ServerLimits // serverID -> limits
+		missing         map[string]int                   // serverID -> missing item count
+		cutoff          map[string]int                   // serverID -> cutoff item count
+		searchLimits    database.SearchLimits
+		expectedMissing map[string]int
+		expectedCutoff  map[string]int
+	}{
+		{
+			name:            "weight scales share",
+			limits:          map[string]database.ServerLimits{"srv1": {Weight: 3}, "srv2": {Weight: 1}},
+			missing:         map[string]int{"srv1": 50, "srv2": 50},
+			searchLimits:    database.SearchLimits{MissingMoviesLimit: 8},
+			expectedMissing: map[string]int{"srv1": 6, "srv2": 2},
+		},
+		{
+			name:            "max missing caps server and frees slots",
+			limits:          map[string]database.ServerLimits{"srv1": {MaxMissing: intPtr(2)}},
+			missing:         map[string]int{"srv1": 90, "srv2": 10},
+			searchLimits:    database.SearchLimits{MissingMoviesLimit: 10},
+			expectedMissing: map[string]int{"srv1": 2, "srv2": 8},
+		},
+		{
+			name:           "max cutoff of zero excludes server",
+			limits:         map[string]database.ServerLimits{"srv1": {MaxCutoff: intPtr(0)}},
+			cutoff:         map[string]int{"srv1": 10, "srv2": 10},
+			searchLimits:   database.SearchLimits{CutoffMoviesLimit: 4},
+			expectedCutoff: map[string]int{"srv1": 0, "srv2": 4},
+		},
+		{
+			name:            "never cutoff only affects cutoff",
+			limits:          map[string]database.ServerLimits{"srv1": {NeverCutoff: true}},
+			missing:         map[string]int{"srv1": 5, "srv2": 5},
+			cutoff:          map[string]int{"srv1": 10, "srv2": 5},
+			searchLimits:    database.SearchLimits{MissingMoviesLimit: 4, CutoffMoviesLimit: 4},
+			expectedMissing: map[string]int{"srv1": 2, "srv2": 2},
+			expectedCutoff:  map[string]int{"srv1": 0, "srv2": 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverMap := make(map[string]*database.Server)
+			detectionResults := &DetectionResults{}
+			for _, id := range []string{"srv1", "srv2"} {
+				serverMap[id] = &database.Server{ID: id, Name: id, Type: database.ServerTypeRadarr, Limits: tt.limits[id]}
+				detectionResults.Results = append(detectionResults.Results, DetectionResult{
+					ServerID:   id,
+					ServerName: id,
+					ServerType: "radarr",
+					Missing:    itemRange(tt.missing[id]),
+					Cutoff:     itemRange(tt.cutoff[id]),
+				})
+			}
+
+			trigger := NewSearchTriggerWithFactory(nil, nil, &mockSearchTriggerLogger{})
+			allocations := trigger.allocateItems(detectionResults, serverMap, tt.searchLimits)
+
+			for _, alloc := range allocations {
+				if expected := tt.expectedMissing[alloc.serverID]; len(alloc.missing) != expected {
+					t.Errorf("server %s: expected %d missing items, got %d", alloc.serverID, expected, len(alloc.missing))
+				}
+				if expected := tt.expectedCutoff[alloc.serverID]; len(alloc.cutoff) != expected {
+					t.Errorf("server %s: expected %d cutoff items, got %d", alloc.serverID, expected, len(alloc.cutoff))
+				}
+			}
+		})
+	}
+}
+
+func TestTriggerSearches_SeparateLimitsPerServerType(t *testing.T) {
+	db := testTriggerDB(t)
+	ctx := context.Background()
+
+	itemRange := func(start, n int) []int {
+		items := make([]int, n)
+		for i := range items {
+			items[i] = start + i
+		}
+		return items
+	}
+
+	var detectionResults DetectionResults
+	for _, srv := range []struct {
+		name       string
+		serverType database.ServerType
+		missing    int
+		cutoff     int
+	}{
+		{"radarr", database.ServerTypeRadarr, 20, 10},
+		{"sonarr", database.ServerTypeSonarr, 5000, 500},
+		{"lidarr", database.ServerTypeLidarr, 20, 0},
+	} {
+		server, err := db.AddServer(srv.name, "http://"+srv.name, "key", srv.serverType)
+		if err != nil {
+			t.Fatalf("adding server: %v", err)
+		}
+		detectionResults.Results = append(detectionResults.Results, DetectionResult{
+			ServerID:   server.ID,
+			ServerName: srv.name,
+			ServerType: string(srv.serverType),
+			Missing:    itemRange(1, srv.missing),
+			Cutoff:     itemRange(10001, srv.cutoff),
+		})
+	}
+
+	trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+		return &mockTriggerAPIClient{serverType: serverType}
+	}, &mockSearchTriggerLogger{})
+
+	// The huge Sonarr backlog can't take the movie budget, and Lidarr shares the movie limits with Radarr
+	limits := database.SearchLimits{MissingMoviesLimit: 4, MissingEpisodesLimit: 3, CutoffMoviesLimit: 2, CutoffEpisodesLimit: 0}
+	results, err := trigger.TriggerSearches(ctx, &detectionResults, limits, true)
+	if err != nil {
+		t.Fatalf("TriggerSearches failed: %v", err)
+	}
+
+	searched := make(map[string]int)
+	for _, r := range results.Results {
+		searched[r.ServerName+" "+r.Category] += len(r.ItemIDs)
+	}
+	expected := map[string]int{"radarr missing": 2, "lidarr missing": 2, "sonarr missing": 3, "radarr cutoff": 2}
+	for key, count := range expected {
+		if searched[key] != count {
+			t.Errorf("expected %d %s searches, got %d (all: %v)", count, key, searched[key], searched)
+		}
+	}
+	if searched["sonarr cutoff"] != 0 {
+		t.Errorf("expected no sonarr cutoff searches with a zero limit, got %d", searched["sonarr cutoff"])
+	}
+
+	wantBudgets := []SearchBudget{
+		{Limit: database.LimitMissingMovies, Used: 4, Available: 4},
+		{Limit: database.LimitMissingEpisodes, Used: 3, Available: 3},
+		{Limit: database.LimitCutoffMovies, Used: 2, Available: 2},
+		{Limit: database.LimitCutoffEpisodes, Used: 0, Available: 0},
+	}
+	if !reflect.DeepEqual(results.Budgets, wantBudgets) {
+		t.Errorf("expected budgets %+v, got %+v", wantBudgets, results.Budgets)
+	}
+}
+
 func TestTriggerSearches_RateLimitSkipsAfter3(t *testing.T) {
 	db := testTriggerDB(t)
 
@@ -824,6 +1008,68 @@ func TestTriggerSearches_RateLimitSkipsAfter3(t *testing.T) {
 	}
 }
 
+func TestTriggerSearches_RateLimitNotifiesOnLockout(t *testing.T) {
+	// Each allocation searches missing and cutoff items separately; a strike carried in from
+	// earlier searches makes the cutoff search the third consecutive 429
+	tests := []struct {
+		name          string
+		priorStrikes  int
+		rateLimited   int
+		notifications int
+	}{
+		{"two rate limits", 0, 2, 0},
+		{"three rate limits", 1, 3, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testTriggerDB(t)
+			server, err := db.AddServer("radarr1", "http://localhost:7878", "api1", database.ServerTypeRadarr)
+			if err != nil {
+				t.Fatalf("adding server: %v", err)
+			}
+
+			appLogger := logger.NewLogger(db, logger.LevelInfo, false)
+			entries := appLogger.Subscribe()
+			mockClient := &mockTriggerAPIClient{
+				serverType: "radarr",
+				triggerErr: &api.RateLimitError{RetryAfter: 30 * time.Second},
+			}
+			trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+				return mockClient
+			}, appLogger)
+
+			allocations := []serverItemAllocation{
+				{serverID: server.ID, serverName: "radarr1", serverType: "radarr", missing: []int{1}, cutoff: []int{2}, rateLimitCount: tt.priorStrikes},
+			}
+			results, err := trigger.executeAllocations(context.Background(), allocations, false)
+			if err != nil {
+				t.Fatalf("executeAllocations failed: %v", err)
+			}
+
+			// Log the failures the way the automation cycle does
+			for _, result := range results.Results {
+				if !result.Success {
+					appLogger.LogSearchError(result.ServerName, result.ServerType, result.Category, result.Error)
+				}
+			}
+			if results.FailureCount+tt.priorStrikes != tt.rateLimited {
+				t.Fatalf("expected %d consecutive rate limits, got %d", tt.rateLimited, results.FailureCount+tt.priorStrikes)
+			}
+
+			sent := 0
+			for len(entries) > 0 {
+				if event, ok := notifications.EventFromLogEntry(<-entries); ok && event.Type == database.NotificationEventRateLimit {
+					sent++
+				}
+			}
+			if sent != tt.notifications {
+				t.Errorf("expected %d rate limit notifications, got %d", tt.notifications, sent)
+			}
+		})
+	}
+}
+
 func TestTriggerSearches_DelayBetweenBatches(t *testing.T) {
 	db := testTriggerDB(t)
 
@@ -893,3 +1139,101 @@ func TestTriggerSearches_DelayBetweenBatches(t *testing.T) {
 		t.Errorf("total duration was %v, expected >= 100ms for batch delays", duration)
 	}
 }
+
+func TestTriggerSearches_SkipsItemsInCooldown(t *testing.T) {
+	db := testTriggerDB(t)
+
+	server1, err := db.AddServer("radarr1", "http://localhost:7878", "api1", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	// Items 1-3 were searched an hour ago, item 4 three days ago
+	_ = db.RecordSearches(server1.ID, database.SearchCategoryMissing, []int{1, 2, 3}, 0, time.Now().Add(-1*time.Hour))
+	_ = db.RecordSearches(server1.ID, database.SearchCategoryMissing, []int{4}, 0, time.Now().Add(-72*time.Hour))
+
+	mockClient := &mockTriggerAPIClient{serverType: "radarr"}
+	trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+		return mockClient
+	}, &mockSearchTriggerLogger{})
+
+	detectionResults := &DetectionResults{
+		Results: []DetectionResult{
+			{
+				ServerID:   server1.ID,
+				ServerName: "radarr1",
+				ServerType: "radarr",
+				Missing:    []int{1, 2, 3, 4, 5, 6},
+				Cutoff:     []int{},
+			},
+		},
+		TotalMissing: 6,
+		SuccessCount: 1,
+	}
+
+	limits := database.SearchLimits{MissingMoviesLimit: 3}
+	results, err := trigger.TriggerSearches(context.Background(), detectionResults, limits, false)
+	if err != nil {
+		t.Fatalf("TriggerSearches failed: %v", err)
+	}
+
+	if results.CooldownSkipped != 3 {
+		t.Errorf("expected 3 items skipped for cooldown, got %d", results.CooldownSkipped)
+	}
+
+	calls := mockClient.getTriggerCalls()
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 trigger call, got %d", len(calls))
+	}
+	// Never-searched items come before item 4 with the default oldest-searched-first strategy
+	if len(calls[0]) != 3 || calls[0][0] != 5 || calls[0][1] != 6 || calls[0][2] != 4 {
+		t.Errorf("expected items [5 6 4] to be searched, got %v", calls[0])
+	}
+
+	// The searched items are now in cooldown too
+	history, err := db.GetSearchHistory(server1.ID, database.SearchCategoryMissing)
+	if err != nil {
+		t.Fatalf("GetSearchHistory failed: %v", err)
+	}
+	if time.Since(history[4]) > time.Minute || time.Since(history[5]) > time.Minute || time.Since(history[6]) > time.Minute {
+		t.Errorf("expected items 4, 5 and 6 to be recorded as just searched, got %v", history)
+	}
+}
+
+func TestTriggerSearches_CooldownDisabled(t *testing.T) {
+	db := testTriggerDB(t)
+
+	server1, err := db.AddServer("radarr1", "http://localhost:7878", "api1", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	_ = db.RecordSearches(server1.ID, database.SearchCategoryMissing, []int{1, 2}, 0, time.Now())
+
+	config := db.GetAppConfig()
+	config.Search.CooldownHours = 0
+	if err := db.SetAppConfig(config); err != nil {
+		t.Fatalf("SetAppConfig failed: %v", err)
+	}
+
+	mockClient := &mockTriggerAPIClient{serverType: "radarr"}
+	trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+		return mockClient
+	}, &mockSearchTriggerLogger{})
+
+	detectionResults := &DetectionResults{
+		Results: []DetectionResult{
+			{ServerID: server1.ID, ServerName: "radarr1", ServerType: "radarr", Missing: []int{1, 2}, Cutoff: []int{}},
+		},
+	}
+
+	results, err := trigger.TriggerSearches(context.Background(), detectionResults, database.SearchLimits{MissingMoviesLimit: 2}, true)
+	if err != nil {
+		t.Fatalf("TriggerSearches failed: %v", err)
+	}
+	if results.CooldownSkipped != 0 {
+		t.Errorf("expected no cooldown skips when disabled, got %d", results.CooldownSkipped)
+	}
+	if results.MissingTriggered != 2 {
+		t.Errorf("expected 2 missing triggered, got %d", results.MissingTriggered)
+	}
+}
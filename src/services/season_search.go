@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"slices"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// seasonSearchClient is implemented by API clients that can search a whole season or series with one command.
+type seasonSearchClient interface {
+	GetSeries(ctx context.Context) ([]api.Series, error)
+	TriggerSeasonSearch(ctx context.Context, seriesID, seasonNumber int) (*api.CommandResponse, error)
+	TriggerSeriesSearch(ctx context.Context, seriesID int) (*api.CommandResponse, error)
+}
+
+// searchPack is a season or series search standing in for several missing episodes.
+type searchPack struct {
+	command    string // api.CommandSeasonSearch or api.CommandSeriesSearch
+	seriesID   int
+	season     int   // Season searched, for season searches
+	episodeIDs []int // Missing episodes the search covers, in selection order
+}
+
+// seasonKey identifies one season of a series.
+type seasonKey struct {
+	seriesID int
+	season   int
+}
+
+// applySeasonPacks returns a copy of the detection results in which Sonarr episodes making up at least
+// percent of a season are replaced by one season search, or by a series search when every monitored
+// season qualifies. A pack keeps the position of its first episode and takes a single allocation slot.
+// The packs are returned by server ID, keyed by the episode standing in for them.
+// If a server's series can't be read, its episodes are searched individually.
+func (s *SearchTrigger) applySeasonPacks(ctx context.Context, detectionResults *DetectionResults, serverMap map[string]*database.Server, percent int) (*DetectionResults, map[string]map[int]searchPack) {
+	if percent <= 0 {
+		return detectionResults, nil
+	}
+
+	grouped := *detectionResults
+	grouped.Results = make([]DetectionResult, len(detectionResults.Results))
+	packs := make(map[string]map[int]searchPack)
+
+	for i, result := range detectionResults.Results {
+		grouped.Results[i] = result
+		server, ok := serverMap[result.ServerID]
+		if result.Error != "" || !ok || server.Type != database.ServerTypeSonarr {
+			continue
+		}
+
+		serverPacks := s.seasonPacks(ctx, server, result, percent)
+		if len(serverPacks) == 0 {
+			continue
+		}
+
+		covered := make(map[int]bool)
+		keyed := make(map[int]searchPack, len(serverPacks))
+		for _, pack := range serverPacks {
+			keyed[pack.episodeIDs[0]] = pack
+			for _, id := range pack.episodeIDs[1:] {
+				covered[id] = true
+			}
+		}
+		grouped.Results[i].Missing = slices.DeleteFunc(slices.Clone(result.Missing), func(id int) bool { return covered[id] })
+		packs[result.ServerID] = keyed
+	}
+
+	return &grouped, packs
+}
+
+// seasonPacks works out which of a server's missing episodes are better searched by season or series.
+func (s *SearchTrigger) seasonPacks(ctx context.Context, server *database.Server, result DetectionResult, percent int) []searchPack {
+	seasons := make(map[seasonKey][]int)
+	var order []seasonKey
+	for _, id := range result.Missing {
+		item, ok := result.MissingItems[id]
+		if !ok || item.SeriesID == 0 {
+			continue
+		}
+		key := seasonKey{item.SeriesID, item.SeasonNumber}
+		if _, seen := seasons[key]; !seen {
+			order = append(order, key)
+		}
+		seasons[key] = append(seasons[key], id)
+	}
+
+	// A pack only helps when it replaces several episode searches, so don't ask for the series otherwise
+	if !slices.ContainsFunc(order, func(key seasonKey) bool { return len(seasons[key]) >= 2 }) {
+		return nil
+	}
+
+	client, ok := s.apiFactory(server.URL, server.APIKey, string(server.Type)).(seasonSearchClient)
+	if !ok {
+		return nil
+	}
+	debugLogger, hasDebug := s.logger.(DebugLogger)
+	if hasDebug {
+		attachAPILogger(client, debugLogger, server.Name)
+	}
+	series, err := client.GetSeries(ctx)
+	if err != nil {
+		if hasDebug {
+			debugLogger.Debug("Failed to read series, searching episodes individually", "server", server.Name, "error", err)
+		}
+		return nil
+	}
+
+	bySeries := make(map[int]api.Series, len(series))
+	for _, show := range series {
+		bySeries[show.ID] = show
+	}
+
+	var packs []searchPack
+	seriesPacked := make(map[int]bool)
+	for _, key := range order {
+		show, ok := bySeries[key.seriesID]
+		if !ok || seriesPacked[key.seriesID] || !seasonQualifies(show, key.season, len(seasons[key]), percent) {
+			continue
+		}
+
+		if wholeSeriesMissing(show, seasons, percent) {
+			// A series search covers every missing episode of the series, whatever its season
+			seriesPacked[key.seriesID] = true
+			var episodes []int
+			for _, id := range result.Missing {
+				if item, ok := result.MissingItems[id]; ok && item.SeriesID == key.seriesID {
+					episodes = append(episodes, id)
+				}
+			}
+			packs = append(packs, searchPack{command: api.CommandSeriesSearch, seriesID: key.seriesID, episodeIDs: episodes})
+			continue
+		}
+
+		packs = append(packs, searchPack{command: api.CommandSeasonSearch, seriesID: key.seriesID, season: key.season, episodeIDs: seasons[key]})
+	}
+
+	return packs
+}
+
+// seasonQualifies reports whether enough of a season is missing to search it as a whole.
+// Seasons are compared against the episodes Sonarr expects to have: monitored episodes that have aired or have a file.
+// A season only qualifies if every episode it is missing is still a candidate, so the search doesn't
+// cover episodes removed earlier, such as ones given up on or searched recently.
+func seasonQualifies(show api.Series, seasonNumber, missing, percent int) bool {
+	i := slices.IndexFunc(show.Seasons, func(season api.Season) bool { return season.SeasonNumber == seasonNumber })
+	if i < 0 || missing < 2 {
+		return false
+	}
+	stats := show.Seasons[i].Statistics
+	return stats != nil && stats.EpisodeCount > 0 && missing*100 >= percent*stats.EpisodeCount && seasonComplete(stats, missing)
+}
+
+// seasonComplete reports whether the candidates account for every episode Sonarr counts as missing
+// from a season: monitored episodes that have aired but have no file.
+func seasonComplete(stats *api.SeasonStatistics, candidates int) bool {
+	return candidates >= stats.EpisodeCount-stats.EpisodeFileCount
+}
+
+// wholeSeriesMissing reports whether every monitored season of a series qualifies for a season
+// search, and there are at least two of them. Specials don't need to qualify, but as a series
+// search covers them too, none of their missing episodes may have been removed.
+func wholeSeriesMissing(show api.Series, seasons map[seasonKey][]int, percent int) bool {
+	qualified := 0
+	for _, season := range show.Seasons {
+		if season.Statistics != nil && !seasonComplete(season.Statistics, len(seasons[seasonKey{show.ID, season.SeasonNumber}])) {
+			return false
+		}
+		if season.SeasonNumber == 0 || !season.Monitored || season.Statistics == nil || season.Statistics.EpisodeCount == 0 {
+			continue
+		}
+		if !seasonQualifies(show, season.SeasonNumber, len(seasons[seasonKey{show.ID, season.SeasonNumber}]), percent) {
+			return false
+		}
+		qualified++
+	}
+	return qualified >= 2
+}
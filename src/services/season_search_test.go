@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// mockSeasonSearchClient is a mockTriggerAPIClient that can also search whole seasons and series, like Sonarr.
+type mockSeasonSearchClient struct {
+	mockTriggerAPIClient
+	series         []api.Series
+	seriesErr      error
+	seasonSearches [][2]int
+	seriesSearches []int
+}
+
+func (m *mockSeasonSearchClient) GetSeries(ctx context.Context) ([]api.Series, error) {
+	return m.series, m.seriesErr
+}
+
+func (m *mockSeasonSearchClient) TriggerSeasonSearch(ctx context.Context, seriesID, seasonNumber int) (*api.CommandResponse, error) {
+	m.seasonSearches = append(m.seasonSearches, [2]int{seriesID, seasonNumber})
+	return &api.CommandResponse{ID: 100 + len(m.seasonSearches)}, nil
+}
+
+func (m *mockSeasonSearchClient) TriggerSeriesSearch(ctx context.Context, seriesID int) (*api.CommandResponse, error) {
+	m.seriesSearches = append(m.seriesSearches, seriesID)
+	return &api.CommandResponse{ID: 200 + len(m.seriesSearches)}, nil
+}
+
+// season returns a monitored season of episodeCount aired episodes, missing of which have no file.
+func season(number, episodeCount, missing int) api.Season {
+	return api.Season{SeasonNumber: number, Monitored: true, Statistics: &api.SeasonStatistics{EpisodeCount: episodeCount, EpisodeFileCount: episodeCount - missing}}
+}
+
+// seasonPackDetection returns a Sonarr backlog where most of season 1 of "Show" is missing, one
+// episode of its season 2 is missing, and every episode of "Other" is missing.
+func seasonPackDetection(serverID string) *DetectionResults {
+	episodes := map[int]api.MediaItem{}
+	add := func(id, seriesID, seasonNumber int, seriesTitle string) {
+		episodes[id] = api.MediaItem{ID: id, Type: "episode", SeriesID: seriesID, SeriesTitle: seriesTitle, SeasonNumber: seasonNumber}
+	}
+	add(101, 10, 1, "Show")
+	add(102, 10, 1, "Show")
+	add(103, 10, 1, "Show")
+	add(201, 10, 2, "Show")
+	add(301, 20, 1, "Other")
+	add(302, 20, 1, "Other")
+	add(401, 20, 2, "Other")
+	add(402, 20, 2, "Other")
+
+	return &DetectionResults{
+		Results: []DetectionResult{{
+			ServerID:     serverID,
+			ServerName:   "sonarr",
+			ServerType:   "sonarr",
+			Missing:      []int{101, 201, 102, 301, 103, 401, 302, 402},
+			Cutoff:       []int{},
+			MissingItems: episodes,
+		}},
+		TotalMissing: 8,
+		SuccessCount: 1,
+	}
+}
+
+func TestTriggerSearches_SeasonPacks(t *testing.T) {
+	db := testTriggerDB(t)
+	server, err := db.AddServer("sonarr", "http://localhost:8989", "key", database.ServerTypeSonarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	client := &mockSeasonSearchClient{
+		mockTriggerAPIClient: mockTriggerAPIClient{serverType: "sonarr"},
+		series: []api.Series{
+			{ID: 10, Title: "Show", Seasons: []api.Season{season(0, 5, 0), season(1, 4, 3), season(2, 10, 1)}},
+			{ID: 20, Title: "Other", Seasons: []api.Season{season(1, 2, 2), season(2, 2, 2)}},
+		},
+	}
+	trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+		return client
+	}, &mockSearchTriggerLogger{})
+
+	// A season pack and a series pack take one slot each, leaving one for an episode
+	limits := database.SearchLimits{MissingEpisodesLimit: 3}
+	results, err := trigger.TriggerSearches(context.Background(), seasonPackDetection(server.ID), limits, false)
+	if err != nil {
+		t.Fatalf("TriggerSearches failed: %v", err)
+	}
+
+	if calls := client.getTriggerCalls(); len(calls) != 1 || !slices.Equal(calls[0], []int{201}) {
+		t.Errorf("expected episode 201 to be searched on its own, got %v", calls)
+	}
+	if len(client.seasonSearches) != 1 || client.seasonSearches[0] != [2]int{10, 1} {
+		t.Errorf("expected a season search for season 1 of series 10, got %v", client.seasonSearches)
+	}
+	if !slices.Equal(client.seriesSearches, []int{20}) {
+		t.Errorf("expected a series search for series 20, got %v", client.seriesSearches)
+	}
+	if results.MissingTriggered != 3 || results.SuccessCount != 3 {
+		t.Errorf("expected 3 searches counted, got %d from %d results", results.MissingTriggered, results.SuccessCount)
+	}
+
+	commands := map[string][]int{}
+	for _, r := range results.Results {
+		commands[r.Command] = r.ItemIDs
+	}
+	if !slices.Equal(commands[api.CommandSeasonSearch], []int{101, 102, 103}) || !slices.Equal(commands[api.CommandSeriesSearch], []int{301, 401, 302, 402}) {
+		t.Errorf("expected packs to cover their episodes, got %v", commands)
+	}
+
+	// Every covered episode goes into cooldown
+	history, err := db.GetSearchHistory(server.ID, database.SearchCategoryMissing)
+	if err != nil {
+		t.Fatalf("GetSearchHistory failed: %v", err)
+	}
+	if len(history) != 8 {
+		t.Errorf("expected all 8 episodes to be recorded as searched, got %v", history)
+	}
+}
+
+func TestTriggerSearches_SeasonPacksSkipRemovedEpisodes(t *testing.T) {
+	tests := []struct {
+		name   string
+		config func(*database.SearchConfig)
+		remove func(t *testing.T, db *database.DB, serverID string)
+	}{
+		{"cooling down", func(c *database.SearchConfig) { c.CooldownHours = 24 }, func(t *testing.T, db *database.DB, serverID string) {
+			searchTimes(t, db, serverID, 102, 1, time.Now().Add(-time.Hour))
+		}},
+		{"given up", func(c *database.SearchConfig) { c.CooldownHours = 0; c.GiveUpAttempts = 2 }, func(t *testing.T, db *database.DB, serverID string) {
+			searchTimes(t, db, serverID, 102, 2, time.Now().Add(-30*time.Hour))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testTriggerDB(t)
+			server, err := db.AddServer("sonarr", "http://localhost:8989", "key", database.ServerTypeSonarr)
+			if err != nil {
+				t.Fatalf("adding server: %v", err)
+			}
+			config := db.GetAppConfig()
+			config.Search.SeasonPackPercent = 50
+			tt.config(&config.Search)
+			if err := db.SetAppConfig(config); err != nil {
+				t.Fatalf("setting config: %v", err)
+			}
+			tt.remove(t, db, server.ID)
+
+			client := &mockSeasonSearchClient{
+				mockTriggerAPIClient: mockTriggerAPIClient{serverType: "sonarr"},
+				series:               []api.Series{{ID: 10, Seasons: []api.Season{season(1, 4, 3), season(2, 10, 1)}}},
+			}
+			trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+				return client
+			}, &mockSearchTriggerLogger{})
+
+			// Episodes 101 and 103 are still half of season 1, but a season search would also cover 102
+			detection := seasonPackDetection(server.ID)
+			detection.Results[0].Missing = []int{101, 102, 103}
+			if _, err := trigger.TriggerSearches(context.Background(), detection, database.SearchLimits{MissingEpisodesLimit: 3}, false); err != nil {
+				t.Fatalf("TriggerSearches failed: %v", err)
+			}
+
+			if len(client.seasonSearches) != 0 {
+				t.Errorf("expected no season search, got %v", client.seasonSearches)
+			}
+			if calls := client.getTriggerCalls(); len(calls) != 1 || !slices.Equal(slices.Sorted(slices.Values(calls[0])), []int{101, 103}) {
+				t.Errorf("expected episodes 101 and 103 to be searched individually, got %v", calls)
+			}
+		})
+	}
+}
+
+func TestTriggerSearches_SeasonPacksFallBack(t *testing.T) {
+	tests := []struct {
+		name      string
+		percent   int
+		seriesErr error
+	}{
+		{"disabled", 0, nil},
+		{"series unavailable", 75, errors.New("server error: status 500")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testTriggerDB(t)
+			server, err := db.AddServer("sonarr", "http://localhost:8989", "key", database.ServerTypeSonarr)
+			if err != nil {
+				t.Fatalf("adding server: %v", err)
+			}
+			config := db.GetAppConfig()
+			config.Search.SeasonPackPercent = tt.percent
+			if err := db.SetAppConfig(config); err != nil {
+				t.Fatalf("setting config: %v", err)
+			}
+
+			client := &mockSeasonSearchClient{
+				mockTriggerAPIClient: mockTriggerAPIClient{serverType: "sonarr"},
+				series:               []api.Series{{ID: 10, Seasons: []api.Season{season(1, 3, 3)}}},
+				seriesErr:            tt.seriesErr,
+			}
+			trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+				return client
+			}, &mockSearchTriggerLogger{})
+
+			limits := database.SearchLimits{MissingEpisodesLimit: 3}
+			results, err := trigger.TriggerSearches(context.Background(), seasonPackDetection(server.ID), limits, false)
+			if err != nil {
+				t.Fatalf("TriggerSearches failed: %v", err)
+			}
+
+			if calls := client.getTriggerCalls(); len(calls) != 1 || !slices.Equal(calls[0], []int{101, 201, 102}) {
+				t.Errorf("expected episodes to be searched individually, got %v", calls)
+			}
+			if len(client.seasonSearches)+len(client.seriesSearches) != 0 || results.MissingTriggered != 3 {
+				t.Errorf("expected no packs and 3 searches, got %d searches", results.MissingTriggered)
+			}
+		})
+	}
+}
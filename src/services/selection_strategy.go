@@ -0,0 +1,156 @@
+package services
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// SelectionCandidates holds the items a strategy chooses from for one server and category.
+type SelectionCandidates struct {
+	ItemIDs      []int                 // Eligible item IDs in the order the server returned them
+	Items        map[int]api.MediaItem // Item metadata indexed by ID (entries may be missing)
+	LastSearched map[int]time.Time     // Last search time indexed by ID (absent if never searched)
+}
+
+// SelectionStrategy orders a server's wanted items so allocation can take the first N.
+type SelectionStrategy interface {
+	Mode() database.SelectionMode
+	Order(candidates SelectionCandidates) []int
+}
+
+// NewSelectionStrategy returns the built-in strategy for the given mode.
+func NewSelectionStrategy(mode database.SelectionMode) (SelectionStrategy, error) {
+	switch mode {
+	case database.SelectionOldestSearchedFirst:
+		return oldestSearchedFirst{}, nil
+	case database.SelectionRandom:
+		return randomSelection{}, nil
+	case database.SelectionNewestReleaseFirst:
+		return newestReleaseFirst{}, nil
+	case database.SelectionAlphabetical:
+		return alphabeticalSelection{}, nil
+	case database.SelectionLeastRecentlyAdded:
+		return leastRecentlyAdded{}, nil
+	default:
+		return nil, fmt.Errorf("unknown selection strategy: %s", mode)
+	}
+}
+
+// oldestSearchedFirst puts never-searched items first, then items by how long ago they were searched.
+type oldestSearchedFirst struct{}
+
+func (oldestSearchedFirst) Mode() database.SelectionMode {
+	return database.SelectionOldestSearchedFirst
+}
+
+func (oldestSearchedFirst) Order(c SelectionCandidates) []int {
+	ordered := slices.Clone(c.ItemIDs)
+	slices.SortStableFunc(ordered, func(a, b int) int {
+		return c.LastSearched[a].Compare(c.LastSearched[b])
+	})
+	return ordered
+}
+
+// randomSelection shuffles items so every item has the same chance of being picked.
+type randomSelection struct{}
+
+func (randomSelection) Mode() database.SelectionMode {
+	return database.SelectionRandom
+}
+
+func (randomSelection) Order(c SelectionCandidates) []int {
+	ordered := slices.Clone(c.ItemIDs)
+	rand.Shuffle(len(ordered), func(i, j int) {
+		ordered[i], ordered[j] = ordered[j], ordered[i]
+	})
+	return ordered
+}
+
+// newestReleaseFirst puts the most recently released items first. Items without a release date go last.
+type newestReleaseFirst struct{}
+
+func (newestReleaseFirst) Mode() database.SelectionMode {
+	return database.SelectionNewestReleaseFirst
+}
+
+func (newestReleaseFirst) Order(c SelectionCandidates) []int {
+	ordered := slices.Clone(c.ItemIDs)
+	slices.SortStableFunc(ordered, func(a, b int) int {
+		return compareDates(c.Items[a].ReleaseDate, c.Items[b].ReleaseDate, true)
+	})
+	return ordered
+}
+
+// alphabeticalSelection orders movies by title, episodes by series, season and episode number,
+// and albums and books by artist or author then title.
+type alphabeticalSelection struct{}
+
+func (alphabeticalSelection) Mode() database.SelectionMode {
+	return database.SelectionAlphabetical
+}
+
+func (alphabeticalSelection) Order(c SelectionCandidates) []int {
+	ordered := slices.Clone(c.ItemIDs)
+	slices.SortStableFunc(ordered, func(a, b int) int {
+		itemA, itemB := c.Items[a], c.Items[b]
+		if cmp := strings.Compare(sortTitle(itemA), sortTitle(itemB)); cmp != 0 {
+			return cmp
+		}
+		if itemA.SeasonNumber != itemB.SeasonNumber {
+			return itemA.SeasonNumber - itemB.SeasonNumber
+		}
+		return itemA.EpisodeNumber - itemB.EpisodeNumber
+	})
+	return ordered
+}
+
+// leastRecentlyAdded puts items that have been on the server longest first. Items without an added date go last.
+type leastRecentlyAdded struct{}
+
+func (leastRecentlyAdded) Mode() database.SelectionMode {
+	return database.SelectionLeastRecentlyAdded
+}
+
+func (leastRecentlyAdded) Order(c SelectionCandidates) []int {
+	ordered := slices.Clone(c.ItemIDs)
+	slices.SortStableFunc(ordered, func(a, b int) int {
+		return compareDates(c.Items[a].Added, c.Items[b].Added, false)
+	})
+	return ordered
+}
+
+// compareDates compares two dates for sorting, always placing zero (unknown) dates last.
+func compareDates(a, b time.Time, newestFirst bool) int {
+	switch {
+	case a.IsZero() && b.IsZero():
+		return 0
+	case a.IsZero():
+		return 1
+	case b.IsZero():
+		return -1
+	case newestFirst:
+		return b.Compare(a)
+	default:
+		return a.Compare(b)
+	}
+}
+
+// sortTitle returns the lowercased title used for alphabetical ordering.
+func sortTitle(item api.MediaItem) string {
+	switch item.Type {
+	case "episode":
+		return strings.ToLower(item.SeriesTitle)
+	case "album":
+		return strings.ToLower(item.ArtistName + " " + item.Title)
+	case "book":
+		return strings.ToLower(item.AuthorName + " " + item.Title)
+	default:
+		return strings.ToLower(item.Title)
+	}
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+func TestNewSelectionStrategy_AllModes(t *testing.T) {
+	for _, mode := range database.SelectionModes {
+		strategy, err := NewSelectionStrategy(mode)
+		if err != nil {
+			t.Fatalf("NewSelectionStrategy(%s) failed: %v", mode, err)
+		}
+		if strategy.Mode() != mode {
+			t.Errorf("expected mode %s, got %s", mode, strategy.Mode())
+		}
+	}
+
+	if _, err := NewSelectionStrategy("bogus"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestSelectionStrategy_Order(t *testing.T) {
+	now := time.Now()
+	items := map[int]api.MediaItem{
+		1: {ID: 1, Type: "movie", Title: "Zodiac", ReleaseDate: now.Add(-48 * time.Hour), Added: now.Add(-10 * time.Hour)},
+		2: {ID: 2, Type: "movie", Title: "alien", ReleaseDate: now.Add(-1 * time.Hour), Added: now.Add(-30 * time.Hour)},
+		3: {ID: 3, Type: "movie", Title: "Memento"},
+		4: {ID: 4, Type: "movie", Title: "Heat", ReleaseDate: now.Add(-24 * time.Hour), Added: now.Add(-20 * time.Hour)},
+	}
+	lastSearched := map[int]time.Time{
+		1: now.Add(-1 * time.Hour),
+		2: now.Add(-5 * time.Hour),
+	}
+	candidates := SelectionCandidates{ItemIDs: []int{1, 2, 3, 4}, Items: items, LastSearched: lastSearched}
+
+	tests := []struct {
+		mode     database.SelectionMode
+		expected []int
+	}{
+		{database.SelectionOldestSearchedFirst, []int{3, 4, 2, 1}},
+		{database.SelectionNewestReleaseFirst, []int{2, 4, 1, 3}},
+		{database.SelectionAlphabetical, []int{2, 4, 3, 1}},
+		{database.SelectionLeastRecentlyAdded, []int{2, 4, 1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			strategy, _ := NewSelectionStrategy(tt.mode)
+			got := strategy.Order(candidates)
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	// Ordering must not modify the caller's slice
+	if !slices.Equal(candidates.ItemIDs, []int{1, 2, 3, 4}) {
+		t.Errorf("input slice was modified: %v", candidates.ItemIDs)
+	}
+}
+
+func TestSelectionStrategy_AlphabeticalEpisodes(t *testing.T) {
+	items := map[int]api.MediaItem{
+		1: {ID: 1, Type: "episode", SeriesTitle: "The Wire", SeasonNumber: 2, EpisodeNumber: 1},
+		2: {ID: 2, Type: "episode", SeriesTitle: "The Wire", SeasonNumber: 1, EpisodeNumber: 3},
+		3: {ID: 3, Type: "episode", SeriesTitle: "Lost", SeasonNumber: 4, EpisodeNumber: 1},
+		4: {ID: 4, Type: "episode", SeriesTitle: "The Wire", SeasonNumber: 1, EpisodeNumber: 2},
+	}
+
+	got := alphabeticalSelection{}.Order(SelectionCandidates{ItemIDs: []int{1, 2, 3, 4}, Items: items})
+	if !slices.Equal(got, []int{3, 4, 2, 1}) {
+		t.Errorf("expected [3 4 2 1], got %v", got)
+	}
+}
+
+func TestSelectionStrategy_RandomIsPermutation(t *testing.T) {
+	ids := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	got := randomSelection{}.Order(SelectionCandidates{ItemIDs: ids})
+
+	sorted := slices.Clone(got)
+	slices.Sort(sorted)
+	if !slices.Equal(sorted, ids) {
+		t.Errorf("expected a permutation of %v, got %v", ids, got)
+	}
+}
+
+func TestTriggerSearches_UsesConfiguredStrategy(t *testing.T) {
+	db := testTriggerDB(t)
+
+	server1, err := db.AddServer("radarr1", "http://localhost:7878", "api1", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	config := db.GetAppConfig()
+	config.Search.Strategy = database.SelectionAlphabetical
+	if err := db.SetAppConfig(config); err != nil {
+		t.Fatalf("SetAppConfig failed: %v", err)
+	}
+
+	mockClient := &mockTriggerAPIClient{serverType: "radarr"}
+	trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+		return mockClient
+	}, &mockSearchTriggerLogger{})
+
+	detectionResults := &DetectionResults{
+		Results: []DetectionResult{
+			{
+				ServerID:   server1.ID,
+				ServerName: "radarr1",
+				ServerType: "radarr",
+				Missing:    []int{1, 2, 3},
+				Cutoff:     []int{},
+				MissingItems: map[int]api.MediaItem{
+					1: {ID: 1, Type: "movie", Title: "Zodiac"},
+					2: {ID: 2, Type: "movie", Title: "Heat"},
+					3: {ID: 3, Type: "movie", Title: "Alien"},
+				},
+			},
+		},
+	}
+
+	results, err := trigger.TriggerSearches(context.Background(), detectionResults, database.SearchLimits{MissingMoviesLimit: 2}, true)
+	if err != nil {
+		t.Fatalf("TriggerSearches failed: %v", err)
+	}
+
+	if len(results.Results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results.Results))
+	}
+	if !slices.Equal(results.Results[0].ItemIDs, []int{3, 2}) {
+		t.Errorf("expected items [3 2], got %v", results.Results[0].ItemIDs)
+	}
+	if results.Results[0].Strategy != database.SelectionAlphabetical {
+		t.Errorf("expected strategy %s, got %s", database.SelectionAlphabetical, results.Results[0].Strategy)
+	}
+
+	// Detection results passed in must keep their original order
+	if !slices.Equal(detectionResults.Results[0].Missing, []int{1, 2, 3}) {
+		t.Errorf("detection results were modified: %v", detectionResults.Results[0].Missing)
+	}
+}
+
+// warningTriggerLogger is a mockSearchTriggerLogger that records warnings.
+type warningTriggerLogger struct {
+	mockSearchTriggerLogger
+	warnings []string
+}
+
+func (l *warningTriggerLogger) Warn(msg string, keyvals ...interface{}) {
+	l.warnings = append(l.warnings, msg)
+}
+
+func TestTriggerSearches_WarnsOnUnknownStrategy(t *testing.T) {
+	db := testTriggerDB(t)
+	server, err := db.AddServer("radarr1", "http://localhost:7878", "api1", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	// Stored values are validated, so only a config injected past the setters can hold a typo
+	getAppConfig := database.GetAppConfigFunc
+	database.GetAppConfigFunc = func(db *database.DB) database.AppConfig {
+		config := getAppConfig(db)
+		config.Search.Strategy = "oldest-first"
+		return config
+	}
+	t.Cleanup(func() { database.GetAppConfigFunc = getAppConfig })
+
+	log := &warningTriggerLogger{}
+	trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+		return &mockTriggerAPIClient{serverType: "radarr"}
+	}, log)
+
+	detectionResults := &DetectionResults{Results: []DetectionResult{
+		{ServerID: server.ID, ServerName: "radarr1", ServerType: "radarr", Missing: []int{1}, Cutoff: []int{}},
+	}}
+	results, err := trigger.TriggerSearches(context.Background(), detectionResults, database.SearchLimits{MissingMoviesLimit: 1}, true)
+	if err != nil {
+		t.Fatalf("TriggerSearches failed: %v", err)
+	}
+
+	if len(log.warnings) != 1 {
+		t.Errorf("expected a warning about the unknown strategy, got %v", log.warnings)
+	}
+	if len(results.Results) != 1 || results.Results[0].Strategy != database.SelectionOldestSearchedFirst {
+		t.Errorf("expected the default strategy to be used, got %+v", results.Results)
+	}
+}
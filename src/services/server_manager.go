@@ -19,10 +19,16 @@ type APIClientFactory func(url, apiKey, serverType string) APIClient
 
 // defaultAPIClientFactory creates real API clients.
 func defaultAPIClientFactory(url, apiKey, serverType string) APIClient {
-	if serverType == "sonarr" {
+	switch serverType {
+	case "sonarr":
 		return api.NewSonarrClient(url, apiKey)
+	case "lidarr":
+		return api.NewLidarrClient(url, apiKey)
+	case "readarr":
+		return api.NewReadarrClient(url, apiKey)
+	default:
+		return api.NewRadarrClient(url, apiKey)
 	}
-	return api.NewRadarrClient(url, apiKey)
 }
 
 // ServerManagerLogger is the interface for logging server connection tests.
@@ -112,10 +118,7 @@ func (m *ServerManager) AddServer(ctx context.Context, name, url, apiKey, server
 	}
 
 	// Validate server type matches
-	expectedApp := "Radarr"
-	if serverType == "sonarr" {
-		expectedApp = "Sonarr"
-	}
+	expectedApp := appName(dbType)
 	if status.AppName != expectedApp {
 		return nil, fmt.Errorf("server is %s, but %s was specified", status.AppName, expectedApp)
 	}
@@ -170,6 +173,44 @@ func (m *ServerManager) UpdateServer(ctx context.Context, id string, updates Ser
 		}
 	}
 
+	// Merge per-server limit overrides onto the current values
+	newLimits := server.Limits
+	if updates.MaxMissing != nil {
+		newLimits.MaxMissing = optionalLimit(*updates.MaxMissing)
+	}
+	if updates.MaxCutoff != nil {
+		newLimits.MaxCutoff = optionalLimit(*updates.MaxCutoff)
+	}
+	if updates.Weight != nil {
+		if *updates.Weight <= 0 {
+			return fmt.Errorf("invalid weight %v: must be greater than 0", *updates.Weight)
+		}
+		newLimits.Weight = *updates.Weight
+	}
+	if updates.NeverCutoff != nil {
+		newLimits.NeverCutoff = *updates.NeverCutoff
+	}
+
+	newPagination := server.Pagination
+	if updates.PageSize != nil {
+		if *updates.PageSize < 0 || *updates.PageSize > api.MaxPageSize {
+			return fmt.Errorf("invalid page size %d: must be between 0 and %d", *updates.PageSize, api.MaxPageSize)
+		}
+		newPagination.PageSize = *updates.PageSize
+	}
+	if updates.PageConcurrency != nil {
+		if *updates.PageConcurrency < 0 || *updates.PageConcurrency > api.MaxPageConcurrency {
+			return fmt.Errorf("invalid page concurrency %d: must be between 0 and %d", *updates.PageConcurrency, api.MaxPageConcurrency)
+		}
+		newPagination.Concurrency = *updates.PageConcurrency
+	}
+
+	if updates.Filters != nil {
+		if err := validateFilters(*updates.Filters); err != nil {
+			return err
+		}
+	}
+
 	// Test connection if URL or API key changed
 	if newURL != server.URL || newAPIKey != server.APIKey {
 		client := m.apiFactory(newURL, newAPIKey, string(server.Type))
@@ -190,8 +231,45 @@ func (m *ServerManager) UpdateServer(ctx context.Context, id string, updates Ser
 	if updates.APIKey != nil {
 		dbUpdate.APIKey = updates.APIKey
 	}
+	if updates.HasLimits() {
+		dbUpdate.Limits = &newLimits
+	}
+	if updates.HasPagination() {
+		dbUpdate.Pagination = &newPagination
+	}
+	dbUpdate.Filters = updates.Filters
+
+	if err := m.db.UpdateServer(id, dbUpdate); err != nil {
+		return err
+	}
 
-	return m.db.UpdateServer(id, dbUpdate)
+	// Wanted lists cached from the old URL may belong to a different server
+	if newURL != server.URL {
+		return m.db.ClearWantedCache(id)
+	}
+	return nil
+}
+
+// optionalLimit converts a requested cap to a stored override, where negative values clear it.
+func optionalLimit(n int) *int {
+	if n < 0 {
+		return nil
+	}
+	return &n
+}
+
+// validateFilters checks that a server's filters can match anything.
+func validateFilters(f database.ServerFilters) error {
+	if f.MinYear < 0 || f.MaxYear < 0 {
+		return fmt.Errorf("invalid year range: years must not be negative")
+	}
+	if f.MinYear != 0 && f.MaxYear != 0 && f.MinYear > f.MaxYear {
+		return fmt.Errorf("invalid year range: minimum year %d is after maximum year %d", f.MinYear, f.MaxYear)
+	}
+	if f.RecentlyAiredHours < 0 {
+		return fmt.Errorf("invalid recently aired hours %d: must not be negative", f.RecentlyAiredHours)
+	}
+	return nil
 }
 
 // RemoveServer removes a server by ID.
@@ -236,6 +314,9 @@ func (m *ServerManager) TestConnection(ctx context.Context, id string) (*Connect
 		m.logger.Info("Connection successful", "server", server.Name, "version", status.Version)
 	}
 
+	// A successful manual test closes the server's circuit so the next cycle uses it again
+	NewCircuitBreaker(m.db).RecordSuccess(server.ID)
+
 	return &ConnectionResult{
 		Success: true,
 		Version: status.Version,
@@ -247,7 +328,7 @@ func (m *ServerManager) TestConnection(ctx context.Context, id string) (*Connect
 func (m *ServerManager) TestNewConnection(ctx context.Context, url, apiKey, serverType string) (*ConnectionResult, error) {
 	// Validate server type
 	serverType = strings.ToLower(serverType)
-	if serverType != "radarr" && serverType != "sonarr" {
+	if _, err := parseServerType(serverType); err != nil {
 		return nil, fmt.Errorf("invalid server type: %s", serverType)
 	}
 
@@ -291,9 +372,15 @@ func (m *ServerManager) ListServers() ([]ServerInfo, error) {
 		return nil, err
 	}
 
+	// Health is informational, so servers are still listed if it cannot be read
+	health, _ := m.db.GetAllServerHealth()
+
 	result := make([]ServerInfo, len(servers))
 	for i, s := range servers {
 		result[i] = *toServerInfo(&s)
+		if h, ok := health[s.ID]; ok {
+			result[i].Health = h
+		}
 	}
 	return result, nil
 }
@@ -318,7 +405,9 @@ func (m *ServerManager) GetServer(ctx context.Context, idOrName string) (*Server
 		return nil, fmt.Errorf("server '%s' not found", idOrName)
 	}
 
-	return toServerInfo(server), nil
+	info := toServerInfo(server)
+	info.Health, _ = m.db.GetServerHealth(server.ID)
+	return info, nil
 }
 
 // GetServerWithCredentials retrieves a server by ID or name including the API key.
@@ -379,9 +468,15 @@ func (m *ServerManager) GetServersByType(serverType string) ([]ServerInfo, error
 		return nil, err
 	}
 
+	// Health is informational, so servers are still listed if it cannot be read
+	health, _ := m.db.GetAllServerHealth()
+
 	result := make([]ServerInfo, len(servers))
 	for i, s := range servers {
 		result[i] = *toServerInfo(&s)
+		if h, ok := health[s.ID]; ok {
+			result[i].Health = h
+		}
 	}
 	return result, nil
 }
@@ -389,13 +484,17 @@ func (m *ServerManager) GetServersByType(serverType string) ([]ServerInfo, error
 // toServerInfo converts a database.Server to a ServerInfo (without API key).
 func toServerInfo(s *database.Server) *ServerInfo {
 	return &ServerInfo{
-		ID:        s.ID,
-		Name:      s.Name,
-		URL:       s.URL,
-		Type:      string(s.Type),
-		Enabled:   s.Enabled,
-		CreatedAt: s.CreatedAt,
-		UpdatedAt: s.UpdatedAt,
+		ID:         s.ID,
+		Name:       s.Name,
+		URL:        s.URL,
+		Type:       string(s.Type),
+		Enabled:    s.Enabled,
+		Limits:     s.Limits,
+		Pagination: s.Pagination,
+		Filters:    s.Filters,
+		Health:     database.ServerHealth{State: database.ServerHealthy},
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
 	}
 }
 
@@ -406,7 +505,25 @@ func parseServerType(serverType string) (database.ServerType, error) {
 		return database.ServerTypeRadarr, nil
 	case "sonarr":
 		return database.ServerTypeSonarr, nil
+	case "lidarr":
+		return database.ServerTypeLidarr, nil
+	case "readarr":
+		return database.ServerTypeReadarr, nil
+	default:
+		return "", fmt.Errorf("invalid server type '%s': must be 'radarr', 'sonarr', 'lidarr' or 'readarr'", serverType)
+	}
+}
+
+// appName returns the application name a server of the given type reports in its system status.
+func appName(serverType database.ServerType) string {
+	switch serverType {
+	case database.ServerTypeSonarr:
+		return "Sonarr"
+	case database.ServerTypeLidarr:
+		return "Lidarr"
+	case database.ServerTypeReadarr:
+		return "Readarr"
 	default:
-		return "", fmt.Errorf("invalid server type '%s': must be 'radarr' or 'sonarr'", serverType)
+		return "Radarr"
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/edrobertsrayne/janitarr/src/api"
@@ -59,6 +60,18 @@ func mockSonarrServer() *httptest.Server {
 	}))
 }
 
+// mockV1Server creates a mock Lidarr/Readarr server for testing.
+func mockV1Server(appName string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/system/status" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"appName": "` + appName + `", "version": "2.0.0"}`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+}
+
 // mockFailingServer creates a mock server that always returns an error.
 func mockFailingServer() *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -124,6 +137,27 @@ func TestAddServer_Sonarr(t *testing.T) {
 	}
 }
 
+func TestAddServer_LidarrAndReadarr(t *testing.T) {
+	db := testDB(t)
+	mgr := NewServerManager(db, nil)
+
+	for _, tc := range []struct{ appName, serverType string }{
+		{"Lidarr", "lidarr"},
+		{"Readarr", "readarr"},
+	} {
+		server := mockV1Server(tc.appName)
+		defer server.Close()
+
+		info, err := mgr.AddServer(context.Background(), "Test "+tc.appName, server.URL, "test-api-key", tc.serverType)
+		if err != nil {
+			t.Fatalf("adding %s: unexpected error: %v", tc.serverType, err)
+		}
+		if info.Type != tc.serverType {
+			t.Errorf("expected type '%s', got '%s'", tc.serverType, info.Type)
+		}
+	}
+}
+
 func TestAddServer_DuplicateName(t *testing.T) {
 	db := testDB(t)
 	server := mockRadarrServer()
@@ -243,6 +277,144 @@ func TestUpdateServer_Success(t *testing.T) {
 	}
 }
 
+func TestUpdateServer_Limits(t *testing.T) {
+	db := testDB(t)
+	server := mockRadarrServer()
+	defer server.Close()
+
+	mgr := NewServerManager(db, nil)
+
+	info, err := mgr.AddServer(context.Background(), "Limited", server.URL, "test-api-key", "radarr")
+	if err != nil {
+		t.Fatalf("unexpected error adding server: %v", err)
+	}
+
+	maxMissing, maxCutoff := 5, 3
+	weight := 2.0
+	neverCutoff := true
+	err = mgr.UpdateServer(context.Background(), info.ID, ServerUpdate{
+		MaxMissing:  &maxMissing,
+		MaxCutoff:   &maxCutoff,
+		Weight:      &weight,
+		NeverCutoff: &neverCutoff,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error updating limits: %v", err)
+	}
+
+	// Clearing one cap must leave the other overrides untouched
+	clearCap := -1
+	if err := mgr.UpdateServer(context.Background(), info.ID, ServerUpdate{MaxCutoff: &clearCap}); err != nil {
+		t.Fatalf("unexpected error clearing cap: %v", err)
+	}
+
+	updated, err := mgr.GetServer(context.Background(), info.ID)
+	if err != nil {
+		t.Fatalf("unexpected error getting server: %v", err)
+	}
+	limits := updated.Limits
+	if limits.MaxMissing == nil || *limits.MaxMissing != 5 {
+		t.Errorf("expected max missing 5, got %v", limits.MaxMissing)
+	}
+	if limits.MaxCutoff != nil {
+		t.Errorf("expected max cutoff cleared, got %d", *limits.MaxCutoff)
+	}
+	if limits.Weight != 2 || !limits.NeverCutoff {
+		t.Errorf("expected weight 2 and never cutoff, got %+v", limits)
+	}
+
+	badWeight := 0.0
+	if err := mgr.UpdateServer(context.Background(), info.ID, ServerUpdate{Weight: &badWeight}); err == nil {
+		t.Error("expected error for non-positive weight")
+	}
+}
+
+func TestUpdateServer_Pagination(t *testing.T) {
+	db := testDB(t)
+	server := mockRadarrServer()
+	defer server.Close()
+
+	mgr := NewServerManager(db, nil)
+
+	info, err := mgr.AddServer(context.Background(), "Paged", server.URL, "test-api-key", "radarr")
+	if err != nil {
+		t.Fatalf("unexpected error adding server: %v", err)
+	}
+
+	pageSize, concurrency := 500, 8
+	err = mgr.UpdateServer(context.Background(), info.ID, ServerUpdate{PageSize: &pageSize, PageConcurrency: &concurrency})
+	if err != nil {
+		t.Fatalf("unexpected error updating pagination: %v", err)
+	}
+
+	// Resetting the page size must leave the concurrency untouched
+	reset := 0
+	if err := mgr.UpdateServer(context.Background(), info.ID, ServerUpdate{PageSize: &reset}); err != nil {
+		t.Fatalf("unexpected error resetting page size: %v", err)
+	}
+
+	updated, err := mgr.GetServer(context.Background(), info.ID)
+	if err != nil {
+		t.Fatalf("unexpected error getting server: %v", err)
+	}
+	if updated.Pagination.PageSize != 0 || updated.Pagination.Concurrency != 8 {
+		t.Errorf("expected default page size and concurrency 8, got %+v", updated.Pagination)
+	}
+
+	tooLarge, negative, tooMany := api.MaxPageSize+1, -1, api.MaxPageConcurrency+1
+	for _, update := range []ServerUpdate{
+		{PageSize: &tooLarge},
+		{PageConcurrency: &negative},
+		{PageConcurrency: &tooMany},
+	} {
+		if err := mgr.UpdateServer(context.Background(), info.ID, update); err == nil || !strings.Contains(err.Error(), "invalid") {
+			t.Errorf("expected invalid pagination error for %+v, got %v", update, err)
+		}
+	}
+}
+
+func TestUpdateServer_Filters(t *testing.T) {
+	db := testDB(t)
+	server := mockRadarrServer()
+	defer server.Close()
+
+	mgr := NewServerManager(db, nil)
+
+	info, err := mgr.AddServer(context.Background(), "Filtered", server.URL, "test-api-key", "radarr")
+	if err != nil {
+		t.Fatalf("unexpected error adding server: %v", err)
+	}
+
+	filters := database.ServerFilters{MonitoredOnly: true, IncludeQualityProfiles: []string{"HD-1080p"}, MinYear: 1990}
+	if err := mgr.UpdateServer(context.Background(), info.ID, ServerUpdate{Filters: &filters}); err != nil {
+		t.Fatalf("unexpected error updating filters: %v", err)
+	}
+
+	// Updates without filters leave them untouched
+	weight := 2.0
+	if err := mgr.UpdateServer(context.Background(), info.ID, ServerUpdate{Weight: &weight}); err != nil {
+		t.Fatalf("unexpected error updating weight: %v", err)
+	}
+
+	updated, err := mgr.GetServer(context.Background(), info.ID)
+	if err != nil {
+		t.Fatalf("unexpected error getting server: %v", err)
+	}
+	if !updated.Filters.MonitoredOnly || updated.Filters.MinYear != 1990 || len(updated.Filters.IncludeQualityProfiles) != 1 {
+		t.Errorf("expected filters to be kept, got %+v", updated.Filters)
+	}
+
+	for _, invalid := range []database.ServerFilters{
+		{MinYear: 2020, MaxYear: 2000},
+		{MaxYear: -1},
+		{RecentlyAiredHours: -6},
+	} {
+		if err := mgr.UpdateServer(context.Background(), info.ID, ServerUpdate{Filters: &invalid}); err == nil || !strings.Contains(err.Error(), "invalid") {
+			t.Errorf("expected invalid filters error for %+v, got %v", invalid, err)
+		}
+	}
+}
+
 func TestUpdateServer_NotFound(t *testing.T) {
 	db := testDB(t)
 	mgr := NewServerManager(db, nil)
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// PairServers pairs two servers of the same type that search the same indexers, so a title wanted
+// on both is searched according to the policy. Servers are given by ID or name. Pairing servers
+// that are already paired replaces their pair.
+func (m *ServerManager) PairServers(primary, secondary, policy string) (*ServerPairInfo, error) {
+	if !database.IsValidPairPolicy(policy) {
+		return nil, fmt.Errorf("%w: invalid pair policy %q: must be one of %s", ErrServerValidation, policy, pairPolicyNames())
+	}
+
+	first, err := m.GetServerWithCredentials(primary)
+	if err != nil {
+		return nil, err
+	}
+	second, err := m.GetServerWithCredentials(secondary)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case first.ID == second.ID:
+		return nil, fmt.Errorf("%w: a server can't be paired with itself", ErrServerValidation)
+	case first.Type != second.Type:
+		return nil, fmt.Errorf("%w: %s is a %s server but %s is a %s server", ErrServerValidation, first.Name, first.Type, second.Name, second.Type)
+	case first.Type != database.ServerTypeRadarr && first.Type != database.ServerTypeSonarr:
+		return nil, fmt.Errorf("%w: only Radarr and Sonarr servers can be paired", ErrServerValidation)
+	}
+
+	if err := m.db.SetServerPair(first.ID, second.ID, database.PairPolicy(policy)); err != nil {
+		return nil, err
+	}
+	return &ServerPairInfo{
+		PrimaryID:     first.ID,
+		PrimaryName:   first.Name,
+		SecondaryID:   second.ID,
+		SecondaryName: second.Name,
+		Type:          string(first.Type),
+		Policy:        policy,
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+// ListServerPairs returns all server pairs.
+func (m *ServerManager) ListServerPairs() ([]ServerPairInfo, error) {
+	pairs, err := m.db.GetServerPairs()
+	if err != nil {
+		return nil, err
+	}
+	servers, err := m.db.GetAllServers()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]database.Server, len(servers))
+	for _, server := range servers {
+		byID[server.ID] = server
+	}
+
+	infos := make([]ServerPairInfo, 0, len(pairs))
+	for _, pair := range pairs {
+		primary, secondary := byID[pair.PrimaryID], byID[pair.SecondaryID]
+		infos = append(infos, ServerPairInfo{
+			PrimaryID:     pair.PrimaryID,
+			PrimaryName:   primary.Name,
+			SecondaryID:   pair.SecondaryID,
+			SecondaryName: secondary.Name,
+			Type:          string(primary.Type),
+			Policy:        string(pair.Policy),
+			CreatedAt:     pair.CreatedAt,
+		})
+	}
+	return infos, nil
+}
+
+// UnpairServers removes the pair of two servers, given by ID or name in either order.
+func (m *ServerManager) UnpairServers(server, other string) error {
+	first, err := m.GetServerWithCredentials(server)
+	if err != nil {
+		return err
+	}
+	second, err := m.GetServerWithCredentials(other)
+	if err != nil {
+		return err
+	}
+
+	removed, err := m.db.DeleteServerPair(first.ID, second.ID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("pair of '%s' and '%s' not found", first.Name, second.Name)
+	}
+	return nil
+}
+
+// pairPolicyNames lists the supported pair policies for error messages.
+func pairPolicyNames() string {
+	names := make([]string, len(database.PairPolicies))
+	for i, policy := range database.PairPolicies {
+		names[i] = string(policy)
+	}
+	return strings.Join(names, ", ")
+}
+
+// applyPairs returns a copy of the detection results in which each title wanted on both servers
+// of a pair is only left on the server that should search it this cycle, along with the items
+// that were dropped. Titles are matched by their TMDB, IMDb or TVDB ID within each category.
+// Pairs run before the cooldown, so a title cooling down on the server whose turn it is waits
+// rather than being searched on the other server.
+// If the pairs cannot be read, the results are left unfiltered.
+func (s *SearchTrigger) applyPairs(detectionResults *DetectionResults) (*DetectionResults, []DedupedItem) {
+	pairs, err := s.db.GetServerPairs()
+	if err != nil || len(pairs) == 0 {
+		return detectionResults, nil
+	}
+
+	index := make(map[string]int, len(detectionResults.Results))
+	for i, result := range detectionResults.Results {
+		if result.Error == "" {
+			index[result.ServerID] = i
+		}
+	}
+
+	deduped := *detectionResults
+	deduped.Results = slices.Clone(detectionResults.Results)
+	var dropped []DedupedItem
+	for _, pair := range pairs {
+		p, primaryOK := index[pair.PrimaryID]
+		q, secondaryOK := index[pair.SecondaryID]
+		if !primaryOK || !secondaryOK || pair.Policy == database.PairSearchBoth {
+			continue
+		}
+		for _, category := range []database.SearchCategory{database.SearchCategoryMissing, database.SearchCategoryCutoff} {
+			dropped = append(dropped, s.dedupPair(pair.Policy, &deduped.Results[p], &deduped.Results[q], category)...)
+		}
+	}
+
+	return &deduped, dropped
+}
+
+// dedupPair drops one category's items wanted on both servers of a pair from the server that
+// shouldn't search them. With prefer-primary that is always the secondary server; with stagger
+// it is the server that searched the title more recently, so the servers take turns.
+func (s *SearchTrigger) dedupPair(policy database.PairPolicy, primary, secondary *DetectionResult, category database.SearchCategory) []DedupedItem {
+	primaryIDs, primaryItems := primary.wanted(category)
+	secondaryIDs, secondaryItems := secondary.wanted(category)
+
+	byExternalID := make(map[string]int, len(*primaryIDs))
+	for _, id := range *primaryIDs {
+		if key := primaryItems[id].ExternalID(); key != "" {
+			byExternalID[key] = id
+		}
+	}
+	if len(byExternalID) == 0 {
+		return nil
+	}
+
+	var primaryHistory, secondaryHistory map[int]time.Time
+	if policy == database.PairStagger {
+		primaryHistory, _ = s.db.GetSearchHistory(primary.ServerID, category)
+		secondaryHistory, _ = s.db.GetSearchHistory(secondary.ServerID, category)
+	}
+
+	dropPrimary := make(map[int]bool)
+	dropSecondary := make(map[int]bool)
+	var dropped []DedupedItem
+	for _, id := range *secondaryIDs {
+		primaryID, ok := byExternalID[secondaryItems[id].ExternalID()]
+		if !ok {
+			continue
+		}
+
+		// Ties, such as a title neither server has searched, go to the primary server
+		if policy == database.PairStagger && secondaryHistory[id].Before(primaryHistory[primaryID]) {
+			dropPrimary[primaryID] = true
+			dropped = append(dropped, dedupedItem(primary, secondary, primaryItems[primaryID], primaryID, category, policy))
+			continue
+		}
+		dropSecondary[id] = true
+		dropped = append(dropped, dedupedItem(secondary, primary, secondaryItems[id], id, category, policy))
+	}
+
+	*primaryIDs = slices.DeleteFunc(slices.Clone(*primaryIDs), func(id int) bool { return dropPrimary[id] })
+	*secondaryIDs = slices.DeleteFunc(slices.Clone(*secondaryIDs), func(id int) bool { return dropSecondary[id] })
+	return dropped
+}
+
+// wanted returns the item IDs and metadata of one of the result's wanted lists.
+func (r *DetectionResult) wanted(category database.SearchCategory) (*[]int, map[int]api.MediaItem) {
+	if category == database.SearchCategoryCutoff {
+		return &r.Cutoff, r.CutoffItems
+	}
+	return &r.Missing, r.MissingItems
+}
+
+// dedupedItem describes an item dropped from a server in favour of its paired server.
+func dedupedItem(dropped, kept *DetectionResult, item api.MediaItem, id int, category database.SearchCategory, policy database.PairPolicy) DedupedItem {
+	return DedupedItem{
+		ServerName: dropped.ServerName,
+		PairedWith: kept.ServerName,
+		ItemID:     id,
+		Title:      item.Title,
+		Category:   string(category),
+		Policy:     string(policy),
+	}
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+func TestPairServers_Validation(t *testing.T) {
+	db := testTriggerDB(t)
+	for _, server := range []struct {
+		name       string
+		serverType database.ServerType
+	}{
+		{"radarr", database.ServerTypeRadarr},
+		{"radarr-4k", database.ServerTypeRadarr},
+		{"sonarr", database.ServerTypeSonarr},
+		{"lidarr", database.ServerTypeLidarr},
+		{"lidarr-2", database.ServerTypeLidarr},
+	} {
+		if _, err := db.AddServer(server.name, "http://"+server.name+":8080", "key", server.serverType); err != nil {
+			t.Fatalf("adding server: %v", err)
+		}
+	}
+	manager := NewServerManagerWithFactory(db, nil, nil)
+
+	tests := []struct {
+		name      string
+		primary   string
+		secondary string
+		policy    string
+	}{
+		{"unknown policy", "radarr", "radarr-4k", "sometimes"},
+		{"same server", "radarr", "radarr", "stagger"},
+		{"different types", "radarr", "sonarr", "stagger"},
+		{"no external IDs", "lidarr", "lidarr-2", "stagger"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := manager.PairServers(tt.primary, tt.secondary, tt.policy); !errors.Is(err, ErrServerValidation) {
+				t.Errorf("expected a validation error, got %v", err)
+			}
+		})
+	}
+
+	pair, err := manager.PairServers("radarr", "radarr-4k", "prefer-primary")
+	if err != nil {
+		t.Fatalf("PairServers failed: %v", err)
+	}
+	if pair.PrimaryName != "radarr" || pair.SecondaryName != "radarr-4k" || pair.Type != "radarr" {
+		t.Errorf("unexpected pair: %+v", pair)
+	}
+	if err := manager.UnpairServers("radarr-4k", "radarr"); err != nil {
+		t.Errorf("UnpairServers failed: %v", err)
+	}
+	if err := manager.UnpairServers("radarr-4k", "radarr"); err == nil {
+		t.Error("expected an error unpairing servers that aren't paired")
+	}
+}
+
+func TestTriggerSearches_PairedServers(t *testing.T) {
+	db := testTriggerDB(t)
+	radarr, err := db.AddServer("radarr", "http://localhost:7878", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	radarr4k, err := db.AddServer("radarr-4k", "http://localhost:7879", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	config := db.GetAppConfig()
+	config.Search.CooldownHours = 0
+	if err := db.SetAppConfig(config); err != nil {
+		t.Fatalf("setting config: %v", err)
+	}
+
+	clients := map[string]*mockTriggerAPIClient{radarr.URL: {}, radarr4k.URL: {}}
+	trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+		return clients[url]
+	}, &mockSearchTriggerLogger{})
+
+	// The Matrix is wanted on both servers; the other movies on only one of them
+	movie := func(id, tmdbID int, title string) api.MediaItem {
+		return api.MediaItem{ID: id, Title: title, Type: "movie", TmdbID: tmdbID}
+	}
+	detection := &DetectionResults{
+		Results: []DetectionResult{
+			{
+				ServerID: radarr.ID, ServerName: "radarr", ServerType: "radarr", Missing: []int{1, 2}, Cutoff: []int{},
+				MissingItems: map[int]api.MediaItem{1: movie(1, 603, "The Matrix"), 2: movie(2, 604, "The Matrix Reloaded")},
+			},
+			{
+				ServerID: radarr4k.ID, ServerName: "radarr-4k", ServerType: "radarr", Missing: []int{10, 11}, Cutoff: []int{},
+				MissingItems: map[int]api.MediaItem{10: movie(10, 603, "The Matrix"), 11: movie(11, 78, "Blade Runner")},
+			},
+		},
+		TotalMissing: 4,
+		SuccessCount: 2,
+	}
+	limits := database.SearchLimits{MissingMoviesLimit: 10}
+
+	run := func(policy database.PairPolicy) (*TriggerResults, []int, []int) {
+		t.Helper()
+		if err := db.SetServerPair(radarr.ID, radarr4k.ID, policy); err != nil {
+			t.Fatalf("SetServerPair failed: %v", err)
+		}
+		for _, client := range clients {
+			client.triggerCalls = nil
+		}
+		results, err := trigger.TriggerSearches(context.Background(), detection, limits, false)
+		if err != nil {
+			t.Fatalf("TriggerSearches failed: %v", err)
+		}
+		searched := func(client *mockTriggerAPIClient) []int {
+			var ids []int
+			for _, call := range client.getTriggerCalls() {
+				ids = append(ids, call...)
+			}
+			slices.Sort(ids)
+			return ids
+		}
+		return results, searched(clients[radarr.URL]), searched(clients[radarr4k.URL])
+	}
+
+	// Preferring the primary server only searches the duplicate there
+	results, primary, secondary := run(database.PairPreferPrimary)
+	if !slices.Equal(primary, []int{1, 2}) || !slices.Equal(secondary, []int{11}) {
+		t.Errorf("expected The Matrix only searched on radarr, got %v and %v", primary, secondary)
+	}
+	want := DedupedItem{ServerName: "radarr-4k", PairedWith: "radarr", ItemID: 10, Title: "The Matrix", Category: "missing", Policy: "prefer-primary"}
+	if len(results.Deduplicated) != 1 || results.Deduplicated[0] != want {
+		t.Errorf("expected The Matrix to be reported as deduplicated, got %v", results.Deduplicated)
+	}
+
+	// Staggering gives the duplicate to the server that searched it least recently
+	_, primary, secondary = run(database.PairStagger)
+	if !slices.Equal(primary, []int{2}) || !slices.Equal(secondary, []int{10, 11}) {
+		t.Errorf("expected The Matrix searched on radarr-4k for its turn, got %v and %v", primary, secondary)
+	}
+	_, primary, secondary = run(database.PairStagger)
+	if !slices.Equal(primary, []int{1, 2}) || !slices.Equal(secondary, []int{11}) {
+		t.Errorf("expected The Matrix back on radarr, got %v and %v", primary, secondary)
+	}
+
+	// Searching both servers leaves the duplicate alone
+	results, primary, secondary = run(database.PairSearchBoth)
+	if len(results.Deduplicated) != 0 || !slices.Equal(primary, []int{1, 2}) || !slices.Equal(secondary, []int{10, 11}) {
+		t.Errorf("expected every item searched, got %v and %v (deduplicated %v)", primary, secondary, results.Deduplicated)
+	}
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/logger"
+)
+
+// TargetedSearchRequest asks for detection and searches on a single server, optionally
+// restricted to specific items or one search category.
+type TargetedSearchRequest struct {
+	ServerID string
+	ItemIDs  []int                   // Only search these items (empty = any wanted item, within the configured limits)
+	Category database.SearchCategory // Only search this category ("" = missing and cutoff)
+}
+
+// TargetedSearchDetector defines the interface for detecting a single server.
+type TargetedSearchDetector interface {
+	DetectServer(ctx context.Context, serverID string) (*DetectionResult, error)
+}
+
+// TargetedSearchLogger defines the interface for logging targeted searches.
+type TargetedSearchLogger interface {
+	LogDetectionComplete(serverName, serverType string, missing, cutoffUnmet int) *logger.LogEntry
+	LogSearches(serverName, serverType, category string, count int, isManual bool) *logger.LogEntry
+	LogSearchError(serverName, serverType, category, reason string) *logger.LogEntry
+}
+
+// TargetedSearch runs detection and searches for one server outside the regular schedule,
+// for example when a Radarr or Sonarr webhook arrives.
+type TargetedSearch struct {
+	db        AutomationDB
+	detector  TargetedSearchDetector
+	trigger   AutomationSearchTrigger
+	scheduler *Scheduler // Optional; when set, requests wait for any active cycle to finish
+	logger    TargetedSearchLogger
+}
+
+// NewTargetedSearch creates a new TargetedSearch service.
+func NewTargetedSearch(db AutomationDB, detector TargetedSearchDetector, trigger AutomationSearchTrigger, scheduler *Scheduler, appLogger TargetedSearchLogger) *TargetedSearch {
+	return &TargetedSearch{
+		db:        db,
+		detector:  detector,
+		trigger:   trigger,
+		scheduler: scheduler,
+		logger:    appLogger,
+	}
+}
+
+// Run performs the request. If an automation cycle is active it waits for it to finish
+// rather than failing, and no cycle can start until the request is done.
+func (t *TargetedSearch) Run(ctx context.Context, req TargetedSearchRequest) (*TriggerResults, error) {
+	var results *TriggerResults
+	search := func(ctx context.Context) error {
+		var err error
+		results, err = t.search(ctx, req)
+		return err
+	}
+
+	if t.scheduler == nil {
+		return results, search(ctx)
+	}
+	err := t.scheduler.RunExclusive(ctx, search)
+	return results, err
+}
+
+// search detects the server and triggers searches for the requested items.
+func (t *TargetedSearch) search(ctx context.Context, req TargetedSearchRequest) (*TriggerResults, error) {
+	result, err := t.detector.DetectServer(ctx, req.ServerID)
+	if err != nil {
+		return nil, err
+	}
+	if result.Skipped != "" {
+		return nil, fmt.Errorf("server %s %s", result.ServerName, result.Skipped)
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("server %s detection failed: %s", result.ServerName, result.Error)
+	}
+	t.logger.LogDetectionComplete(result.ServerName, result.ServerType, len(result.Missing), len(result.Cutoff))
+
+	filtered := filterDetectionResult(*result, req)
+	detectionResults := &DetectionResults{
+		Results:      []DetectionResult{filtered},
+		TotalMissing: len(filtered.Missing),
+		TotalCutoff:  len(filtered.Cutoff),
+		SuccessCount: 1,
+		Targeted:     len(req.ItemIDs) > 0,
+	}
+
+	// Specific items are searched even if they exceed the cycle limits; per-server caps still apply
+	limits := t.db.GetAppConfig().SearchLimits
+	if len(req.ItemIDs) > 0 {
+		limits = database.SearchLimits{
+			MissingMoviesLimit:   len(filtered.Missing),
+			MissingEpisodesLimit: len(filtered.Missing),
+			CutoffMoviesLimit:    len(filtered.Cutoff),
+			CutoffEpisodesLimit:  len(filtered.Cutoff),
+		}
+	}
+
+	triggerResults, err := t.trigger.TriggerSearches(ctx, detectionResults, limits, false)
+	if err != nil {
+		return nil, fmt.Errorf("triggering searches failed: %w", err)
+	}
+
+	for _, r := range triggerResults.Results {
+		if !r.Success {
+			t.logger.LogSearchError(r.ServerName, r.ServerType, r.Category, r.Error)
+		} else if len(r.ItemIDs) > 0 {
+			t.logger.LogSearches(r.ServerName, r.ServerType, r.Category, r.SearchCount(), true)
+		}
+	}
+	t.reportSkipped(req, *result, filtered, triggerResults)
+
+	return triggerResults, nil
+}
+
+// reportSkipped warns when items named in the request were not searched: because the server
+// doesn't want them, they are already downloading, or the server's download queue is full.
+func (t *TargetedSearch) reportSkipped(req TargetedSearchRequest, result, filtered DetectionResult, triggerResults *TriggerResults) {
+	warn, ok := t.logger.(warnLogger)
+	if !ok || len(req.ItemIDs) == 0 {
+		return
+	}
+
+	notWanted := len(req.ItemIDs) - len(filtered.Missing) - len(filtered.Cutoff)
+	if notWanted > 0 || triggerResults.QueueSkipped > 0 || len(triggerResults.QueueFullServers) > 0 {
+		warn.Warn("Some requested items were not searched",
+			"server", result.ServerName,
+			"requested", len(req.ItemIDs),
+			"notWanted", notWanted,
+			"downloading", triggerResults.QueueSkipped,
+			"queueFull", len(triggerResults.QueueFullServers) > 0)
+	}
+}
+
+// filterDetectionResult returns a copy of the result limited to the requested category and items.
+func filterDetectionResult(result DetectionResult, req TargetedSearchRequest) DetectionResult {
+	keep := func(category database.SearchCategory, ids []int) []int {
+		if req.Category != "" && req.Category != category {
+			return []int{}
+		}
+		if len(req.ItemIDs) == 0 {
+			return ids
+		}
+		kept := []int{}
+		for _, id := range ids {
+			if slices.Contains(req.ItemIDs, id) {
+				kept = append(kept, id)
+			}
+		}
+		return kept
+	}
+
+	result.Missing = keep(database.SearchCategoryMissing, result.Missing)
+	result.Cutoff = keep(database.SearchCategoryCutoff, result.Cutoff)
+	return result
+}
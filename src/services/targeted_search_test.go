@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/logger"
+)
+
+// mockTargetedSearchLogger is a no-op implementation of TargetedSearchLogger for testing.
+type mockTargetedSearchLogger struct{}
+
+func (m *mockTargetedSearchLogger) LogDetectionComplete(serverName, serverType string, missing, cutoffUnmet int) *logger.LogEntry {
+	return nil
+}
+
+func (m *mockTargetedSearchLogger) LogSearches(serverName, serverType, category string, count int, isManual bool) *logger.LogEntry {
+	return nil
+}
+
+func (m *mockTargetedSearchLogger) LogSearchError(serverName, serverType, category, reason string) *logger.LogEntry {
+	return nil
+}
+
+// newTestTargetedSearch builds a TargetedSearch over one Radarr server with items 1-5 missing and 10 cutoff unmet.
+func newTestTargetedSearch(t *testing.T, scheduler *Scheduler) (*TargetedSearch, *database.Server, *mockTriggerAPIClient) {
+	t.Helper()
+	db := testTriggerDB(t)
+
+	server, err := db.AddServer("radarr1", "http://localhost:7878", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	config := db.GetAppConfig()
+	config.SearchLimits = database.SearchLimits{MissingMoviesLimit: 2, CutoffMoviesLimit: 1}
+	if err := db.SetAppConfig(config); err != nil {
+		t.Fatalf("SetAppConfig failed: %v", err)
+	}
+
+	detectorClient := &mockDetectorClient{cutoff: []api.MediaItem{{ID: 10, Type: "movie"}}}
+	for id := 1; id <= 5; id++ {
+		detectorClient.missing = append(detectorClient.missing, api.MediaItem{ID: id, Type: "movie"})
+	}
+	detector := NewDetectorWithFactory(db, func(url, apiKey, serverType string) DetectorAPIClient {
+		return detectorClient
+	})
+
+	triggerClient := &mockTriggerAPIClient{serverType: "radarr"}
+	trigger := NewSearchTriggerWithFactory(db, func(url, apiKey, serverType string) SearchTriggerAPIClient {
+		return triggerClient
+	}, &mockSearchTriggerLogger{})
+
+	return NewTargetedSearch(db, detector, trigger, scheduler, &mockTargetedSearchLogger{}), server, triggerClient
+}
+
+func TestTargetedSearch_WholeServerUsesLimits(t *testing.T) {
+	search, server, client := newTestTargetedSearch(t, nil)
+
+	results, err := search.Run(context.Background(), TargetedSearchRequest{ServerID: server.ID})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if results.MissingTriggered != 2 || results.CutoffTriggered != 1 {
+		t.Errorf("expected 2 missing and 1 cutoff searches, got %d and %d", results.MissingTriggered, results.CutoffTriggered)
+	}
+	if len(client.getTriggerCalls()) != 2 {
+		t.Errorf("expected 2 trigger calls, got %d", len(client.getTriggerCalls()))
+	}
+}
+
+func TestTargetedSearch_SpecificItems(t *testing.T) {
+	search, server, client := newTestTargetedSearch(t, nil)
+
+	// Item 99 is not wanted and is ignored; the rest are searched beyond the cycle limit of 2
+	req := TargetedSearchRequest{ServerID: server.ID, ItemIDs: []int{1, 3, 5, 99}, Category: database.SearchCategoryMissing}
+	results, err := search.Run(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if results.MissingTriggered != 3 || results.CutoffTriggered != 0 {
+		t.Errorf("expected 3 missing and 0 cutoff searches, got %d and %d", results.MissingTriggered, results.CutoffTriggered)
+	}
+
+	calls := client.getTriggerCalls()
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 trigger call, got %d", len(calls))
+	}
+	searched := slices.Clone(calls[0])
+	slices.Sort(searched)
+	if !slices.Equal(searched, []int{1, 3, 5}) {
+		t.Errorf("expected items [1 3 5], got %v", searched)
+	}
+}
+
+func TestTargetedSearch_SpecificItemsIgnoreCooldownAndGiveUp(t *testing.T) {
+	search, server, client := newTestTargetedSearch(t, nil)
+	db := search.db.(*database.DB)
+
+	config := db.GetAppConfig()
+	config.Search.CooldownHours = 24
+	config.Search.GiveUpAttempts = 2
+	if err := db.SetAppConfig(config); err != nil {
+		t.Fatalf("SetAppConfig failed: %v", err)
+	}
+	// Item 1 was searched an hour ago; item 2 has been searched too often to be tried again yet
+	searchTimes(t, db, server.ID, 1, 1, time.Now().Add(-time.Hour))
+	searchTimes(t, db, server.ID, 2, 2, time.Now().Add(-30*time.Hour))
+
+	req := TargetedSearchRequest{ServerID: server.ID, ItemIDs: []int{1, 2}}
+	results, err := search.Run(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if results.MissingTriggered != 2 || results.CooldownSkipped != 0 || results.GiveUpSkipped != 0 {
+		t.Errorf("expected both items to be searched, got %d (%d cooling down, %d given up)",
+			results.MissingTriggered, results.CooldownSkipped, results.GiveUpSkipped)
+	}
+
+	// A search of the whole server still respects them
+	client.triggerCalls = nil
+	results, err = search.Run(context.Background(), TargetedSearchRequest{ServerID: server.ID, Category: database.SearchCategoryMissing})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if results.CooldownSkipped == 0 && results.GiveUpSkipped == 0 {
+		t.Error("expected a whole-server search to skip recently searched or given up items")
+	}
+}
+
+func TestTargetedSearch_UnknownServer(t *testing.T) {
+	search, _, _ := newTestTargetedSearch(t, nil)
+
+	if _, err := search.Run(context.Background(), TargetedSearchRequest{ServerID: "missing"}); err == nil {
+		t.Error("expected error for unknown server")
+	}
+}
+
+func TestTargetedSearch_WaitsForActiveCycle(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	scheduler := NewScheduler(nil, 1, func(ctx context.Context, isManual bool) error {
+		close(started)
+		<-release
+		return nil
+	})
+	search, server, client := newTestTargetedSearch(t, scheduler)
+
+	go func() { _ = scheduler.TriggerManual(context.Background()) }()
+	<-started
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := search.Run(context.Background(), TargetedSearchRequest{ServerID: server.ID})
+		done <- err
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("targeted search ran while a cycle was active")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("targeted search did not run after the cycle finished")
+	}
+	if len(client.getTriggerCalls()) == 0 {
+		t.Error("expected searches to be triggered")
+	}
+}
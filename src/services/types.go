@@ -7,6 +7,7 @@ import (
 
 	"github.com/edrobertsrayne/janitarr/src/api"
 	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/logger"
 )
 
 // Error constants for server operations
@@ -20,20 +21,61 @@ var (
 
 // ServerInfo represents a server for display (without API key).
 type ServerInfo struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	URL       string    `json:"url"`
-	Type      string    `json:"type"`
-	Enabled   bool      `json:"enabled"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID         string                    `json:"id"`
+	Name       string                    `json:"name"`
+	URL        string                    `json:"url"`
+	Type       string                    `json:"type"`
+	Enabled    bool                      `json:"enabled"`
+	Limits     database.ServerLimits     `json:"limits"`
+	Pagination database.ServerPagination `json:"pagination"`
+	Filters    database.ServerFilters    `json:"filters"`
+	Health     database.ServerHealth     `json:"health"`
+	CreatedAt  time.Time                 `json:"createdAt"`
+	UpdatedAt  time.Time                 `json:"updatedAt"`
+}
+
+// ServerPairInfo represents a pair of servers searching the same indexers, for display.
+type ServerPairInfo struct {
+	PrimaryID     string    `json:"primaryId"`
+	PrimaryName   string    `json:"primaryName"`
+	SecondaryID   string    `json:"secondaryId"`
+	SecondaryName string    `json:"secondaryName"`
+	Type          string    `json:"type"`
+	Policy        string    `json:"policy"`
+	CreatedAt     time.Time `json:"createdAt"`
 }
 
 // ServerUpdate represents optional fields for updating a server.
+// A negative MaxMissing or MaxCutoff removes that per-server cap, a PageSize or
+// PageConcurrency of 0 restores the default, and Filters replaces all of the server's filters.
 type ServerUpdate struct {
-	Name   *string `json:"name,omitempty"`
-	URL    *string `json:"url,omitempty"`
-	APIKey *string `json:"apiKey,omitempty"`
+	Name        *string  `json:"name,omitempty"`
+	URL         *string  `json:"url,omitempty"`
+	APIKey      *string  `json:"apiKey,omitempty"`
+	MaxMissing  *int     `json:"maxMissing,omitempty"`
+	MaxCutoff   *int     `json:"maxCutoff,omitempty"`
+	Weight      *float64 `json:"weight,omitempty"`
+	NeverCutoff *bool    `json:"neverCutoff,omitempty"`
+
+	PageSize        *int `json:"pageSize,omitempty"`
+	PageConcurrency *int `json:"pageConcurrency,omitempty"`
+
+	Filters *database.ServerFilters `json:"filters,omitempty"`
+}
+
+// HasLimits reports whether the update changes any per-server search limits.
+func (u ServerUpdate) HasLimits() bool {
+	return u.MaxMissing != nil || u.MaxCutoff != nil || u.Weight != nil || u.NeverCutoff != nil
+}
+
+// HasPagination reports whether the update changes how the server's wanted lists are fetched.
+func (u ServerUpdate) HasPagination() bool {
+	return u.PageSize != nil || u.PageConcurrency != nil
+}
+
+// HasFilters reports whether the update changes which wanted items are searched.
+func (u ServerUpdate) HasFilters() bool {
+	return u.Filters != nil
 }
 
 // ConnectionResult represents the result of testing a server connection.
@@ -54,6 +96,14 @@ type DetectionResult struct {
 	MissingItems map[int]api.MediaItem `json:"missingItems,omitempty"` // Item metadata indexed by ID
 	CutoffItems  map[int]api.MediaItem `json:"cutoffItems,omitempty"`  // Item metadata indexed by ID
 	Error        string                `json:"error,omitempty"`
+	Skipped      string                `json:"skipped,omitempty"`  // Why the server was not contacted, e.g. an open circuit
+	Refresh      string                `json:"refresh,omitempty"`  // How the wanted lists were read: RefreshFull, RefreshDelta or RefreshCached
+	CachedAt     *time.Time            `json:"cachedAt,omitempty"` // When cached results were last brought up to date
+	Filtered     int                   `json:"filtered,omitempty"` // Items left out by the server's filters
+	Excluded     int                   `json:"excluded,omitempty"` // Items left out by the server's exclusions
+
+	Unavailable      int `json:"unavailable,omitempty"`      // Items left out because they are not released or available yet
+	RecentlyReleased int `json:"recentlyReleased,omitempty"` // Items left out because they were released or aired within the grace period
 }
 
 // DetectionResults represents aggregated detection results.
@@ -63,23 +113,37 @@ type DetectionResults struct {
 	TotalCutoff  int               `json:"totalCutoff"`
 	SuccessCount int               `json:"successCount"`
 	FailureCount int               `json:"failureCount"`
+	SkippedCount int               `json:"skippedCount"`
+	Targeted     bool              `json:"targeted,omitempty"` // Items were named by the caller, e.g. a webhook, so cooldown and give-up don't apply
 }
 
 // TriggerResult represents the result of triggering searches for one category on one server.
 type TriggerResult struct {
-	ServerID       string `json:"serverId"`
-	ServerName     string `json:"serverName"`
-	ServerType     string `json:"serverType"`
-	Category       string `json:"category"` // "missing" or "cutoff"
-	ItemIDs        []int  `json:"itemIDs"`
-	Success        bool   `json:"success"`
-	Error          string `json:"error,omitempty"`
-	Title          string `json:"title,omitempty"`          // Movie title or episode title
-	Year           int    `json:"year,omitempty"`           // For movies
-	SeriesTitle    string `json:"seriesTitle,omitempty"`    // For episodes
-	SeasonNumber   int    `json:"seasonNumber,omitempty"`   // For episodes
-	EpisodeNumber  int    `json:"episodeNumber,omitempty"`  // For episodes
-	QualityProfile string `json:"qualityProfile,omitempty"` // Quality profile name
+	ServerID       string                 `json:"serverId"`
+	ServerName     string                 `json:"serverName"`
+	ServerType     string                 `json:"serverType"`
+	Category       string                 `json:"category"` // "missing" or "cutoff"
+	ItemIDs        []int                  `json:"itemIDs"`
+	Success        bool                   `json:"success"`
+	Error          string                 `json:"error,omitempty"`
+	Title          string                 `json:"title,omitempty"`          // Movie title or episode title
+	Year           int                    `json:"year,omitempty"`           // For movies
+	SeriesTitle    string                 `json:"seriesTitle,omitempty"`    // For episodes
+	SeasonNumber   int                    `json:"seasonNumber,omitempty"`   // For episodes
+	EpisodeNumber  int                    `json:"episodeNumber,omitempty"`  // For episodes
+	QualityProfile string                 `json:"qualityProfile,omitempty"` // Quality profile name
+	Strategy       database.SelectionMode `json:"strategy,omitempty"`       // Selection strategy that picked the items
+	CommandID      int                    `json:"commandId,omitempty"`      // Server command running the search, for outcome tracking
+	Command        string                 `json:"command,omitempty"`        // Sonarr search command: EpisodeSearch, SeasonSearch or SeriesSearch
+}
+
+// SearchCount returns how many searches the result counts against the search limits:
+// one for a season or series search, otherwise one per item.
+func (r TriggerResult) SearchCount() int {
+	if r.Command == api.CommandSeasonSearch || r.Command == api.CommandSeriesSearch {
+		return 1
+	}
+	return len(r.ItemIDs)
 }
 
 // TriggerResults represents aggregated trigger results.
@@ -89,20 +153,52 @@ type TriggerResults struct {
 	CutoffTriggered  int             `json:"cutoffTriggered"`
 	SuccessCount     int             `json:"successCount"`
 	FailureCount     int             `json:"failureCount"`
+	CooldownSkipped  int             `json:"cooldownSkipped"` // Items skipped because they were searched recently
+	QueueSkipped     int             `json:"queueSkipped"`    // Items skipped because they are already downloading
+	GiveUpSkipped    int             `json:"giveUpSkipped"`   // Items skipped because they were searched too often without a grab
+
+	RequestedTriggered int `json:"requestedTriggered"` // Items searched because they were requested in Overseerr or Jellyseerr
+
+	QueueFullServers []string `json:"queueFullServers,omitempty"` // Servers not searched because their download queue was full
+
+	Deduplicated []DedupedItem `json:"deduplicated,omitempty"` // Items left to a paired server wanting the same title
+
+	Budgets []SearchBudget `json:"budgets"` // Searches triggered against each search limit
+}
+
+// DedupedItem is an item not searched on its server because a paired server wants the same title
+// and the pair's policy gave the search to that server.
+type DedupedItem struct {
+	ServerName string `json:"serverName"` // Server the item was not searched on
+	PairedWith string `json:"pairedWith"` // Paired server searching the title instead
+	ItemID     int    `json:"itemId"`
+	Title      string `json:"title"`
+	Category   string `json:"category"`
+	Policy     string `json:"policy"`
+}
+
+// SearchBudget reports how much of one search limit a cycle used.
+type SearchBudget struct {
+	Limit     string `json:"limit"`     // Limit key, e.g. "limits.missing.movies"
+	Used      int    `json:"used"`      // Searches triggered against the limit
+	Available int    `json:"available"` // The configured limit
 }
 
 // SchedulerStatus represents the current state of the scheduler.
 type SchedulerStatus struct {
-	IsRunning     bool       `json:"isRunning"`
-	IsCycleActive bool       `json:"isCycleActive"`
-	NextRun       *time.Time `json:"nextRun,omitempty"`
-	LastRun       *time.Time `json:"lastRun,omitempty"`
-	IntervalHours int        `json:"intervalHours"`
+	IsRunning     bool        `json:"isRunning"`
+	IsCycleActive bool        `json:"isCycleActive"`
+	NextRun       *time.Time  `json:"nextRun,omitempty"`
+	LastRun       *time.Time  `json:"lastRun,omitempty"`
+	IntervalHours int         `json:"intervalHours"`
+	Cron          string      `json:"cron,omitempty"`         // Cron expression in use, if any
+	UpcomingRuns  []time.Time `json:"upcomingRuns,omitempty"` // Next few scheduled runs while running
 }
 
 // CycleResult represents the result of an automation cycle.
 type CycleResult struct {
 	Success          bool             `json:"success"`
+	DryRun           bool             `json:"dryRun"`
 	DetectionResults DetectionResults `json:"detectionResults"`
 	SearchResults    TriggerResults   `json:"searchResults"`
 	TotalSearches    int              `json:"totalSearches"`
@@ -111,6 +207,20 @@ type CycleResult struct {
 	Duration         time.Duration    `json:"duration"`
 }
 
+// Summary returns the cycle totals recorded in the cycle end log entry.
+func (r *CycleResult) Summary() logger.CycleSummary {
+	return logger.CycleSummary{
+		Searches:         r.TotalSearches,
+		MissingTriggered: r.SearchResults.MissingTriggered,
+		CutoffTriggered:  r.SearchResults.CutoffTriggered,
+		Failures:         r.TotalFailures,
+		ServersChecked:   len(r.DetectionResults.Results),
+		Duration:         r.Duration,
+		DryRun:           r.DryRun,
+		Errors:           r.Errors,
+	}
+}
+
 // ServerManagerInterface defines the interface for the ServerManager service.
 type ServerManagerInterface interface {
 	AddServer(ctx context.Context, name, url, apiKey, serverType string) (*ServerInfo, error)
@@ -122,6 +232,9 @@ type ServerManagerInterface interface {
 	GetServer(ctx context.Context, idOrName string) (*ServerInfo, error)
 	GetEnabledServers() ([]database.Server, error)
 	SetServerEnabled(id string, enabled bool) error
+	PairServers(primary, secondary, policy string) (*ServerPairInfo, error)
+	ListServerPairs() ([]ServerPairInfo, error)
+	UnpairServers(server, other string) error
 }
 
 // StringPtr is a helper function to return a pointer to a string.
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// How a detection result's wanted lists were read.
+const (
+	RefreshFull   = "full"   // Both lists were read in full from the server
+	RefreshDelta  = "delta"  // The cache was brought up to date; only lists that changed unexpectedly were re-read
+	RefreshCached = "cached" // The lists came from the cache without contacting the server
+)
+
+// wantedChangeClient is implemented by API clients that can report changes to their wanted lists
+// and read individual items, which lets detection refresh the cache incrementally.
+type wantedChangeClient interface {
+	GetWantedTotals(ctx context.Context) (*api.WantedTotals, error)
+	GetHistorySince(ctx context.Context, since time.Time) ([]api.HistoryRecord, error)
+	GetItems(ctx context.Context, ids []int) ([]api.MediaItem, error)
+}
+
+// readWanted returns a server's missing and cutoff unmet items and how they were read.
+// Wanted lists are cached in the database. Between full refreshes the cache is brought up to date
+// from the server's history and list totals, and a list is only re-read when those don't account
+// for its changes.
+func (d *Detector) readWanted(ctx context.Context, server *database.Server, client DetectorAPIClient) ([]api.MediaItem, []api.MediaItem, string, error) {
+	now := d.now()
+	interval := time.Duration(d.db.GetAppConfig().Detection.FullRefreshHours) * time.Hour
+	refresh := &wantedRefresh{db: d.db, serverID: server.ID}
+
+	state, err := d.db.GetWantedCache(server.ID)
+	if err != nil && d.logger != nil {
+		d.logger.Debug("Failed to read wanted cache, refreshing in full", "server", server.Name, "error", err)
+	}
+	changes, incremental := client.(wantedChangeClient)
+	if incremental && state != nil && !d.fullRefresh && interval > 0 && now.Sub(state.FullRefreshAt) < interval {
+		missing, cutoff, err := refresh.delta(ctx, state.RefreshedAt, now, changes, client)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		d.markRefreshed(refresh, state.FullRefreshAt, now)
+		return missing, cutoff, RefreshDelta, nil
+	}
+
+	missing, err := client.GetAllMissing(ctx)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("missing detection failed: %w", err)
+	}
+	cutoff, err := client.GetAllCutoffUnmet(ctx)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("cutoff detection failed: %w", err)
+	}
+
+	refresh.cache(database.SearchCategoryMissing, missing)
+	refresh.cache(database.SearchCategoryCutoff, cutoff)
+	d.markRefreshed(refresh, now, now)
+	return missing, cutoff, RefreshFull, nil
+}
+
+// markRefreshed records when a server's wanted lists were refreshed. If the cache could not be
+// updated the refresh is not recorded, so the next detection checks everything since the last
+// successful one.
+func (d *Detector) markRefreshed(refresh *wantedRefresh, fullRefreshAt, refreshedAt time.Time) {
+	if refresh.err == nil {
+		refresh.err = d.db.SetWantedCacheRefreshed(refresh.serverID, fullRefreshAt, refreshedAt)
+	}
+	if refresh.err != nil && d.logger != nil {
+		d.logger.Debug("Failed to update wanted cache", "server", refresh.serverID, "error", refresh.err)
+	}
+}
+
+// wantedRefresh updates one server's cached wanted lists.
+type wantedRefresh struct {
+	db       *database.DB
+	serverID string
+	err      error // First failure to update the cache
+}
+
+// wantedChanges describes what changed on a server since its wanted lists were last refreshed.
+type wantedChanges struct {
+	hasFile   map[int]bool          // Items whose file was imported (true) or deleted (false), by their last event
+	stale     map[int]bool          // Cached or newly missing items whose details were re-read
+	refreshed map[int]api.MediaItem // Current details of the stale items still on the server
+	ok        bool                  // Whether the stale items could be re-read
+}
+
+// delta brings the cached wanted lists up to date. Items with a file imported since the last
+// refresh leave the missing list and items with a deleted file join it; either way they leave the
+// cutoff unmet list. The details of every item with history since the last refresh, and of items
+// released since then, are re-read so their monitored state, tags and availability stay current.
+// If a list then doesn't match the server's total, such as after an item was newly monitored or an
+// upgrade still missed the cutoff, it is re-read in full.
+func (r *wantedRefresh) delta(ctx context.Context, since, now time.Time, changes wantedChangeClient, client DetectorAPIClient) ([]api.MediaItem, []api.MediaItem, error) {
+	totals, err := changes.GetWantedTotals(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("change detection failed: %w", err)
+	}
+	history, err := changes.GetHistorySince(ctx, since)
+	if err != nil {
+		return nil, nil, fmt.Errorf("change detection failed: %w", err)
+	}
+
+	// Later events win, e.g. an upgrade deletes the old file before importing the new one
+	slices.SortStableFunc(history, func(a, b api.HistoryRecord) int { return a.Date.Compare(b.Date) })
+	touched := make(map[int]bool)
+	found := &wantedChanges{hasFile: make(map[int]bool), stale: make(map[int]bool), ok: true}
+	for _, record := range history {
+		id := record.ItemID()
+		if id == 0 {
+			continue
+		}
+		touched[id] = true
+		if record.Imported() {
+			found.hasFile[id] = true
+		} else if record.Deleted() {
+			found.hasFile[id] = false
+			found.stale[id] = true
+		}
+	}
+
+	cachedMissing, missingErr := cachedWanted(r.db, r.serverID, database.SearchCategoryMissing)
+	cachedCutoff, cutoffErr := cachedWanted(r.db, r.serverID, database.SearchCategoryCutoff)
+	for _, item := range slices.Concat(cachedMissing, cachedCutoff) {
+		released := item.ReleaseDate.After(since) && !item.ReleaseDate.After(now)
+		if touched[item.ID] || released {
+			found.stale[item.ID] = true
+		}
+	}
+	found.refresh(ctx, changes)
+
+	missing, err := r.list(ctx, database.SearchCategoryMissing, cachedMissing, missingErr, totals.Missing, found, client.GetAllMissing)
+	if err != nil {
+		return nil, nil, fmt.Errorf("missing detection failed: %w", err)
+	}
+	cutoff, err := r.list(ctx, database.SearchCategoryCutoff, cachedCutoff, cutoffErr, totals.Cutoff, found, client.GetAllCutoffUnmet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cutoff detection failed: %w", err)
+	}
+	return missing, cutoff, nil
+}
+
+// refresh re-reads the details of the stale items. Items the server no longer has are left out.
+// If they can't be read, for example because one was deleted and the server rejects the request,
+// the lists are re-read in full instead.
+func (c *wantedChanges) refresh(ctx context.Context, changes wantedChangeClient) {
+	c.refreshed = make(map[int]api.MediaItem, len(c.stale))
+	if len(c.stale) == 0 {
+		return
+	}
+
+	ids := slices.Sorted(maps.Keys(c.stale))
+	items, err := changes.GetItems(ctx, ids)
+	if err != nil {
+		c.ok = false
+		return
+	}
+	for _, item := range items {
+		c.refreshed[item.ID] = item
+	}
+}
+
+// list brings one cached wanted list up to date, re-reading it with fetch if needed.
+func (r *wantedRefresh) list(ctx context.Context, category database.SearchCategory, cached []api.MediaItem, cacheErr error,
+	total int, changes *wantedChanges, fetch func(context.Context) ([]api.MediaItem, error)) ([]api.MediaItem, error) {
+	if cacheErr == nil && changes.ok {
+		kept := make([]api.MediaItem, 0, len(cached))
+		listed := make(map[int]bool, len(cached))
+		modified := false
+		for _, item := range cached {
+			if hasFile, ok := changes.hasFile[item.ID]; ok && (hasFile || category == database.SearchCategoryCutoff) {
+				modified = true
+				continue
+			}
+			if changes.stale[item.ID] {
+				current, ok := changes.refreshed[item.ID]
+				if !ok || !current.Monitored {
+					modified = true
+					continue
+				}
+				item, modified = current, true
+			}
+			kept = append(kept, item)
+			listed[item.ID] = true
+		}
+
+		// Monitored items that lost their file are missing again
+		if category == database.SearchCategoryMissing {
+			for _, id := range slices.Sorted(maps.Keys(changes.hasFile)) {
+				current, ok := changes.refreshed[id]
+				if !changes.hasFile[id] && ok && current.Monitored && !listed[id] {
+					kept = append(kept, current)
+					modified = true
+				}
+			}
+		}
+
+		if len(kept) == total {
+			if modified {
+				r.cache(category, kept)
+			}
+			return kept, nil
+		}
+	}
+
+	items, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.cache(category, items)
+	return items, nil
+}
+
+// cache replaces a cached wanted list.
+func (r *wantedRefresh) cache(category database.SearchCategory, items []api.MediaItem) {
+	entries := make([]database.WantedItem, 0, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			r.fail(fmt.Errorf("encoding item %d: %w", item.ID, err))
+			return
+		}
+		entries = append(entries, database.WantedItem{ID: item.ID, Data: string(data)})
+	}
+	r.fail(r.db.ReplaceWantedItems(r.serverID, category, entries))
+}
+
+// fail records the first failure to update the cache.
+func (r *wantedRefresh) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// cachedWanted returns a cached wanted list.
+func cachedWanted(db *database.DB, serverID string, category database.SearchCategory) ([]api.MediaItem, error) {
+	entries, err := db.GetWantedItems(serverID, category)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]api.MediaItem, 0, len(entries))
+	for _, entry := range entries {
+		var item api.MediaItem
+		if err := json.Unmarshal([]byte(entry.Data), &item); err != nil {
+			return nil, fmt.Errorf("decoding cached item %d: %w", entry.ID, err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// CachedAll returns the wanted lists cached by earlier detections on all enabled servers, without
+// contacting any server. The names of enabled servers that have never been detected are returned
+// separately and are not included in the results.
+func (d *Detector) CachedAll() (*DetectionResults, []string, error) {
+	servers, err := d.db.GetAllServers()
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting servers: %w", err)
+	}
+	states, err := d.db.GetAllWantedCache()
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting wanted cache: %w", err)
+	}
+
+	options := d.db.GetAppConfig().Detection
+	results := &DetectionResults{Results: []DetectionResult{}}
+	var uncached []string
+	for _, server := range servers {
+		if !server.Enabled {
+			continue
+		}
+		state, ok := states[server.ID]
+		if !ok {
+			uncached = append(uncached, server.Name)
+			continue
+		}
+
+		result := newDetectionResult(&server)
+		result.Refresh = RefreshCached
+		result.CachedAt = &state.RefreshedAt
+
+		missing, err := cachedWanted(d.db, server.ID, database.SearchCategoryMissing)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading cached missing items for %s: %w", server.Name, err)
+		}
+		cutoff, err := cachedWanted(d.db, server.ID, database.SearchCategoryCutoff)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading cached cutoff items for %s: %w", server.Name, err)
+		}
+		result.addFiltered(missing, cutoff, server.Filters, d.activeExclusions(server.ID), options, d.now())
+
+		results.Results = append(results.Results, result)
+		results.SuccessCount++
+		results.TotalMissing += len(result.Missing)
+		results.TotalCutoff += len(result.Cutoff)
+	}
+
+	return results, uncached, nil
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/api"
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// changeDetectorClient is a mockDetectorClient that reports its totals and history and reads
+// single items, so detection can refresh its cache incrementally.
+type changeDetectorClient struct {
+	mockDetectorClient
+	history     []api.HistoryRecord
+	itemsErr    error
+	fullReads   int
+	historySeen []time.Time
+	itemReads   [][]int
+}
+
+func (c *changeDetectorClient) GetAllMissing(ctx context.Context) ([]api.MediaItem, error) {
+	c.fullReads++
+	return c.mockDetectorClient.GetAllMissing(ctx)
+}
+
+func (c *changeDetectorClient) GetAllCutoffUnmet(ctx context.Context) ([]api.MediaItem, error) {
+	c.fullReads++
+	return c.mockDetectorClient.GetAllCutoffUnmet(ctx)
+}
+
+func (c *changeDetectorClient) GetWantedTotals(ctx context.Context) (*api.WantedTotals, error) {
+	return &api.WantedTotals{Missing: len(c.missing), Cutoff: len(c.cutoff)}, nil
+}
+
+func (c *changeDetectorClient) GetHistorySince(ctx context.Context, since time.Time) ([]api.HistoryRecord, error) {
+	c.historySeen = append(c.historySeen, since)
+	return c.history, nil
+}
+
+func (c *changeDetectorClient) GetItems(ctx context.Context, ids []int) ([]api.MediaItem, error) {
+	c.itemReads = append(c.itemReads, ids)
+	if c.itemsErr != nil {
+		return nil, c.itemsErr
+	}
+	var items []api.MediaItem
+	for _, item := range append(c.missing, c.cutoff...) {
+		if slices.Contains(ids, item.ID) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func movies(ids ...int) []api.MediaItem {
+	items := make([]api.MediaItem, len(ids))
+	for i, id := range ids {
+		items[i] = api.MediaItem{ID: id, Title: "Movie", Type: "movie", Monitored: true}
+	}
+	return items
+}
+
+func TestDetector_RefreshesCacheIncrementally(t *testing.T) {
+	db := testDetectorDB(t)
+	ctx := context.Background()
+
+	server, err := db.AddServer("radarr", "http://localhost:7878", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	client := &changeDetectorClient{mockDetectorClient: mockDetectorClient{missing: movies(3, 1, 2), cutoff: movies(10)}}
+	detector := NewDetectorWithFactory(db, func(url, apiKey, serverType string) DetectorAPIClient {
+		return client
+	})
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	detector.now = func() time.Time { return now }
+
+	detect := func(wantRefresh string, wantFullReads int, wantMissing ...int) {
+		t.Helper()
+		client.fullReads = 0
+		result, err := detector.DetectServer(ctx, server.ID)
+		if err != nil || result.Error != "" {
+			t.Fatalf("detection failed: %v %s", err, result.Error)
+		}
+		if result.Refresh != wantRefresh || client.fullReads != wantFullReads {
+			t.Errorf("expected %s refresh with %d full reads, got %s with %d", wantRefresh, wantFullReads, result.Refresh, client.fullReads)
+		}
+		if len(result.Missing) != len(wantMissing) {
+			t.Fatalf("expected missing %v, got %v", wantMissing, result.Missing)
+		}
+		for i, id := range wantMissing {
+			if result.Missing[i] != id {
+				t.Errorf("expected missing %v in order, got %v", wantMissing, result.Missing)
+				break
+			}
+		}
+	}
+
+	// The first detection reads everything
+	detect(RefreshFull, 2, 3, 1, 2)
+
+	// Nothing changed: no list or item is read
+	now = now.Add(time.Hour)
+	detect(RefreshDelta, 0, 3, 1, 2)
+	if last := client.historySeen[len(client.historySeen)-1]; !last.Equal(now.Add(-time.Hour)) {
+		t.Errorf("expected history since the previous refresh, got %v", last)
+	}
+	if len(client.itemReads) != 0 {
+		t.Errorf("expected no items to be read, got %v", client.itemReads)
+	}
+
+	// An imported movie leaves the missing list without a read
+	now = now.Add(time.Hour)
+	client.missing = movies(3, 2)
+	client.history = []api.HistoryRecord{{EventType: "downloadFolderImported", MovieID: 1}}
+	detect(RefreshDelta, 0, 3, 2)
+
+	// A newly monitored movie changes the total, so only the missing list is re-read
+	now = now.Add(time.Hour)
+	client.missing = movies(3, 2, 7)
+	client.history = nil
+	detect(RefreshDelta, 1, 3, 2, 7)
+
+	// A deleted file moves the movie from the cutoff unmet list to the missing list
+	now = now.Add(time.Hour)
+	client.missing, client.cutoff = movies(3, 2, 7, 10), nil
+	client.history = []api.HistoryRecord{{EventType: "movieFileDeleted", MovieID: 10}}
+	detect(RefreshDelta, 0, 3, 2, 7, 10)
+
+	// An upgrade deletes the old file before importing the new one
+	now = now.Add(time.Hour)
+	client.missing = movies(3, 2, 7)
+	client.history = []api.HistoryRecord{
+		{EventType: "downloadFolderImported", MovieID: 10, Date: now.Add(-time.Minute)},
+		{EventType: "movieFileDeleted", MovieID: 10, Date: now.Add(-2 * time.Minute)},
+	}
+	detect(RefreshDelta, 0, 3, 2, 7)
+
+	// Movies with history have their details re-read, e.g. a tag added since the last refresh
+	now = now.Add(time.Hour)
+	client.missing[1].Tags = []string{"4k"}
+	client.history = []api.HistoryRecord{{EventType: "grabbed", MovieID: 2}}
+	client.itemReads = nil
+	detect(RefreshDelta, 0, 3, 2, 7)
+	if len(client.itemReads) != 1 || !slices.Equal(client.itemReads[0], []int{2}) {
+		t.Errorf("expected only movie 2 to be read, got %v", client.itemReads)
+	}
+	if cached, _ := cachedWanted(db, server.ID, database.SearchCategoryMissing); len(cached[1].Tags) != 1 {
+		t.Errorf("expected the cached movie to have its new tag, got %+v", cached[1])
+	}
+
+	// If the items can't be read, both lists are re-read
+	now = now.Add(time.Hour)
+	client.itemsErr = errors.New("not found")
+	detect(RefreshDelta, 2, 3, 2, 7)
+	client.itemsErr = nil
+
+	// Once the full refresh interval has passed everything is read again
+	now = now.Add(24 * time.Hour)
+	client.history = nil
+	detect(RefreshFull, 2, 3, 2, 7)
+
+	// Forcing a full refresh ignores the cache
+	detector.WithFullRefresh()
+	detect(RefreshFull, 2, 3, 2, 7)
+}
+
+func TestDetector_RereadsReleasedItems(t *testing.T) {
+	db := testDetectorDB(t)
+	ctx := context.Background()
+
+	server, err := db.AddServer("radarr", "http://localhost:7878", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	client := &changeDetectorClient{mockDetectorClient: mockDetectorClient{missing: movies(1, 2)}}
+	client.missing[0].ReleaseDate, client.missing[0].Unavailable = now.Add(30*time.Minute), true
+	client.missing[1].ReleaseDate, client.missing[1].Unavailable = now.Add(48*time.Hour), true
+	detector := NewDetectorWithFactory(db, func(url, apiKey, serverType string) DetectorAPIClient {
+		return client
+	})
+	detector.now = func() time.Time { return now }
+	if _, err := detector.DetectServer(ctx, server.ID); err != nil {
+		t.Fatalf("detection failed: %v", err)
+	}
+
+	// Movie 1 is released and becomes available without any history
+	now = now.Add(time.Hour)
+	client.missing[0].Unavailable = false
+	if _, err := detector.DetectServer(ctx, server.ID); err != nil {
+		t.Fatalf("detection failed: %v", err)
+	}
+
+	if len(client.itemReads) != 1 || !slices.Equal(client.itemReads[0], []int{1}) {
+		t.Errorf("expected only the released movie to be read, got %v", client.itemReads)
+	}
+	cached, err := cachedWanted(db, server.ID, database.SearchCategoryMissing)
+	if err != nil {
+		t.Fatalf("reading cache: %v", err)
+	}
+	if cached[0].Unavailable || !cached[1].Unavailable {
+		t.Errorf("expected only the released movie to be available, got %+v", cached)
+	}
+}
+
+func TestDetector_FullRefreshEveryCycleWhenIntervalIsZero(t *testing.T) {
+	db := testDetectorDB(t)
+	ctx := context.Background()
+
+	config := db.GetAppConfig()
+	config.Detection.FullRefreshHours = 0
+	if err := db.SetAppConfig(config); err != nil {
+		t.Fatalf("setting config: %v", err)
+	}
+	if _, err := db.AddServer("radarr", "http://localhost:7878", "key", database.ServerTypeRadarr); err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	client := &changeDetectorClient{mockDetectorClient: mockDetectorClient{missing: movies(1)}}
+	detector := NewDetectorWithFactory(db, func(url, apiKey, serverType string) DetectorAPIClient {
+		return client
+	})
+
+	for range 2 {
+		results, err := detector.DetectAll(ctx)
+		if err != nil {
+			t.Fatalf("DetectAll failed: %v", err)
+		}
+		if results.Results[0].Refresh != RefreshFull {
+			t.Errorf("expected a full refresh, got %q", results.Results[0].Refresh)
+		}
+	}
+}
+
+func TestDetector_CachedAll(t *testing.T) {
+	db := testDetectorDB(t)
+	ctx := context.Background()
+
+	scanned, err := db.AddServer("radarr", "http://localhost:7878", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	client := &changeDetectorClient{mockDetectorClient: mockDetectorClient{missing: movies(1, 2), cutoff: movies(5)}}
+	detector := NewDetectorWithFactory(db, func(url, apiKey, serverType string) DetectorAPIClient {
+		return client
+	})
+	if _, err := detector.DetectServer(ctx, scanned.ID); err != nil {
+		t.Fatalf("detection failed: %v", err)
+	}
+
+	if _, err := db.AddServer("sonarr", "http://localhost:8989", "key", database.ServerTypeSonarr); err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+
+	client.fullReads = 0
+	results, uncached, err := detector.CachedAll()
+	if err != nil {
+		t.Fatalf("CachedAll failed: %v", err)
+	}
+	if client.fullReads != 0 {
+		t.Errorf("expected no server contact, got %d reads", client.fullReads)
+	}
+	if len(uncached) != 1 || uncached[0] != "sonarr" {
+		t.Errorf("expected sonarr to be uncached, got %v", uncached)
+	}
+	if len(results.Results) != 1 || results.TotalMissing != 2 || results.TotalCutoff != 1 {
+		t.Fatalf("expected cached radarr backlog, got %+v", results)
+	}
+	result := results.Results[0]
+	if result.Refresh != RefreshCached || result.CachedAt == nil || result.MissingItems[2].Title != "Movie" {
+		t.Errorf("expected cached result with item details, got %+v", result)
+	}
+}
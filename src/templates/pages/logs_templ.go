@@ -47,7 +47,7 @@ func Logs(logs []logger.LogEntry) templ.Component {
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div class=\"max-w-7xl mx-auto\"><div class=\"mb-6 flex justify-between items-center\"><h1 class=\"text-3xl font-bold\">Activity Logs</h1><div class=\"flex gap-2\"><a href=\"/api/logs/export?format=json\" download class=\"btn btn-ghost btn-sm\">Export JSON</a> <a href=\"/api/logs/export?format=csv\" download class=\"btn btn-ghost btn-sm\">Export CSV</a> <button hx-delete=\"/api/logs\" hx-confirm=\"Are you sure you want to clear all logs?\" hx-target=\"#log-container\" hx-swap=\"innerHTML\" class=\"btn btn-error btn-sm\">Clear Logs</button></div></div><!-- Filter toolbar --><div class=\"card bg-base-100 shadow mb-6\"><div class=\"card-body p-4\"><!-- Search input row --><div class=\"mb-4\"><label for=\"search-filter\" class=\"label\"><span class=\"label-text text-sm\">Search Messages</span></label> <input type=\"text\" id=\"search-filter\" name=\"search\" placeholder=\"Search log messages...\" hx-get=\"/partials/log-entries\" hx-target=\"#log-entries\" hx-swap=\"innerHTML\" hx-include=\".log-filter\" hx-trigger=\"keyup changed delay:500ms\" class=\"log-filter input input-bordered input-sm w-full\"></div><div class=\"grid grid-cols-1 md:grid-cols-2 lg:grid-cols-5 gap-4 mb-4\"><div><label for=\"type-filter\" class=\"label\"><span class=\"label-text text-sm\">Type</span></label> <select id=\"type-filter\" name=\"type\" hx-get=\"/partials/log-entries\" hx-target=\"#log-entries\" hx-swap=\"innerHTML\" hx-include=\".log-filter\" class=\"log-filter select select-bordered select-sm w-full\"><option value=\"\">All Types</option> <option value=\"cycle_start\">Cycle Start</option> <option value=\"cycle_end\">Cycle End</option> <option value=\"detection\">Detection</option> <option value=\"search\">Search</option> <option value=\"error\">Error</option></select></div><div><label for=\"server-filter\" class=\"label\"><span class=\"label-text text-sm\">Server</span></label> <select id=\"server-filter\" name=\"server\" hx-get=\"/partials/log-entries\" hx-target=\"#log-entries\" hx-swap=\"innerHTML\" hx-include=\".log-filter\" class=\"log-filter select select-bordered select-sm w-full\"><option value=\"\">All Servers</option></select></div><div><label for=\"operation-filter\" class=\"label\"><span class=\"label-text text-sm\">Operation</span></label> <select id=\"operation-filter\" name=\"operation\" hx-get=\"/partials/log-entries\" hx-target=\"#log-entries\" hx-swap=\"innerHTML\" hx-include=\".log-filter\" class=\"log-filter select select-bordered select-sm w-full\"><option value=\"\">All Operations</option> <option value=\"search\">Search</option> <option value=\"automation_cycle\">Automation Cycle</option> <option value=\"connection\">Connection</option> <option value=\"system\">System</option></select></div><div><label for=\"from-date\" class=\"label\"><span class=\"label-text text-sm\">From Date</span></label> <input type=\"datetime-local\" id=\"from-date\" name=\"from\" hx-get=\"/partials/log-entries\" hx-target=\"#log-entries\" hx-swap=\"innerHTML\" hx-include=\".log-filter\" hx-trigger=\"change\" class=\"log-filter input input-bordered input-sm w-full\"></div><div><label for=\"to-date\" class=\"label\"><span class=\"label-text text-sm\">To Date</span></label> <input type=\"datetime-local\" id=\"to-date\" name=\"to\" hx-get=\"/partials/log-entries\" hx-target=\"#log-entries\" hx-swap=\"innerHTML\" hx-include=\".log-filter\" hx-trigger=\"change\" class=\"log-filter input input-bordered input-sm w-full\"></div></div><div class=\"flex gap-2\"><button hx-get=\"/partials/log-entries\" hx-target=\"#log-entries\" hx-swap=\"innerHTML\" hx-include=\".log-filter\" class=\"btn btn-primary btn-sm\">Apply Filters</button> <button onclick=\"document.querySelectorAll('.log-filter').forEach(el => el.value = ''); htmx.trigger('#type-filter', 'change');\" class=\"btn btn-ghost btn-sm\">Clear Filters</button></div></div></div><!-- Logs container with WebSocket integration --><div class=\"card bg-base-100 shadow\" id=\"log-container\" hx-ext=\"ws\" ws-connect=\"/ws/logs\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div class=\"max-w-7xl mx-auto\"><div class=\"mb-6 flex justify-between items-center\"><h1 class=\"text-3xl font-bold\">Activity Logs</h1><div class=\"flex gap-2\"><a href=\"/api/logs/export?format=json\" download class=\"btn btn-ghost btn-sm\">Export JSON</a> <a href=\"/api/logs/export?format=csv\" download class=\"btn btn-ghost btn-sm\">Export CSV</a> <button hx-delete=\"/api/logs\" hx-confirm=\"Are you sure you want to clear all logs?\" hx-target=\"#log-container\" hx-swap=\"innerHTML\" class=\"btn btn-error btn-sm\">Clear Logs</button></div></div><!-- Filter toolbar --><div class=\"card bg-base-100 shadow mb-6\"><div class=\"card-body p-4\"><!-- Search input row --><div class=\"mb-4\"><label for=\"search-filter\" class=\"label\"><span class=\"label-text text-sm\">Search Messages</span></label> <input type=\"text\" id=\"search-filter\" name=\"search\" placeholder=\"Search log messages...\" hx-get=\"/partials/log-entries\" hx-target=\"#log-entries\" hx-swap=\"innerHTML\" hx-include=\".log-filter\" hx-trigger=\"keyup changed delay:500ms\" class=\"log-filter input input-bordered input-sm w-full\"></div><div class=\"grid grid-cols-1 md:grid-cols-2 lg:grid-cols-5 gap-4 mb-4\"><div><label for=\"type-filter\" class=\"label\"><span class=\"label-text text-sm\">Type</span></label> <select id=\"type-filter\" name=\"type\" hx-get=\"/partials/log-entries\" hx-target=\"#log-entries\" hx-swap=\"innerHTML\" hx-include=\".log-filter\" class=\"log-filter select select-bordered select-sm w-full\"><option value=\"\">All Types</option> <option value=\"cycle_start\">Cycle Start</option> <option value=\"cycle_end\">Cycle End</option> <option value=\"detection\">Detection</option> <option value=\"search\">Search</option> <option value=\"outcome\">Outcome</option> <option value=\"error\">Error</option></select></div><div><label for=\"server-filter\" class=\"label\"><span class=\"label-text text-sm\">Server</span></label> <select id=\"server-filter\" name=\"server\" hx-get=\"/partials/log-entries\" hx-target=\"#log-entries\" hx-swap=\"innerHTML\" hx-include=\".log-filter\" class=\"log-filter select select-bordered select-sm w-full\"><option value=\"\">All Servers</option></select></div><div><label for=\"operation-filter\" class=\"label\"><span class=\"label-text text-sm\">Operation</span></label> <select id=\"operation-filter\" name=\"operation\" hx-get=\"/partials/log-entries\" hx-target=\"#log-entries\" hx-swap=\"innerHTML\" hx-include=\".log-filter\" class=\"log-filter select select-bordered select-sm w-full\"><option value=\"\">All Operations</option> <option value=\"search\">Search</option> <option value=\"outcome\">Outcome</option> <option value=\"automation_cycle\">Automation Cycle</option> <option value=\"connection\">Connection</option> <option value=\"system\">System</option></select></div><div><label for=\"from-date\" class=\"label\"><span class=\"label-text text-sm\">From Date</span></label> <input type=\"datetime-local\" id=\"from-date\" name=\"from\" hx-get=\"/partials/log-entries\" hx-target=\"#log-entries\" hx-swap=\"innerHTML\" hx-include=\".log-filter\" hx-trigger=\"change\" class=\"log-filter input input-bordered input-sm w-full\"></div><div><label for=\"to-date\" class=\"label\"><span class=\"label-text text-sm\">To Date</span></label> <input type=\"datetime-local\" id=\"to-date\" name=\"to\" hx-get=\"/partials/log-entries\" hx-target=\"#log-entries\" hx-swap=\"innerHTML\" hx-include=\".log-filter\" hx-trigger=\"change\" class=\"log-filter input input-bordered input-sm w-full\"></div></div><div class=\"flex gap-2\"><button hx-get=\"/partials/log-entries\" hx-target=\"#log-entries\" hx-swap=\"innerHTML\" hx-include=\".log-filter\" class=\"btn btn-primary btn-sm\">Apply Filters</button> <button onclick=\"document.querySelectorAll('.log-filter').forEach(el => el.value = ''); htmx.trigger('#type-filter', 'change');\" class=\"btn btn-ghost btn-sm\">Clear Filters</button></div></div></div><!-- Logs container with WebSocket integration --><div class=\"card bg-base-100 shadow\" id=\"log-container\" hx-ext=\"ws\" ws-connect=\"/ws/logs\">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -74,7 +74,7 @@ func Logs(logs []logger.LogEntry) templ.Component {
 				var templ_7745c5c3_Var3 string
 				templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs("/partials/log-entries?offset=" + string(rune(len(logs))))
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `src/templates/pages/logs.templ`, Line: 178, Col: 72}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `src/templates/pages/logs.templ`, Line: 180, Col: 72}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
 				if templ_7745c5c3_Err != nil {
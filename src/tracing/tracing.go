@@ -0,0 +1,155 @@
+// Package tracing sets up optional OpenTelemetry tracing for automation cycles and API calls.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/version"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName identifies Janitarr's spans to the tracer provider.
+	instrumentationName = "github.com/edrobertsrayne/janitarr"
+
+	// EnvExporter overrides the tracing.exporter config key.
+	EnvExporter = "JANITARR_TRACING_EXPORTER"
+
+	// EnvEndpoint overrides the tracing.endpoint config key.
+	EnvEndpoint = "JANITARR_TRACING_ENDPOINT"
+)
+
+// Tracer returns the tracer used for all Janitarr spans.
+// Spans are discarded until Setup installs an exporter.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// RecordError marks a span as failed with the given error. It does nothing if err is nil.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// ServerAttributes describes the *arr server a span relates to.
+func ServerAttributes(serverName, serverType string) trace.SpanStartEventOption {
+	return trace.WithAttributes(
+		attribute.String("janitarr.server.name", serverName),
+		attribute.String("janitarr.server.type", serverType),
+	)
+}
+
+// ConfigFromEnv applies the JANITARR_TRACING_* environment variables on top of the stored configuration.
+func ConfigFromEnv(config database.TracingConfig) (database.TracingConfig, error) {
+	if val, ok := os.LookupEnv(EnvExporter); ok {
+		val = strings.ToLower(strings.TrimSpace(val))
+		if !database.IsValidTracingExporter(val) {
+			return config, fmt.Errorf("invalid %s %q: must be none, otlp, stdout or file", EnvExporter, val)
+		}
+		config.Exporter = database.TracingExporter(val)
+	}
+	if val, ok := os.LookupEnv(EnvEndpoint); ok {
+		config.Endpoint = strings.TrimSpace(val)
+	}
+	return config, nil
+}
+
+// Setup installs a global tracer provider that sends spans to the configured exporter and
+// propagates W3C trace context. The returned function flushes pending spans and must be
+// called on shutdown. With the "none" exporter nothing is installed.
+func Setup(ctx context.Context, config database.TracingConfig) (func(context.Context) error, error) {
+	exporter, closeExporter, err := newExporter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the defaults
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			attribute.String("service.name", "janitarr"),
+			attribute.String("service.version", version.Short()),
+		),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("creating tracing resource: %w", err), closeExporter())
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return func(ctx context.Context) error {
+		return errors.Join(provider.Shutdown(ctx), closeExporter())
+	}, nil
+}
+
+// newExporter creates the span exporter for the configuration, or nil when tracing is disabled.
+// The returned close function releases anything the exporter writes to.
+func newExporter(ctx context.Context, config database.TracingConfig) (sdktrace.SpanExporter, func() error, error) {
+	noClose := func() error { return nil }
+
+	switch config.Exporter {
+	case database.TracingNone, "":
+		return nil, noClose, nil
+
+	case database.TracingOTLP:
+		// Without an endpoint the OTEL_EXPORTER_OTLP_* environment variables apply
+		var opts []otlptracehttp.Option
+		if config.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(config.Endpoint))
+		}
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating OTLP exporter: %w", err)
+		}
+		return exporter, noClose, nil
+
+	case database.TracingStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating stdout exporter: %w", err)
+		}
+		return exporter, noClose, nil
+
+	case database.TracingFile:
+		path := config.Endpoint
+		if path == "" {
+			path = "./data/traces.jsonl"
+		}
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("opening trace file: %w", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			return nil, nil, errors.Join(fmt.Errorf("creating file exporter: %w", err), file.Close())
+		}
+		return exporter, file.Close, nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown tracing exporter: %s", config.Exporter)
+}
@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"go.opentelemetry.io/otel"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	stored := database.TracingConfig{Exporter: database.TracingNone, Endpoint: "http://collector:4318"}
+
+	t.Run("no overrides", func(t *testing.T) {
+		config, err := ConfigFromEnv(stored)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if config != stored {
+			t.Errorf("expected %+v, got %+v", stored, config)
+		}
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		t.Setenv(EnvExporter, " OTLP ")
+		t.Setenv(EnvEndpoint, "http://other:4318")
+
+		config, err := ConfigFromEnv(stored)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if config.Exporter != database.TracingOTLP || config.Endpoint != "http://other:4318" {
+			t.Errorf("unexpected config: %+v", config)
+		}
+	})
+
+	t.Run("invalid exporter", func(t *testing.T) {
+		t.Setenv(EnvExporter, "jaeger")
+
+		if _, err := ConfigFromEnv(stored); err == nil {
+			t.Error("expected error for unknown exporter")
+		}
+	})
+}
+
+func TestSetup_None(t *testing.T) {
+	previous := otel.GetTracerProvider()
+
+	shutdown, err := Setup(context.Background(), database.TracingConfig{Exporter: database.TracingNone})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown failed: %v", err)
+	}
+	if otel.GetTracerProvider() != previous {
+		t.Error("expected the tracer provider to be left alone")
+	}
+}
+
+func TestSetup_File(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	shutdown, err := Setup(context.Background(), database.TracingConfig{Exporter: database.TracingFile, Endpoint: path})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	ctx, parent := Tracer().Start(context.Background(), "Automation.RunCycle")
+	_, child := Tracer().Start(ctx, "Detector.detectServer", ServerAttributes("Movies", "radarr"))
+	child.End()
+	parent.End()
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading trace file: %v", err)
+	}
+	for _, want := range []string{`"Name":"Automation.RunCycle"`, `"Name":"Detector.detectServer"`, `"janitarr.server.name"`, `"janitarr"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("trace file missing %s", want)
+		}
+	}
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	if _, err := Setup(context.Background(), database.TracingConfig{Exporter: "zipkin"}); err == nil {
+		t.Error("expected error for unknown exporter")
+	}
+}
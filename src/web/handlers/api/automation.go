@@ -45,9 +45,10 @@ func (h *AutomationHandlers) TriggerAutomationCycle(w http.ResponseWriter, r *ht
 		return
 	}
 
-	// Trigger the cycle in a goroutine to avoid blocking the HTTP response
+	// Trigger the cycle in a goroutine to avoid blocking the HTTP response.
+	// The cycle outlives the request but stays part of its trace.
+	ctx := context.WithoutCancel(r.Context())
 	go func() {
-		ctx := context.Background()                                // Or pass a cancellable context from r.Context()
 		_, err := h.Automation.RunCycle(ctx, true, payload.DryRun) // isManual = true
 		if err != nil {
 			// Log the error but don't respond to the HTTP request directly
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/services"
 )
 
 // ConfigHandlers provides handlers for application configuration API endpoints.
@@ -36,6 +37,8 @@ func (h *ConfigHandlers) PatchConfig(w http.ResponseWriter, r *http.Request) {
 
 	currentConfig := h.DB.GetAppConfig()
 	newConfig := currentConfig // Start with current config
+	newPassword := ""
+	var newRequestsKey *string
 
 	// Apply updates
 	for key, val := range updates {
@@ -54,6 +57,20 @@ func (h *ConfigHandlers) PatchConfig(w http.ResponseWriter, r *http.Request) {
 				jsonError(w, fmt.Sprintf("Invalid value type for %s", key), http.StatusBadRequest)
 				return
 			}
+		case "schedule.cron", "schedule.windows", "schedule.blackouts":
+			v, ok := val.(string)
+			if !ok {
+				jsonError(w, fmt.Sprintf("Invalid value type for %s", key), http.StatusBadRequest)
+				return
+			}
+			setScheduleField(&newConfig.Schedule, strings.ToLower(key), v)
+		case "schedule.catchup":
+			if v, ok := val.(string); ok && database.IsValidCatchUpPolicy(v) {
+				newConfig.Schedule.CatchUp = database.CatchUpPolicy(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value for %s", key), http.StatusBadRequest)
+				return
+			}
 		case "limits.missingmovieslimit":
 			if v, ok := val.(float64); ok {
 				newConfig.SearchLimits.MissingMoviesLimit = int(v)
@@ -82,12 +99,155 @@ func (h *ConfigHandlers) PatchConfig(w http.ResponseWriter, r *http.Request) {
 				jsonError(w, fmt.Sprintf("Invalid value type for %s", key), http.StatusBadRequest)
 				return
 			}
+		case "search.cooldownhours":
+			if v, ok := val.(float64); ok && v >= 0 {
+				newConfig.Search.CooldownHours = int(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value type for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "search.strategy":
+			if v, ok := val.(string); ok && database.IsValidSelectionMode(v) {
+				newConfig.Search.Strategy = database.SelectionMode(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "search.seasonpackpercent":
+			if v, ok := val.(float64); ok && v >= 0 && v <= 100 {
+				newConfig.Search.SeasonPackPercent = int(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "search.maxqueuesize":
+			if v, ok := val.(float64); ok && v >= 0 {
+				newConfig.Search.MaxQueueSize = int(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value type for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "search.giveupattempts":
+			if v, ok := val.(float64); ok && v >= 0 {
+				newConfig.Search.GiveUpAttempts = int(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value type for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "search.giveuppolicy":
+			if v, ok := val.(string); ok && database.IsValidGiveUpPolicy(v) {
+				newConfig.Search.GiveUpPolicy = database.GiveUpPolicy(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "search.giveupdays":
+			if v, ok := val.(float64); ok && v >= 1 {
+				newConfig.Search.GiveUpDays = int(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "search.giveuptaglabel":
+			if v, ok := val.(string); ok && strings.TrimSpace(v) != "" {
+				newConfig.Search.GiveUpTagLabel = strings.TrimSpace(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "detection.fullrefreshhours":
+			if v, ok := val.(float64); ok && v >= 0 {
+				newConfig.Detection.FullRefreshHours = int(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value type for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "detection.skipunavailable":
+			if v, ok := val.(bool); ok {
+				newConfig.Detection.SkipUnavailable = v
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value type for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "detection.releasegracehours":
+			if v, ok := val.(float64); ok && v >= 0 {
+				newConfig.Detection.ReleaseGraceHours = int(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value type for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "auth.mode":
+			if v, ok := val.(string); ok && database.IsValidAuthMode(v) {
+				newConfig.Auth.Mode = database.AuthMode(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "auth.username":
+			if v, ok := val.(string); ok && strings.TrimSpace(v) != "" {
+				newConfig.Auth.Username = strings.TrimSpace(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "tracing.exporter":
+			if v, ok := val.(string); ok && database.IsValidTracingExporter(v) {
+				newConfig.Tracing.Exporter = database.TracingExporter(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "tracing.endpoint":
+			if v, ok := val.(string); ok {
+				newConfig.Tracing.Endpoint = strings.TrimSpace(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value type for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "requests.url":
+			if v, ok := val.(string); ok {
+				newConfig.Requests.URL = strings.TrimSpace(v)
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value type for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "requests.apikey":
+			if v, ok := val.(string); ok {
+				v = strings.TrimSpace(v)
+				newRequestsKey = &v
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value type for %s", key), http.StatusBadRequest)
+				return
+			}
+		case "auth.password":
+			if v, ok := val.(string); ok {
+				newPassword = v
+			} else {
+				jsonError(w, fmt.Sprintf("Invalid value type for %s", key), http.StatusBadRequest)
+				return
+			}
 		default:
 			jsonError(w, fmt.Sprintf("Unknown configuration key: %s", key), http.StatusBadRequest)
 			return
 		}
 	}
 
+	if err := services.ValidateScheduleConfig(newConfig.Schedule); err != nil {
+		jsonError(w, fmt.Sprintf("Invalid schedule: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if !h.applyAuthPassword(w, newConfig.Auth, newPassword) {
+		return
+	}
+
+	if newRequestsKey != nil {
+		if err := h.DB.SetRequestsAPIKey(*newRequestsKey); err != nil {
+			jsonError(w, fmt.Sprintf("Failed to update configuration: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	if err := h.DB.SetAppConfig(newConfig); err != nil {
 		jsonError(w, fmt.Sprintf("Failed to update configuration: %v", err), http.StatusInternalServerError)
 		return
@@ -96,9 +256,56 @@ func (h *ConfigHandlers) PatchConfig(w http.ResponseWriter, r *http.Request) {
 	jsonMessage(w, "Configuration updated successfully", http.StatusOK)
 }
 
+// applyAuthPassword stores a new password if one was given, logging out every existing session,
+// and checks that authentication is only enabled once a password exists. It writes an error
+// response and returns false on failure.
+func (h *ConfigHandlers) applyAuthPassword(w http.ResponseWriter, auth database.AuthConfig, password string) bool {
+	if password != "" {
+		if err := h.DB.SetAuthPassword(password); err != nil {
+			jsonError(w, fmt.Sprintf("Invalid password: %v", err), http.StatusBadRequest)
+			return false
+		}
+		if err := h.DB.DeleteAllSessions(); err != nil {
+			jsonError(w, fmt.Sprintf("Failed to log out existing sessions: %v", err), http.StatusInternalServerError)
+			return false
+		}
+		return true
+	}
+
+	if auth.Mode != database.AuthDisabled && !h.DB.HasAuthPassword() {
+		jsonError(w, "A password must be set before enabling authentication", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// RegenerateAPIKey replaces the API key and returns the new one.
+func (h *ConfigHandlers) RegenerateAPIKey(w http.ResponseWriter, r *http.Request) {
+	apiKey, err := h.DB.RegenerateAPIKey()
+	if err != nil {
+		jsonError(w, fmt.Sprintf("Failed to regenerate API key: %v", err), http.StatusInternalServerError)
+		return
+	}
+	jsonSuccess(w, map[string]string{"apiKey": apiKey})
+}
+
+// setScheduleField stores a cron expression, allowed windows or blackout periods by config key.
+func setScheduleField(schedule *database.ScheduleConfig, key, value string) {
+	value = strings.TrimSpace(value)
+	switch key {
+	case "schedule.cron":
+		schedule.Cron = value
+	case "schedule.windows":
+		schedule.Windows = value
+	case "schedule.blackouts":
+		schedule.Blackouts = value
+	}
+}
+
 // ResetConfig resets the application configuration to default values.
 func (h *ConfigHandlers) ResetConfig(w http.ResponseWriter, r *http.Request) {
 	defaultConfig := database.DefaultAppConfig()
+	defaultConfig.Auth = h.DB.GetAppConfig().Auth // Never lock out or expose the UI by resetting
 	if err := h.DB.SetAppConfig(defaultConfig); err != nil {
 		jsonError(w, fmt.Sprintf("Failed to reset configuration: %v", err), http.StatusInternalServerError)
 		return
@@ -129,6 +336,19 @@ func (h *ConfigHandlers) PostConfig(w http.ResponseWriter, r *http.Request) {
 		newConfig.Schedule.Enabled = false
 	}
 
+	for _, key := range []string{"schedule.cron", "schedule.windows", "schedule.blackouts"} {
+		if _, ok := r.Form[key]; ok {
+			setScheduleField(&newConfig.Schedule, key, r.FormValue(key))
+		}
+	}
+	if val := r.FormValue("schedule.catchup"); database.IsValidCatchUpPolicy(val) {
+		newConfig.Schedule.CatchUp = database.CatchUpPolicy(val)
+	}
+	if err := services.ValidateScheduleConfig(newConfig.Schedule); err != nil {
+		jsonError(w, fmt.Sprintf("Invalid schedule: %v", err), http.StatusBadRequest)
+		return
+	}
+
 	// Parse search limits
 	if val := r.FormValue("limits.missing.movies"); val != "" {
 		if i, err := strconv.Atoi(val); err == nil && i >= 0 && i <= 1000 {
@@ -154,6 +374,57 @@ func (h *ConfigHandlers) PostConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Parse search settings
+	if val := r.FormValue("search.cooldown"); val != "" {
+		if i, err := strconv.Atoi(val); err == nil && i >= 0 && i <= 720 {
+			newConfig.Search.CooldownHours = i
+		}
+	}
+	if val := r.FormValue("search.strategy"); val != "" {
+		if !database.IsValidSelectionMode(val) {
+			jsonError(w, fmt.Sprintf("Invalid value for search.strategy: %s", val), http.StatusBadRequest)
+			return
+		}
+		newConfig.Search.Strategy = database.SelectionMode(val)
+	}
+	if val := r.FormValue("search.seasonPackPercent"); val != "" {
+		if i, err := strconv.Atoi(val); err == nil && i >= 0 && i <= 100 {
+			newConfig.Search.SeasonPackPercent = i
+		}
+	}
+	if val := r.FormValue("search.maxQueueSize"); val != "" {
+		if i, err := strconv.Atoi(val); err == nil && i >= 0 {
+			newConfig.Search.MaxQueueSize = i
+		}
+	}
+	if val := r.FormValue("search.giveUpAttempts"); val != "" {
+		if i, err := strconv.Atoi(val); err == nil && i >= 0 {
+			newConfig.Search.GiveUpAttempts = i
+		}
+	}
+	if val := r.FormValue("search.giveUpPolicy"); database.IsValidGiveUpPolicy(val) {
+		newConfig.Search.GiveUpPolicy = database.GiveUpPolicy(val)
+	}
+	if val := r.FormValue("search.giveUpDays"); val != "" {
+		if i, err := strconv.Atoi(val); err == nil && i >= 1 {
+			newConfig.Search.GiveUpDays = i
+		}
+	}
+	if val := strings.TrimSpace(r.FormValue("search.giveUpTagLabel")); val != "" {
+		newConfig.Search.GiveUpTagLabel = val
+	}
+
+	// Parse request settings; a blank API key keeps the stored one
+	if _, ok := r.Form["requests.url"]; ok {
+		newConfig.Requests.URL = strings.TrimSpace(r.FormValue("requests.url"))
+	}
+	if val := strings.TrimSpace(r.FormValue("requests.apiKey")); val != "" {
+		if err := h.DB.SetRequestsAPIKey(val); err != nil {
+			jsonError(w, fmt.Sprintf("Failed to update configuration: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	// Parse logs settings
 	if val := r.FormValue("logs.retention_days"); val != "" {
 		if i, err := strconv.Atoi(val); err == nil && i >= 7 && i <= 90 {
@@ -161,6 +432,17 @@ func (h *ConfigHandlers) PostConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Parse authentication settings
+	if val := r.FormValue("auth.mode"); database.IsValidAuthMode(val) {
+		newConfig.Auth.Mode = database.AuthMode(val)
+	}
+	if val := strings.TrimSpace(r.FormValue("auth.username")); val != "" {
+		newConfig.Auth.Username = val
+	}
+	if !h.applyAuthPassword(w, newConfig.Auth, r.FormValue("auth.password")) {
+		return
+	}
+
 	if err := h.DB.SetAppConfig(newConfig); err != nil {
 		jsonError(w, fmt.Sprintf("Failed to update configuration: %v", err), http.StatusInternalServerError)
 		return
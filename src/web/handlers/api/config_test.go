@@ -7,8 +7,10 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/web/middleware"
 )
 
 func TestGetConfig(t *testing.T) {
@@ -79,6 +81,196 @@ func TestPatchConfig_Success(t *testing.T) {
 	}
 }
 
+func TestPatchConfig_Schedule(t *testing.T) {
+	db := testDB(t)
+	handlers := NewConfigHandlers(db)
+
+	body, _ := json.Marshal(map[string]any{
+		"schedule.cron":      "0 2,14 * * *",
+		"schedule.blackouts": "12:00-18:00",
+	})
+	req := httptest.NewRequest("PATCH", "/api/config", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+	handlers.PatchConfig(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	config := db.GetAppConfig()
+	if config.Schedule.Cron != "0 2,14 * * *" || config.Schedule.Blackouts != "12:00-18:00" {
+		t.Errorf("expected schedule to be saved, got %+v", config.Schedule)
+	}
+
+	// Invalid expressions are rejected and not persisted
+	body, _ = json.Marshal(map[string]any{"schedule.cron": "every day"})
+	req = httptest.NewRequest("PATCH", "/api/config", bytes.NewReader(body))
+	rr = httptest.NewRecorder()
+	handlers.PatchConfig(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+	if db.GetAppConfig().Schedule.Cron != "0 2,14 * * *" {
+		t.Error("invalid cron expression should not be saved")
+	}
+}
+
+func TestPatchConfig_Tracing(t *testing.T) {
+	db := testDB(t)
+	handlers := NewConfigHandlers(db)
+
+	body, _ := json.Marshal(map[string]any{
+		"tracing.exporter": "otlp",
+		"tracing.endpoint": " http://collector:4318 ",
+	})
+	req := httptest.NewRequest("PATCH", "/api/config", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+	handlers.PatchConfig(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	config := db.GetAppConfig()
+	if config.Tracing.Exporter != database.TracingOTLP || config.Tracing.Endpoint != "http://collector:4318" {
+		t.Errorf("expected tracing to be saved, got %+v", config.Tracing)
+	}
+
+	body, _ = json.Marshal(map[string]any{"tracing.exporter": "jaeger"})
+	req = httptest.NewRequest("PATCH", "/api/config", bytes.NewReader(body))
+	rr = httptest.NewRecorder()
+	handlers.PatchConfig(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func TestPatchConfig_Requests(t *testing.T) {
+	db := testDB(t)
+	handlers := NewConfigHandlers(db)
+
+	body, _ := json.Marshal(map[string]any{
+		"requests.url":    " http://overseerr:5055 ",
+		"requests.apiKey": "seerr-key",
+	})
+	req := httptest.NewRequest("PATCH", "/api/config", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+	handlers.PatchConfig(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	config := db.GetAppConfig()
+	if config.Requests.URL != "http://overseerr:5055" || !config.Requests.HasAPIKey || db.GetRequestsAPIKey() != "seerr-key" {
+		t.Errorf("expected requests settings to be saved, got %+v", config.Requests)
+	}
+
+	// The API key is never returned
+	req = httptest.NewRequest("GET", "/api/config", nil)
+	rr = httptest.NewRecorder()
+	handlers.GetConfig(rr, req)
+	if strings.Contains(rr.Body.String(), "seerr-key") {
+		t.Errorf("expected the requests API key to be hidden, got %s", rr.Body.String())
+	}
+}
+
+func TestPatchConfig_Auth(t *testing.T) {
+	db := testDB(t)
+	handlers := NewConfigHandlers(db)
+
+	patch := func(updates map[string]any) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(updates)
+		req := httptest.NewRequest("PATCH", "/api/config", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handlers.PatchConfig(rr, req)
+		return rr
+	}
+
+	// Authentication cannot be enabled without a password
+	if rr := patch(map[string]any{"auth.mode": "enabled"}); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+	if db.GetAppConfig().Auth.Mode != database.AuthDisabled {
+		t.Error("auth mode should not change without a password")
+	}
+
+	rr := patch(map[string]any{"auth.mode": "enabled", "auth.username": "janitor", "auth.password": "correct horse"})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if !db.VerifyCredentials("janitor", "correct horse") {
+		t.Error("expected new credentials to be saved")
+	}
+
+	// The password hash and API key are never part of the config response
+	rr = httptest.NewRecorder()
+	handlers.GetConfig(rr, httptest.NewRequest("GET", "/api/config", nil))
+	if strings.Contains(rr.Body.String(), db.GetAPIKey()) || strings.Contains(rr.Body.String(), "$2a$") {
+		t.Error("config response should not contain credentials")
+	}
+}
+
+func TestConfig_PasswordChangeEndsSessions(t *testing.T) {
+	db := testDB(t)
+	handlers := NewConfigHandlers(db)
+	if err := db.SetAuthPassword("correct horse"); err != nil {
+		t.Fatalf("SetAuthPassword failed: %v", err)
+	}
+	config := db.GetAppConfig()
+	config.Auth.Mode = database.AuthEnabled
+	if err := db.SetAppConfig(config); err != nil {
+		t.Fatalf("SetAppConfig failed: %v", err)
+	}
+
+	protected := middleware.Auth(db)(http.HandlerFunc(handlers.GetConfig))
+	withSession := func(token string) int {
+		req := httptest.NewRequest("GET", "/api/config", nil)
+		req.AddCookie(&http.Cookie{Name: middleware.SessionCookieName, Value: token})
+		rr := httptest.NewRecorder()
+		protected.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	tests := []struct {
+		name   string
+		change func() *httptest.ResponseRecorder
+	}{
+		{"patch", func() *httptest.ResponseRecorder {
+			req := httptest.NewRequest("PATCH", "/api/config", strings.NewReader(`{"auth.password": "battery staple"}`))
+			rr := httptest.NewRecorder()
+			handlers.PatchConfig(rr, req)
+			return rr
+		}},
+		{"form", func() *httptest.ResponseRecorder {
+			req := httptest.NewRequest("POST", "/api/config", strings.NewReader("auth.password=tr0ub4dor+%263"))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rr := httptest.NewRecorder()
+			handlers.PostConfig(rr, req)
+			return rr
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := db.CreateSession("admin", time.Hour)
+			if err != nil {
+				t.Fatalf("CreateSession failed: %v", err)
+			}
+			if code := withSession(token); code != http.StatusOK {
+				t.Fatalf("expected session to be accepted before the change, got %d", code)
+			}
+
+			if rr := tt.change(); rr.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+
+			if code := withSession(token); code != http.StatusUnauthorized {
+				t.Errorf("expected old session to be rejected after the change, got %d", code)
+			}
+		})
+	}
+}
+
 func TestPatchConfig_InvalidJSON(t *testing.T) {
 	db := testDB(t)
 	handlers := NewConfigHandlers(db)
@@ -338,3 +530,20 @@ func TestPostConfig_SearchLimitUpperBound(t *testing.T) {
 		})
 	}
 }
+
+func TestPostConfig_InvalidStrategy(t *testing.T) {
+	db := testDB(t)
+	handlers := NewConfigHandlers(db)
+
+	req := httptest.NewRequest("POST", "/api/config", strings.NewReader("search.strategy=oldest-first"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr := httptest.NewRecorder()
+	handlers.PostConfig(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+	if db.GetAppConfig().Search.Strategy != database.SelectionOldestSearchedFirst {
+		t.Error("invalid strategy should not be saved")
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// ExclusionHandlers provides handlers for the items that must never be searched automatically.
+type ExclusionHandlers struct {
+	Manager *services.ExclusionManager
+}
+
+// NewExclusionHandlers creates a new ExclusionHandlers instance.
+func NewExclusionHandlers(manager *services.ExclusionManager) *ExclusionHandlers {
+	return &ExclusionHandlers{Manager: manager}
+}
+
+// ListExclusions returns all exclusions that have not expired.
+func (h *ExclusionHandlers) ListExclusions(w http.ResponseWriter, r *http.Request) {
+	exclusions, err := h.Manager.List()
+	if err != nil {
+		jsonError(w, fmt.Sprintf("Failed to retrieve exclusions: %v", err), http.StatusInternalServerError)
+		return
+	}
+	jsonSuccess(w, exclusions)
+}
+
+// CreateExclusion excludes an item, series, TMDB or TVDB ID on a server.
+func (h *ExclusionHandlers) CreateExclusion(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		Server    string     `json:"server"` // Server ID or name
+		Kind      string     `json:"kind"`
+		TargetID  int        `json:"targetId"`
+		Title     string     `json:"title"`
+		Reason    string     `json:"reason"`
+		ExpiresAt *time.Time `json:"expiresAt"`
+		Days      int        `json:"days"` // Alternative to ExpiresAt: expire this many days from now
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		jsonError(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	if payload.Kind == "" {
+		payload.Kind = "item"
+	}
+	if payload.ExpiresAt == nil && payload.Days > 0 {
+		expiresAt := time.Now().AddDate(0, 0, payload.Days)
+		payload.ExpiresAt = &expiresAt
+	}
+
+	exclusion, err := h.Manager.Add(payload.Server, payload.Kind, payload.TargetID, payload.Title, payload.Reason, payload.ExpiresAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			jsonError(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, services.ErrExclusionValidation) {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		jsonError(w, fmt.Sprintf("Failed to add exclusion: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	jsonSuccess(w, exclusion)
+}
+
+// DeleteExclusion removes an exclusion, so the items it matched are searched again.
+func (h *ExclusionHandlers) DeleteExclusion(w http.ResponseWriter, r *http.Request) {
+	if err := h.Manager.Remove(chi.URLParam(r, "id")); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			jsonError(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		jsonError(w, fmt.Sprintf("Failed to remove exclusion: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	jsonMessage(w, "Exclusion removed successfully", http.StatusOK)
+}
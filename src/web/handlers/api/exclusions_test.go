@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/services"
+)
+
+func TestExclusionHandlers(t *testing.T) {
+	db := testDB(t)
+	if _, err := db.AddServer("radarr", "http://localhost:7878", "key", database.ServerTypeRadarr); err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	handlers := NewExclusionHandlers(services.NewExclusionManager(db))
+
+	// The log viewer excludes items by server name, with an expiry in days
+	body := `{"server":"radarr","targetId":42,"title":"Movie","days":30}`
+	rr := httptest.NewRecorder()
+	handlers.CreateExclusion(rr, httptest.NewRequest("POST", "/api/exclusions", strings.NewReader(body)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var created struct {
+		Data services.ExclusionInfo `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if created.Data.Kind != database.ExcludeItem || created.Data.TargetID != 42 || created.Data.ExpiresAt == nil {
+		t.Errorf("unexpected exclusion: %+v", created.Data)
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		expected int
+	}{
+		{"unknown server", `{"server":"sonarr","targetId":1}`, http.StatusNotFound},
+		{"unknown kind", `{"server":"radarr","kind":"imdb","targetId":1}`, http.StatusBadRequest},
+		{"series on Radarr", `{"server":"radarr","kind":"series","targetId":1}`, http.StatusBadRequest},
+		{"invalid payload", `{"server":`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handlers.CreateExclusion(rr, httptest.NewRequest("POST", "/api/exclusions", strings.NewReader(tt.body)))
+			if rr.Code != tt.expected {
+				t.Errorf("expected status %d, got %d: %s", tt.expected, rr.Code, rr.Body.String())
+			}
+		})
+	}
+
+	rr = httptest.NewRecorder()
+	handlers.ListExclusions(rr, httptest.NewRequest("GET", "/api/exclusions", nil))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"serverName":"radarr"`) {
+		t.Errorf("expected the exclusion to be listed, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	handlers.DeleteExclusion(rr, withURLParam(httptest.NewRequest("DELETE", "/api/exclusions/"+created.Data.ID, nil), "id", created.Data.ID))
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr = httptest.NewRecorder()
+	handlers.DeleteExclusion(rr, withURLParam(httptest.NewRequest("DELETE", "/api/exclusions/"+created.Data.ID, nil), "id", created.Data.ID))
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// GiveUpHandlers provides handlers for the items Janitarr has given up searching.
+type GiveUpHandlers struct {
+	Manager *services.GiveUpManager
+}
+
+// NewGiveUpHandlers creates a new GiveUpHandlers instance.
+func NewGiveUpHandlers(manager *services.GiveUpManager) *GiveUpHandlers {
+	return &GiveUpHandlers{Manager: manager}
+}
+
+// ListGivenUp returns the items given up on across all servers.
+func (h *GiveUpHandlers) ListGivenUp(w http.ResponseWriter, r *http.Request) {
+	items, err := h.Manager.List()
+	if err != nil {
+		jsonError(w, fmt.Sprintf("Failed to retrieve given up items: %v", err), http.StatusInternalServerError)
+		return
+	}
+	jsonSuccess(w, items)
+}
+
+// ResetGivenUp searches an item given up on again.
+func (h *GiveUpHandlers) ResetGivenUp(w http.ResponseWriter, r *http.Request) {
+	category := database.SearchCategory(chi.URLParam(r, "category"))
+	if category != database.SearchCategoryMissing && category != database.SearchCategoryCutoff {
+		jsonError(w, "Category must be missing or cutoff", http.StatusBadRequest)
+		return
+	}
+	itemID, err := strconv.Atoi(chi.URLParam(r, "item"))
+	if err != nil {
+		jsonError(w, "Item ID must be a number", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Manager.Reset(r.Context(), chi.URLParam(r, "server"), category, itemID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			jsonError(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		jsonError(w, fmt.Sprintf("Failed to reset item: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	jsonMessage(w, "Item will be searched again", http.StatusOK)
+}
+
+// ResetAllGivenUp searches every item given up on again.
+func (h *GiveUpHandlers) ResetAllGivenUp(w http.ResponseWriter, r *http.Request) {
+	reset, err := h.Manager.ResetAll(r.Context())
+	if err != nil {
+		jsonError(w, fmt.Sprintf("Failed to reset items after %d: %v", reset, err), http.StatusInternalServerError)
+		return
+	}
+	jsonMessage(w, fmt.Sprintf("%d items will be searched again", reset), http.StatusOK)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/edrobertsrayne/janitarr/src/database"
 	"github.com/edrobertsrayne/janitarr/src/services"
+	"github.com/edrobertsrayne/janitarr/src/web/middleware"
 )
 
 // HealthHandlers provides handlers for health check API endpoints.
@@ -27,9 +28,27 @@ type HealthResponse struct {
 	Timestamp time.Time              `json:"timestamp"`
 	Services  map[string]interface{} `json:"services"`
 	Database  map[string]string      `json:"database"`
+	Servers   []ServerHealthStatus   `json:"servers"`
 }
 
-// GetHealth performs a comprehensive health check of the application.
+// healthSummary is the health check response for unauthenticated callers. It leaves out server
+// names and error messages, which can reveal internal hostnames.
+type healthSummary struct {
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ServerHealthStatus reports the circuit breaker state of a single server.
+type ServerHealthStatus struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+	database.ServerHealth
+}
+
+// GetHealth performs a comprehensive health check of the application. Callers that aren't
+// authenticated only receive the overall status.
 func (h *HealthHandlers) GetHealth(w http.ResponseWriter, r *http.Request) {
 	status := "ok"
 	statusCode := http.StatusOK
@@ -64,14 +83,56 @@ func (h *HealthHandlers) GetHealth(w http.ResponseWriter, r *http.Request) {
 		servicesStatus["scheduler"].(map[string]interface{})["message"] = "Scheduler is not running"
 	}
 
-	response := HealthResponse{
+	// Check servers. A skipped server degrades the status but doesn't fail the check,
+	// since Janitarr itself is still working.
+	servers := h.serverHealth()
+	for _, server := range servers {
+		if server.Enabled && server.State == database.ServerCircuitOpen && status == "ok" {
+			status = "degraded"
+		}
+	}
+
+	var response interface{} = HealthResponse{
 		Status:    status,
 		Timestamp: time.Now(),
 		Services:  servicesStatus,
 		Database:  databaseStatus,
+		Servers:   servers,
+	}
+	if !middleware.Authenticated(r.Context()) {
+		response = healthSummary{Status: status, Timestamp: time.Now()}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(response)
 }
+
+// serverHealth returns the health of every configured server, or an empty list if it cannot be read.
+func (h *HealthHandlers) serverHealth() []ServerHealthStatus {
+	result := []ServerHealthStatus{}
+
+	servers, err := h.DB.GetAllServers()
+	if err != nil {
+		return result
+	}
+	health, err := h.DB.GetAllServerHealth()
+	if err != nil {
+		return result
+	}
+
+	for _, server := range servers {
+		status := ServerHealthStatus{
+			ID:           server.ID,
+			Name:         server.Name,
+			Type:         string(server.Type),
+			Enabled:      server.Enabled,
+			ServerHealth: database.ServerHealth{State: database.ServerHealthy},
+		}
+		if h, ok := health[server.ID]; ok {
+			status.ServerHealth = h
+		}
+		result = append(result, status)
+	}
+	return result
+}
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/notifications"
+	"github.com/go-chi/chi/v5"
+)
+
+// NotificationHandlers provides handlers for notification channel API endpoints.
+type NotificationHandlers struct {
+	DB *database.DB
+}
+
+// NewNotificationHandlers creates a new NotificationHandlers instance.
+func NewNotificationHandlers(db *database.DB) *NotificationHandlers {
+	return &NotificationHandlers{DB: db}
+}
+
+// stringList decodes either a JSON array of strings or a single string, since form encoders
+// send one checked checkbox as a plain value.
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = stringList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// notificationChannelPayload is the request body for creating or updating a channel.
+type notificationChannelPayload struct {
+	Name          *string    `json:"name"`
+	Provider      string     `json:"provider"`
+	URL           *string    `json:"url"`
+	Token         *string    `json:"token"`
+	Events        stringList `json:"events"`
+	TitleTemplate *string    `json:"titleTemplate"`
+	BodyTemplate  *string    `json:"bodyTemplate"`
+	Enabled       *bool      `json:"enabled"`
+}
+
+// validate checks the events and templates in the payload.
+func (p notificationChannelPayload) validate() error {
+	for _, e := range p.Events {
+		if !database.IsValidNotificationEvent(e) {
+			return fmt.Errorf("invalid event: %s", e)
+		}
+	}
+	for _, tmpl := range []*string{p.TitleTemplate, p.BodyTemplate} {
+		if tmpl != nil {
+			if err := notifications.ValidateTemplate(*tmpl); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p notificationChannelPayload) events() []database.NotificationEvent {
+	if p.Events == nil {
+		return nil
+	}
+	events := make([]database.NotificationEvent, len(p.Events))
+	for i, e := range p.Events {
+		events[i] = database.NotificationEvent(e)
+	}
+	return events
+}
+
+// ListNotificationChannels returns all configured notification channels.
+func (h *NotificationHandlers) ListNotificationChannels(w http.ResponseWriter, r *http.Request) {
+	channels, err := h.DB.GetNotificationChannels()
+	if err != nil {
+		jsonError(w, fmt.Sprintf("Failed to retrieve notification channels: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if channels == nil {
+		channels = []database.NotificationChannel{}
+	}
+	jsonSuccess(w, channels)
+}
+
+// GetNotificationChannel returns a single notification channel by ID.
+func (h *NotificationHandlers) GetNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	channel, ok := h.loadChannel(w, r)
+	if !ok {
+		return
+	}
+	jsonSuccess(w, channel)
+}
+
+// CreateNotificationChannel adds a new notification channel.
+func (h *NotificationHandlers) CreateNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	var payload notificationChannelPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		jsonError(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	if err := payload.validate(); err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	channel := database.NotificationChannel{
+		Provider: database.NotificationProvider(payload.Provider),
+		Events:   payload.events(),
+		Enabled:  payload.Enabled == nil || *payload.Enabled,
+	}
+	if payload.Name != nil {
+		channel.Name = strings.TrimSpace(*payload.Name)
+	}
+	if payload.URL != nil {
+		channel.URL = strings.TrimSpace(*payload.URL)
+	}
+	if payload.Token != nil {
+		channel.Token = *payload.Token
+	}
+	if payload.TitleTemplate != nil {
+		channel.TitleTemplate = *payload.TitleTemplate
+	}
+	if payload.BodyTemplate != nil {
+		channel.BodyTemplate = *payload.BodyTemplate
+	}
+	if channel.Events == nil {
+		channel.Events = database.NotificationEvents // Subscribe to everything by default
+	}
+
+	created, err := h.DB.AddNotificationChannel(channel)
+	if err != nil {
+		errMsg := err.Error()
+		if strings.Contains(errMsg, "already exists") {
+			jsonError(w, errMsg, http.StatusConflict)
+			return
+		}
+		if strings.Contains(errMsg, "required") || strings.Contains(errMsg, "unknown") {
+			jsonError(w, errMsg, http.StatusBadRequest)
+			return
+		}
+		jsonError(w, fmt.Sprintf("Failed to add notification channel: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	jsonSuccess(w, created)
+}
+
+// UpdateNotificationChannel updates an existing notification channel. Omitted fields are unchanged.
+func (h *NotificationHandlers) UpdateNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	channelID := chi.URLParam(r, "id")
+
+	var payload notificationChannelPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		jsonError(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	if err := payload.validate(); err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updates := &database.NotificationChannelUpdate{
+		Name:          payload.Name,
+		URL:           payload.URL,
+		Token:         payload.Token,
+		Events:        payload.events(),
+		TitleTemplate: payload.TitleTemplate,
+		BodyTemplate:  payload.BodyTemplate,
+		Enabled:       payload.Enabled,
+	}
+
+	if err := h.DB.UpdateNotificationChannel(channelID, updates); err != nil {
+		errMsg := err.Error()
+		if strings.Contains(errMsg, "not found") {
+			jsonError(w, "Notification channel not found", http.StatusNotFound)
+			return
+		}
+		if strings.Contains(errMsg, "already exists") {
+			jsonError(w, errMsg, http.StatusConflict)
+			return
+		}
+		jsonError(w, fmt.Sprintf("Failed to update notification channel: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	jsonMessage(w, "Notification channel updated successfully", http.StatusOK)
+}
+
+// DeleteNotificationChannel removes a notification channel.
+func (h *NotificationHandlers) DeleteNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	deleted, err := h.DB.DeleteNotificationChannel(chi.URLParam(r, "id"))
+	if err != nil {
+		jsonError(w, fmt.Sprintf("Failed to remove notification channel: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if !deleted {
+		jsonError(w, "Notification channel not found", http.StatusNotFound)
+		return
+	}
+
+	jsonMessage(w, "Notification channel removed successfully", http.StatusOK)
+}
+
+// TestNotificationChannel sends a sample notification through a channel.
+func (h *NotificationHandlers) TestNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	channel, ok := h.loadChannel(w, r)
+	if !ok {
+		return
+	}
+
+	if err := notifications.SendTest(r.Context(), *channel); err != nil {
+		jsonError(w, fmt.Sprintf("Test notification failed: %v", err), http.StatusBadGateway)
+		return
+	}
+
+	jsonMessage(w, "Test notification sent", http.StatusOK)
+}
+
+// loadChannel fetches the channel named in the URL, writing an error response if it is missing.
+func (h *NotificationHandlers) loadChannel(w http.ResponseWriter, r *http.Request) (*database.NotificationChannel, bool) {
+	channel, err := h.DB.GetNotificationChannel(chi.URLParam(r, "id"))
+	if err != nil {
+		jsonError(w, fmt.Sprintf("Failed to retrieve notification channel: %v", err), http.StatusInternalServerError)
+		return nil, false
+	}
+	if channel == nil {
+		jsonError(w, "Notification channel not found", http.StatusNotFound)
+		return nil, false
+	}
+	return channel, true
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/go-chi/chi/v5"
+)
+
+// withURLParam adds a chi route parameter to the request.
+func withURLParam(req *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestCreateNotificationChannel(t *testing.T) {
+	db := testDB(t)
+	handlers := NewNotificationHandlers(db)
+
+	// A single checked event arrives as a plain string from the settings form
+	body := `{"name":"hooks","provider":"webhook","url":"http://localhost:9000/hook","token":"secret","events":"cycle_end"}`
+	rr := httptest.NewRecorder()
+	handlers.CreateNotificationChannel(rr, httptest.NewRequest("POST", "/api/notifications", strings.NewReader(body)))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if strings.Contains(rr.Body.String(), "secret") {
+		t.Error("token should not be returned")
+	}
+
+	channels, _ := db.GetNotificationChannels()
+	if len(channels) != 1 || !channels[0].Enabled || len(channels[0].Events) != 1 {
+		t.Fatalf("expected one enabled channel with one event, got %+v", channels)
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		expected int
+	}{
+		{"duplicate name", `{"name":"hooks","provider":"webhook","url":"http://x"}`, http.StatusConflict},
+		{"unknown provider", `{"name":"pager","provider":"pager","url":"http://x"}`, http.StatusBadRequest},
+		{"unknown event", `{"name":"a","provider":"ntfy","url":"http://x","events":["party"]}`, http.StatusBadRequest},
+		{"bad template", `{"name":"b","provider":"ntfy","url":"http://x","bodyTemplate":"{{.Cycle"}`, http.StatusBadRequest},
+		{"missing url", `{"name":"c","provider":"ntfy"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handlers.CreateNotificationChannel(rr, httptest.NewRequest("POST", "/api/notifications", strings.NewReader(tt.body)))
+			if rr.Code != tt.expected {
+				t.Errorf("expected status %d, got %d: %s", tt.expected, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestUpdateAndTestNotificationChannel(t *testing.T) {
+	db := testDB(t)
+	handlers := NewNotificationHandlers(db)
+
+	var received map[string]any
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer hook.Close()
+
+	channel, err := db.AddNotificationChannel(database.NotificationChannel{
+		Name: "hooks", Provider: database.NotificationWebhook, URL: "http://127.0.0.1:1/unreachable", Enabled: true,
+	})
+	if err != nil {
+		t.Fatalf("AddNotificationChannel failed: %v", err)
+	}
+
+	payload, _ := json.Marshal(map[string]any{"url": hook.URL, "titleTemplate": "Test from {{.Message}}"})
+	req := withURLParam(httptest.NewRequest("PUT", "/api/notifications/"+channel.ID, bytes.NewReader(payload)), "id", channel.ID)
+	rr := httptest.NewRecorder()
+	handlers.UpdateNotificationChannel(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	req = withURLParam(httptest.NewRequest("POST", "/api/notifications/"+channel.ID+"/test", nil), "id", channel.ID)
+	rr = httptest.NewRecorder()
+	handlers.TestNotificationChannel(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if title, _ := received["title"].(string); !strings.HasPrefix(title, "Test from This is a test notification") {
+		t.Errorf("expected custom title, got %q", title)
+	}
+
+	req = withURLParam(httptest.NewRequest("DELETE", "/api/notifications/missing", nil), "id", "missing")
+	rr = httptest.NewRecorder()
+	handlers.DeleteNotificationChannel(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+}
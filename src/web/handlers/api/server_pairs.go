@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/edrobertsrayne/janitarr/src/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// ListServerPairs returns all server pairs.
+func (h *ServerHandlers) ListServerPairs(w http.ResponseWriter, r *http.Request) {
+	pairs, err := h.ServerManager.ListServerPairs()
+	if err != nil {
+		jsonError(w, fmt.Sprintf("Failed to retrieve server pairs: %v", err), http.StatusInternalServerError)
+		return
+	}
+	jsonSuccess(w, pairs)
+}
+
+// PairServers pairs two servers, replacing any existing pair of them.
+func (h *ServerHandlers) PairServers(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		Primary   string `json:"primary"`   // Server ID or name
+		Secondary string `json:"secondary"` // Server ID or name
+		Policy    string `json:"policy"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		jsonError(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	pair, err := h.ServerManager.PairServers(payload.Primary, payload.Secondary, payload.Policy)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			jsonError(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, services.ErrServerValidation) {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		jsonError(w, fmt.Sprintf("Failed to pair servers: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	jsonSuccess(w, pair)
+}
+
+// UnpairServers removes the pair of two servers.
+func (h *ServerHandlers) UnpairServers(w http.ResponseWriter, r *http.Request) {
+	server, other := chi.URLParam(r, "server"), chi.URLParam(r, "other")
+
+	if err := h.ServerManager.UnpairServers(server, other); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			jsonError(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		jsonError(w, fmt.Sprintf("Failed to unpair servers: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	jsonMessage(w, "Servers unpaired successfully", http.StatusOK)
+}
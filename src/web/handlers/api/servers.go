@@ -108,7 +108,7 @@ func (h *ServerHandlers) UpdateServer(w http.ResponseWriter, r *http.Request) {
 			jsonError(w, "Server not found", http.StatusNotFound)
 			return
 		}
-		if strings.Contains(errMsg, "connection failed") || strings.Contains(errMsg, "already exists") {
+		if strings.Contains(errMsg, "connection failed") || strings.Contains(errMsg, "already exists") || strings.Contains(errMsg, "invalid") {
 			jsonError(w, err.Error(), http.StatusBadRequest)
 			return
 		}
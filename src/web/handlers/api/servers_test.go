@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -24,6 +25,7 @@ type mockServerManager struct {
 	testConnFunc     func(ctx context.Context, id string) (*services.ConnectionResult, error)
 	updateServerFunc func(ctx context.Context, id string, updates services.ServerUpdate) error
 	removeServerFunc func(id string) error
+	pairs            []services.ServerPairInfo
 }
 
 func newMockServerManager() *mockServerManager {
@@ -137,6 +139,37 @@ func (m *mockServerManager) SetServerEnabled(id string, enabled bool) error {
 	return nil
 }
 
+func (m *mockServerManager) PairServers(primary, secondary, policy string) (*services.ServerPairInfo, error) {
+	first, exists := m.servers[primary]
+	if !exists {
+		return nil, errors.New("server not found")
+	}
+	second, exists := m.servers[secondary]
+	if !exists {
+		return nil, errors.New("server not found")
+	}
+	if !database.IsValidPairPolicy(policy) {
+		return nil, fmt.Errorf("%w: invalid pair policy %q", services.ErrServerValidation, policy)
+	}
+	pair := services.ServerPairInfo{PrimaryID: first.ID, PrimaryName: first.Name, SecondaryID: second.ID, SecondaryName: second.Name, Type: first.Type, Policy: policy}
+	m.pairs = append(m.pairs, pair)
+	return &pair, nil
+}
+
+func (m *mockServerManager) ListServerPairs() ([]services.ServerPairInfo, error) {
+	return m.pairs, nil
+}
+
+func (m *mockServerManager) UnpairServers(server, other string) error {
+	for i, pair := range m.pairs {
+		if (pair.PrimaryID == server && pair.SecondaryID == other) || (pair.PrimaryID == other && pair.SecondaryID == server) {
+			m.pairs = append(m.pairs[:i], m.pairs[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("pair not found")
+}
+
 func TestListServers_Empty(t *testing.T) {
 	db := testDB(t)
 	mockMgr := newMockServerManager()
@@ -281,6 +314,34 @@ func TestUpdateServer_Success(t *testing.T) {
 	}
 }
 
+func TestUpdateServer_Filters(t *testing.T) {
+	db := testDB(t)
+	mockMgr := newMockServerManager()
+	var got services.ServerUpdate
+	mockMgr.updateServerFunc = func(ctx context.Context, id string, updates services.ServerUpdate) error {
+		got = updates
+		return nil
+	}
+	handlers := NewServerHandlers(mockMgr, db)
+
+	// The web form sends its flat fields alongside the filters object
+	body := []byte(`{"name": "Sonarr", "type": "sonarr", "filters": {"monitoredOnly": true, "excludeTags": ["kids"], "minYear": 2000}}`)
+	req := httptest.NewRequest("PUT", "/api/servers/abc", bytes.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "abc")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	rr := httptest.NewRecorder()
+	handlers.UpdateServer(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if !got.HasFilters() || !got.Filters.MonitoredOnly || got.Filters.MinYear != 2000 || len(got.Filters.ExcludeTags) != 1 {
+		t.Errorf("expected filters to be passed on, got %+v", got.Filters)
+	}
+}
+
 func TestDeleteServer_Success(t *testing.T) {
 	db := testDB(t)
 	mockMgr := newMockServerManager()
@@ -341,3 +402,56 @@ func TestTestNewServerConnection_Success(t *testing.T) {
 		t.Error("expected successful connection")
 	}
 }
+
+func TestPairServers(t *testing.T) {
+	db := testDB(t)
+	mockMgr := newMockServerManager()
+	handlers := NewServerHandlers(mockMgr, db)
+
+	radarr, _ := mockMgr.AddServer(context.Background(), "radarr", "http://radarr.com", "key", "radarr")
+	radarr4k, _ := mockMgr.AddServer(context.Background(), "radarr-4k", "http://radarr4k.com", "key", "radarr")
+
+	pair := func(policy string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]string{"primary": radarr.ID, "secondary": radarr4k.ID, "policy": policy})
+		rr := httptest.NewRecorder()
+		handlers.PairServers(rr, httptest.NewRequest("POST", "/api/server-pairs", bytes.NewReader(body)))
+		return rr
+	}
+
+	if rr := pair("sometimes"); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an unknown policy, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := pair("prefer-primary"); rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr := httptest.NewRecorder()
+	handlers.ListServerPairs(rr, httptest.NewRequest("GET", "/api/server-pairs", nil))
+	var response struct {
+		Data []services.ServerPairInfo `json:"data"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(response.Data) != 1 || response.Data[0].SecondaryName != "radarr-4k" || response.Data[0].Policy != "prefer-primary" {
+		t.Errorf("unexpected pairs: %+v", response.Data)
+	}
+
+	// Pairs can be removed naming the servers in either order
+	unpair := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest("DELETE", "/api/server-pairs/"+radarr4k.ID+"/"+radarr.ID, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("server", radarr4k.ID)
+		rctx.URLParams.Add("other", radarr.ID)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rr := httptest.NewRecorder()
+		handlers.UnpairServers(rr, req)
+		return rr
+	}
+	if rr := unpair(); rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := unpair(); rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 once unpaired, got %d", rr.Code)
+	}
+}
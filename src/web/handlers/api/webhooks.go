@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/logger"
+	"github.com/edrobertsrayne/janitarr/src/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxWebhookBodyBytes caps the size of a webhook payload; *arr payloads are a few kilobytes.
+const maxWebhookBodyBytes = 64 << 10
+
+// maxPendingWebhooks caps the webhook searches waiting to run. Further webhooks are rejected
+// with 429 so a burst of events can't pile up unbounded goroutines behind a long cycle.
+const maxPendingWebhooks = 16
+
+// WebhookHandlers provides handlers for inbound webhooks that request targeted searches.
+type WebhookHandlers struct {
+	DB      *database.DB
+	Search  *services.TargetedSearch
+	Logger  *logger.Logger
+	pending chan struct{} // One slot per webhook search queued or running
+}
+
+// NewWebhookHandlers creates a new WebhookHandlers instance.
+func NewWebhookHandlers(db *database.DB, search *services.TargetedSearch, appLogger *logger.Logger) *WebhookHandlers {
+	return &WebhookHandlers{
+		DB:      db,
+		Search:  search,
+		Logger:  appLogger,
+		pending: make(chan struct{}, maxPendingWebhooks),
+	}
+}
+
+// webhookItem is an object with an ID inside a Radarr, Sonarr, Lidarr or Readarr webhook.
+type webhookItem struct {
+	ID int `json:"id"`
+}
+
+// webhookPayload accepts both *arr "Connect" webhooks and the generic Janitarr format.
+type webhookPayload struct {
+	// *arr webhooks (On Grab, On Import, On Health, Test, ...)
+	EventType string        `json:"eventType"`
+	Movie     *webhookItem  `json:"movie"`
+	Episodes  []webhookItem `json:"episodes"`
+	Album     *webhookItem  `json:"album"`
+	Albums    []webhookItem `json:"albums"`
+	Book      *webhookItem  `json:"book"`
+	Books     []webhookItem `json:"books"`
+
+	// Generic payload
+	ItemIDs  []int                   `json:"itemIds"`
+	Category database.SearchCategory `json:"category"`
+}
+
+// itemIDs collects the item IDs named anywhere in the payload.
+func (p webhookPayload) itemIDs() []int {
+	ids := append([]int{}, p.ItemIDs...)
+	for _, item := range []*webhookItem{p.Movie, p.Album, p.Book} {
+		if item != nil && item.ID > 0 {
+			ids = append(ids, item.ID)
+		}
+	}
+	for _, list := range [][]webhookItem{p.Episodes, p.Albums, p.Books} {
+		for _, item := range list {
+			if item.ID > 0 {
+				ids = append(ids, item.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// HandleWebhook queues detection and searches for the items named in the payload, or for the whole
+// server when the body is empty or a generic payload names no items.
+// The search waits for any active automation cycle instead of being rejected.
+func (h *WebhookHandlers) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	server, err := h.lookupServer(chi.URLParam(r, "server"))
+	if err != nil {
+		jsonError(w, fmt.Sprintf("Failed to get server: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if server == nil {
+		jsonError(w, "Server not found", http.StatusNotFound)
+		return
+	}
+
+	// An empty body asks for a search of the whole server
+	var payload webhookPayload
+	r.Body = http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes)
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil && !errors.Is(err, io.EOF) {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			jsonError(w, "Request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		jsonError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// *arr sends a Test event when the connection is saved
+	if strings.EqualFold(payload.EventType, "Test") {
+		jsonMessage(w, "Webhook test received", http.StatusOK)
+		return
+	}
+
+	// Only *arr events that name items are acted on; others, such as Health or ApplicationUpdate,
+	// are acknowledged without searching the whole server
+	itemIDs := payload.itemIDs()
+	if payload.EventType != "" && len(itemIDs) == 0 {
+		jsonMessage(w, fmt.Sprintf("Ignored %s event without items", payload.EventType), http.StatusOK)
+		return
+	}
+
+	if payload.Category != "" && payload.Category != database.SearchCategoryMissing && payload.Category != database.SearchCategoryCutoff {
+		jsonError(w, "Invalid category: must be missing or cutoff", http.StatusBadRequest)
+		return
+	}
+	if !server.Enabled {
+		jsonError(w, fmt.Sprintf("Server %s is disabled", server.Name), http.StatusConflict)
+		return
+	}
+
+	req := services.TargetedSearchRequest{
+		ServerID: server.ID,
+		ItemIDs:  itemIDs,
+		Category: payload.Category,
+	}
+
+	select {
+	case h.pending <- struct{}{}:
+	default:
+		jsonError(w, "Too many webhook searches queued, try again later", http.StatusTooManyRequests)
+		return
+	}
+
+	// Run in the background, keeping the request's trace; the search is queued behind any active cycle
+	ctx := context.WithoutCancel(r.Context())
+	go func() {
+		defer func() { <-h.pending }()
+		if _, err := h.Search.Run(ctx, req); err != nil {
+			h.Logger.LogServerError(server.Name, string(server.Type), fmt.Sprintf("Webhook search failed: %v", err))
+		}
+	}()
+
+	msg := fmt.Sprintf("Search queued for %s", server.Name)
+	if len(req.ItemIDs) > 0 {
+		msg = fmt.Sprintf("Search queued for %d item(s) on %s", len(req.ItemIDs), server.Name)
+	}
+	jsonMessage(w, msg, http.StatusAccepted)
+}
+
+// lookupServer finds a server by ID, falling back to its name.
+func (h *WebhookHandlers) lookupServer(ref string) (*database.Server, error) {
+	server, err := h.DB.GetServer(ref)
+	if err != nil || server != nil {
+		return server, err
+	}
+	return h.DB.GetServerByName(ref)
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+	"github.com/edrobertsrayne/janitarr/src/logger"
+	"github.com/edrobertsrayne/janitarr/src/services"
+)
+
+func TestHandleWebhook(t *testing.T) {
+	db := testDB(t)
+	appLogger := logger.NewLogger(db, logger.LevelInfo, false)
+
+	server, err := db.AddServer("Radarr", "http://localhost:7878", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+	disabled, err := db.AddServer("old", "http://localhost:7879", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+	enabled := false
+	if err := db.UpdateServer(disabled.ID, &database.ServerUpdate{Enabled: &enabled}); err != nil {
+		t.Fatalf("UpdateServer failed: %v", err)
+	}
+
+	// Hold a cycle open so queued searches never reach the server
+	started := make(chan struct{})
+	scheduler := services.NewScheduler(nil, 1, func(ctx context.Context, isManual bool) error {
+		close(started)
+		select {}
+	})
+	go func() { _ = scheduler.TriggerManual(context.Background()) }()
+	<-started
+
+	search := services.NewTargetedSearch(db, services.NewDetector(db), services.NewSearchTrigger(db, appLogger), scheduler, appLogger)
+	handlers := NewWebhookHandlers(db, search, appLogger)
+
+	tests := []struct {
+		name     string
+		server   string
+		body     string
+		expected int
+	}{
+		{"empty body by id", server.ID, "", http.StatusAccepted},
+		{"radarr import by name", "radarr", `{"eventType":"Download","movie":{"id":12}}`, http.StatusAccepted},
+		{"sonarr grab", server.ID, `{"eventType":"Grab","series":{"id":3},"episodes":[{"id":7},{"id":8}]}`, http.StatusAccepted},
+		{"health", server.ID, `{"eventType":"Health","level":"warning"}`, http.StatusOK},
+		{"application update", server.ID, `{"eventType":"ApplicationUpdate"}`, http.StatusOK},
+		{"grab without items", server.ID, `{"eventType":"Grab","series":{"id":3},"episodes":[]}`, http.StatusOK},
+		{"generic", server.ID, `{"itemIds":[1,2],"category":"cutoff"}`, http.StatusAccepted},
+		{"test event", server.ID, `{"eventType":"Test"}`, http.StatusOK},
+		{"unknown server", "nope", "", http.StatusNotFound},
+		{"disabled server", disabled.ID, "", http.StatusConflict},
+		{"invalid category", server.ID, `{"category":"everything"}`, http.StatusBadRequest},
+		{"invalid json", server.ID, `{"itemIds":`, http.StatusBadRequest},
+		{"oversized body", server.ID, `{"eventType":"` + strings.Repeat("x", maxWebhookBodyBytes) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := withURLParam(httptest.NewRequest("POST", "/api/webhooks/"+tt.server, strings.NewReader(tt.body)), "server", tt.server)
+			rr := httptest.NewRecorder()
+			queued := len(handlers.pending)
+			handlers.HandleWebhook(rr, req)
+
+			if rr.Code != tt.expected {
+				t.Errorf("expected status %d, got %d: %s", tt.expected, rr.Code, rr.Body.String())
+			}
+			if searched := len(handlers.pending) > queued; searched != (rr.Code == http.StatusAccepted) {
+				t.Errorf("expected a search to be queued only for accepted webhooks, got status %d with search queued %v", rr.Code, searched)
+			}
+		})
+	}
+}
+
+func TestHandleWebhook_RejectsWhenQueueFull(t *testing.T) {
+	db := testDB(t)
+	appLogger := logger.NewLogger(db, logger.LevelInfo, false)
+	server, err := db.AddServer("Radarr", "http://localhost:7878", "key", database.ServerTypeRadarr)
+	if err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+
+	// Hold a cycle open so every queued search stays pending
+	started := make(chan struct{})
+	scheduler := services.NewScheduler(nil, 1, func(ctx context.Context, isManual bool) error {
+		close(started)
+		select {}
+	})
+	go func() { _ = scheduler.TriggerManual(context.Background()) }()
+	<-started
+
+	search := services.NewTargetedSearch(db, services.NewDetector(db), services.NewSearchTrigger(db, appLogger), scheduler, appLogger)
+	handlers := NewWebhookHandlers(db, search, appLogger)
+
+	send := func() int {
+		req := withURLParam(httptest.NewRequest("POST", "/api/webhooks/"+server.ID, strings.NewReader("")), "server", server.ID)
+		rr := httptest.NewRecorder()
+		handlers.HandleWebhook(rr, req)
+		return rr.Code
+	}
+
+	for i := 0; i < maxPendingWebhooks; i++ {
+		if code := send(); code != http.StatusAccepted {
+			t.Fatalf("webhook %d: expected status 202, got %d", i+1, code)
+		}
+	}
+	if code := send(); code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429 once the queue is full, got %d", code)
+	}
+}
+
+func TestWebhookPayload_ItemIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  webhookPayload
+		expected []int
+	}{
+		{"none", webhookPayload{EventType: "Health"}, []int{}},
+		{"movie", webhookPayload{Movie: &webhookItem{ID: 4}}, []int{4}},
+		{"episodes", webhookPayload{Episodes: []webhookItem{{ID: 7}, {ID: 8}}}, []int{7, 8}},
+		{"albums and books", webhookPayload{Albums: []webhookItem{{ID: 2}}, Book: &webhookItem{ID: 9}}, []int{9, 2}},
+		{"generic", webhookPayload{ItemIDs: []int{1, 2}}, []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.payload.itemIDs(); !slices.Equal(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/edrobertsrayne/janitarr/src/database"
 	"github.com/edrobertsrayne/janitarr/src/logger"
 	"github.com/edrobertsrayne/janitarr/src/templates/pages"
 )
@@ -21,14 +22,30 @@ func (h *PageHandlers) HandleDashboard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Search outcomes are optional; servers without any show no success rate
+	outcomes := make(map[string]database.SearchOutcomeStats)
+	if outcomeStats, err := h.db.GetSearchOutcomeStats(); err == nil {
+		for _, stats := range outcomeStats {
+			outcomes[stats.ServerID] = stats
+		}
+	}
+
+	// Backlog counts come from the wanted lists cached by the last detection, so no server is
+	// contacted; servers without a cache show no backlog
+	backlogs, _ := h.db.GetAllWantedCache()
+
 	// Convert servers to display format
 	serverDisplays := make([]pages.ServerDisplay, len(servers))
 	for i, srv := range servers {
 		serverDisplays[i] = pages.ServerDisplay{
-			Name:    srv.Name,
-			Type:    string(srv.Type),
-			URL:     srv.URL,
-			Enabled: srv.Enabled,
+			Name:     srv.Name,
+			Type:     string(srv.Type),
+			URL:      srv.URL,
+			Enabled:  srv.Enabled,
+			Outcomes: outcomes[srv.ID],
+		}
+		if backlog, ok := backlogs[srv.ID]; ok {
+			serverDisplays[i].Backlog = &backlog
 		}
 	}
 
@@ -0,0 +1,82 @@
+package pages
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/edrobertsrayne/janitarr/src/templates/pages"
+	"github.com/edrobertsrayne/janitarr/src/web/middleware"
+)
+
+// sessionTTL is how long a login session lasts.
+const sessionTTL = 30 * 24 * time.Hour
+
+// HandleLogin renders the login page
+func (h *PageHandlers) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	pages.Login(safeReturnURL(r.URL.Query().Get("returnUrl")), "").Render(r.Context(), w)
+}
+
+// HandleLoginSubmit checks credentials and starts a session
+func (h *PageHandlers) HandleLoginSubmit(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	returnURL := safeReturnURL(r.FormValue("returnUrl"))
+	username := strings.TrimSpace(r.FormValue("username"))
+
+	if !h.db.VerifyCredentials(username, r.FormValue("password")) {
+		if h.logger != nil {
+			h.logger.Warn("Failed login attempt", "username", username, "remote", r.RemoteAddr)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		pages.Login(returnURL, "Invalid username or password").Render(r.Context(), w)
+		return
+	}
+
+	token, err := h.db.CreateSession(username, sessionTTL)
+	if err != nil {
+		http.Error(w, "Failed to create session", http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     middleware.SessionCookieName,
+		Value:    token,
+		Path:     "/",
+		Expires:  time.Now().Add(sessionTTL),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, returnURL, http.StatusSeeOther)
+}
+
+// HandleLogout ends the current session
+func (h *PageHandlers) HandleLogout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(middleware.SessionCookieName); err == nil {
+		_ = h.db.DeleteSession(cookie.Value)
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     middleware.SessionCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, "/login", http.StatusSeeOther)
+}
+
+// safeReturnURL only allows redirects to local paths so the login form cannot be used as an open redirect
+func safeReturnURL(returnURL string) string {
+	if !strings.HasPrefix(returnURL, "/") || strings.HasPrefix(returnURL, "//") || strings.HasPrefix(returnURL, "/\\") {
+		return "/"
+	}
+	return returnURL
+}
@@ -3,6 +3,7 @@ package pages
 import (
 	"net/http"
 
+	"github.com/edrobertsrayne/janitarr/src/database"
 	"github.com/edrobertsrayne/janitarr/src/services"
 	"github.com/edrobertsrayne/janitarr/src/templates/components/forms"
 	"github.com/edrobertsrayne/janitarr/src/templates/pages"
@@ -17,6 +18,9 @@ func (h *PageHandlers) HandleServers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Health is informational, so the page still renders if it cannot be read
+	health, _ := h.db.GetAllServerHealth()
+
 	// Convert to ServerInfo
 	serverInfos := make([]services.ServerInfo, len(servers))
 	for i, srv := range servers {
@@ -26,12 +30,19 @@ func (h *PageHandlers) HandleServers(w http.ResponseWriter, r *http.Request) {
 			URL:       srv.URL,
 			Type:      string(srv.Type),
 			Enabled:   srv.Enabled,
+			Health:    database.ServerHealth{State: database.ServerHealthy},
 			CreatedAt: srv.CreatedAt,
 			UpdatedAt: srv.UpdatedAt,
 		}
+		if h, ok := health[srv.ID]; ok {
+			serverInfos[i].Health = h
+		}
 	}
 
-	pages.Servers(serverInfos).Render(r.Context(), w)
+	// Like health, given up items are informational
+	givenUp, _ := services.NewGiveUpManager(h.db).List()
+
+	pages.Servers(serverInfos, givenUp).Render(r.Context(), w)
 }
 
 // HandleNewServerForm renders the new server form modal
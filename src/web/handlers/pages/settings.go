@@ -17,5 +17,11 @@ func (h *PageHandlers) HandleSettings(w http.ResponseWriter, r *http.Request) {
 		logCount = 0
 	}
 
-	pages.Settings(config, logCount).Render(r.Context(), w)
+	channels, err := h.db.GetNotificationChannels()
+	if err != nil {
+		http.Error(w, "Failed to load notification channels", http.StatusInternalServerError)
+		return
+	}
+
+	pages.Settings(config, logCount, h.db.GetAPIKey(), channels).Render(r.Context(), w)
 }
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/edrobertsrayne/janitarr/src/database"
+)
+
+// SessionCookieName is the cookie holding the web UI session token.
+const SessionCookieName = "janitarr_session"
+
+// APIKeyHeader is the header scripts use to authenticate with the API key.
+const APIKeyHeader = "X-Api-Key"
+
+type contextKey string
+
+const (
+	sessionUserKey   contextKey = "sessionUser"
+	peerAddrKey      contextKey = "peerAddr"
+	authenticatedKey contextKey = "authenticated"
+)
+
+// publicPaths are reachable without authentication. Entries ending in "/" match as prefixes.
+var publicPaths = []string{"/login", "/health", "/api/health", "/static/"}
+
+// PeerAddr records the address of the TCP peer before proxy headers can rewrite r.RemoteAddr.
+// It must run ahead of chi's RealIP middleware so local-address checks can't be spoofed with
+// X-Forwarded-For or X-Real-IP.
+func PeerAddr(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), peerAddrKey, r.RemoteAddr)))
+	})
+}
+
+// Auth is a middleware that requires a session cookie or API key according to the configured auth mode.
+func Auth(db *database.DB) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Resolve the session even on public paths so pages can show who is logged in
+			if cookie, err := r.Cookie(SessionCookieName); err == nil {
+				if user := db.GetSessionUser(cookie.Value); user != "" {
+					r = r.WithContext(context.WithValue(r.Context(), sessionUserKey, user))
+				}
+			}
+
+			// Public paths are served either way, but can check Authenticated to limit what they reveal
+			authenticated := isAuthenticated(db, r)
+			if authenticated || isPublicPath(r.URL.Path) {
+				next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), authenticatedKey, authenticated)))
+				return
+			}
+
+			unauthorized(w, r)
+		})
+	}
+}
+
+// Authenticated reports whether the request passed authentication, including when the auth mode
+// doesn't require it. It is false on public paths for callers that would otherwise be rejected.
+func Authenticated(ctx context.Context) bool {
+	authenticated, _ := ctx.Value(authenticatedKey).(bool)
+	return authenticated
+}
+
+// isAuthenticated checks the request's session, the auth mode and its API key.
+func isAuthenticated(db *database.DB, r *http.Request) bool {
+	if SessionUser(r.Context()) != "" {
+		return true
+	}
+
+	switch db.GetAppConfig().Auth.Mode {
+	case database.AuthDisabled:
+		return true
+	case database.AuthDisabledForLocal:
+		if isLocalAddress(peerAddr(r)) {
+			return true
+		}
+	}
+
+	return validAPIKey(db, r)
+}
+
+// SessionUser returns the username of the logged-in session, or "" if the request has none.
+func SessionUser(ctx context.Context) string {
+	user, _ := ctx.Value(sessionUserKey).(string)
+	return user
+}
+
+// peerAddr returns the connection address recorded by PeerAddr, falling back to r.RemoteAddr
+// when the middleware isn't installed.
+func peerAddr(r *http.Request) string {
+	if addr, ok := r.Context().Value(peerAddrKey).(string); ok {
+		return addr
+	}
+	return r.RemoteAddr
+}
+
+// isLocalAddress reports whether a request's remote address is loopback, private or link-local.
+func isLocalAddress(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr // Tolerate a bare IP without a port
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast()
+}
+
+func isPublicPath(path string) bool {
+	for _, p := range publicPaths {
+		if path == p || (strings.HasSuffix(p, "/") && strings.HasPrefix(path, p)) {
+			return true
+		}
+	}
+	return false
+}
+
+// validAPIKey checks the X-Api-Key header, or the apikey query parameter for clients such as
+// Prometheus that cannot set headers.
+func validAPIKey(db *database.DB, r *http.Request) bool {
+	provided := r.Header.Get(APIKeyHeader)
+	if provided == "" {
+		provided = r.URL.Query().Get("apikey")
+	}
+	expected := db.GetAPIKey()
+	if provided == "" || expected == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(provided), []byte(expected)) == 1
+}
+
+// unauthorized returns 401 JSON for API and WebSocket clients and redirects browsers to the login page.
+func unauthorized(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/api/") || strings.HasPrefix(r.URL.Path, "/ws/") || r.URL.Path == "/metrics" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+
+	loginURL := "/login?returnUrl=" + url.QueryEscape(r.URL.RequestURI())
+
+	// htmx follows redirects transparently, so ask it to navigate the whole page instead,
+	// returning to the page that made the partial request
+	if r.Header.Get("HX-Request") == "true" {
+		returnURL := "/"
+		if current, err := url.Parse(r.Header.Get("HX-Current-URL")); err == nil && current.Path != "" {
+			returnURL = current.RequestURI()
+		}
+		w.Header().Set("HX-Redirect", "/login?returnUrl="+url.QueryEscape(returnURL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	http.Redirect(w, r, loginURL, http.StatusSeeOther)
+}
@@ -26,6 +26,7 @@ type ServerConfig struct {
 	DB        *database.DB
 	Logger    *logger.Logger
 	Scheduler *services.Scheduler
+	Metrics   *metrics.Metrics // Shared with the automation services; created if nil
 	IsDev     bool
 }
 
@@ -41,7 +42,10 @@ type Server struct {
 // NewServer creates a new HTTP server instance.
 func NewServer(config ServerConfig) *Server {
 	r := chi.NewRouter()
-	prometheusMetrics := metrics.NewMetrics() // Initialize Prometheus metrics
+	prometheusMetrics := config.Metrics
+	if prometheusMetrics == nil {
+		prometheusMetrics = metrics.NewMetrics() // Initialize Prometheus metrics
+	}
 
 	// Wire scheduler and database to metrics
 	if config.Scheduler != nil {
@@ -98,22 +102,32 @@ func (s *Server) setupRoutes() {
 
 	// Middleware
 	r.Use(chiMiddleware.RequestID)
+	r.Use(webMiddleware.PeerAddr) // Record the connection address before RealIP rewrites it
 	r.Use(chiMiddleware.RealIP)
+	r.Use(webMiddleware.Tracing) // Start a span per request, continuing the caller's trace
 	r.Use(func(next http.Handler) http.Handler {
 		return webMiddleware.Recoverer(next, s.config.IsDev)
 	})
 	r.Use(webMiddleware.RequestLogger(s.config.Logger)) // Use custom request logger with logger
 	r.Use(s.metricsMiddleware)                          // Use Prometheus metrics middleware
+	r.Use(webMiddleware.Auth(s.config.DB))              // Require login or API key per auth mode
 
 	// Handlers
 	configHandlers := api.NewConfigHandlers(s.config.DB)
 	serverManager := services.NewServerManager(s.config.DB, s.config.Logger)
 	serverHandlers := api.NewServerHandlers(serverManager, s.config.DB)
 	logHandlers := api.NewLogHandlers(s.config.DB)
+	notificationHandlers := api.NewNotificationHandlers(s.config.DB)
 	healthHandlers := api.NewHealthHandlers(s.config.DB, s.config.Scheduler)
+	giveUpHandlers := api.NewGiveUpHandlers(services.NewGiveUpManager(s.config.DB))
+	exclusionHandlers := api.NewExclusionHandlers(services.NewExclusionManager(s.config.DB))
 
-	automationService := services.NewAutomation(s.config.DB, services.NewDetector(s.config.DB), services.NewSearchTrigger(s.config.DB, s.config.Logger), s.config.Logger)
+	detector := services.NewDetector(s.config.DB).WithMetrics(s.prometheusMetrics).WithLogger(s.config.Logger)
+	searchTrigger := services.NewSearchTrigger(s.config.DB, s.config.Logger).WithMetrics(s.prometheusMetrics)
+	automationService := services.NewAutomation(s.config.DB, detector, searchTrigger, s.config.Logger).WithMetrics(s.prometheusMetrics)
 	automationHandlers := api.NewAutomationHandlers(s.config.DB, automationService, s.config.Scheduler, s.config.Logger)
+	targetedSearch := services.NewTargetedSearch(s.config.DB, detector, searchTrigger, s.config.Scheduler, s.config.Logger)
+	webhookHandlers := api.NewWebhookHandlers(s.config.DB, targetedSearch, s.config.Logger)
 	statsHandlers := api.NewStatsHandlers(s.config.DB)             // Instantiate StatsHandlers
 	metricsHandlers := api.NewMetricsHandlers(s.prometheusMetrics) // Instantiate MetricsHandlers
 
@@ -130,6 +144,7 @@ func (s *Server) setupRoutes() {
 		r.Post("/config", configHandlers.PostConfig)
 		r.Patch("/config", configHandlers.PatchConfig)
 		r.Put("/config/reset", configHandlers.ResetConfig)
+		r.Post("/config/apikey", configHandlers.RegenerateAPIKey)
 
 		r.Get("/servers", serverHandlers.ListServers)
 		r.Post("/servers", serverHandlers.CreateServer)
@@ -140,6 +155,26 @@ func (s *Server) setupRoutes() {
 			r.Delete("/", serverHandlers.DeleteServer)
 			r.Post("/test", serverHandlers.TestServerConnection) // Test existing server
 		})
+		r.Get("/server-pairs", serverHandlers.ListServerPairs)
+		r.Post("/server-pairs", serverHandlers.PairServers)                      // Pair two servers, replacing any existing pair
+		r.Delete("/server-pairs/{server}/{other}", serverHandlers.UnpairServers) // Either order
+
+		r.Get("/given-up", giveUpHandlers.ListGivenUp)
+		r.Delete("/given-up", giveUpHandlers.ResetAllGivenUp)
+		r.Delete("/given-up/{server}/{category}/{item}", giveUpHandlers.ResetGivenUp) // Server ID or name
+
+		r.Get("/exclusions", exclusionHandlers.ListExclusions)
+		r.Post("/exclusions", exclusionHandlers.CreateExclusion)
+		r.Delete("/exclusions/{id}", exclusionHandlers.DeleteExclusion)
+
+		r.Get("/notifications", notificationHandlers.ListNotificationChannels)
+		r.Post("/notifications", notificationHandlers.CreateNotificationChannel)
+		r.Route("/notifications/{id}", func(r chi.Router) {
+			r.Get("/", notificationHandlers.GetNotificationChannel)
+			r.Put("/", notificationHandlers.UpdateNotificationChannel)
+			r.Delete("/", notificationHandlers.DeleteNotificationChannel)
+			r.Post("/test", notificationHandlers.TestNotificationChannel) // Send a sample notification
+		})
 
 		r.Get("/logs", logHandlers.ListLogs)          // List logs
 		r.Delete("/logs", logHandlers.ClearLogs)      // Clear logs
@@ -148,6 +183,8 @@ func (s *Server) setupRoutes() {
 		r.Post("/automation/trigger", automationHandlers.TriggerAutomationCycle)
 		r.Get("/automation/status", automationHandlers.GetSchedulerStatus)
 
+		r.Post("/webhooks/{server}", webhookHandlers.HandleWebhook) // Targeted search from *arr or external tools
+
 		r.Get("/stats/summary", statsHandlers.GetSummaryStats)
 		r.Get("/stats/servers/{id}", statsHandlers.GetServerStats)
 	})
@@ -158,6 +195,11 @@ func (s *Server) setupRoutes() {
 	// WebSocket
 	r.Get("/ws/logs", s.wsHub.ServeWS)
 
+	// Authentication
+	r.Get("/login", pageHandlers.HandleLogin)
+	r.Post("/login", pageHandlers.HandleLoginSubmit)
+	r.Post("/logout", pageHandlers.HandleLogout)
+
 	// Page routes
 	r.Get("/", pageHandlers.HandleDashboard)
 	r.Get("/servers", pageHandlers.HandleServers)
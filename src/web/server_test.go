@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/edrobertsrayne/janitarr/src/database"
 	"github.com/edrobertsrayne/janitarr/src/logger"
@@ -88,3 +91,201 @@ func TestHealthRouteAlias(t *testing.T) {
 		t.Errorf("expected both endpoints to return same status, got %s and %s", resp.Status, resp2.Status)
 	}
 }
+
+func TestHealth_ServerStates(t *testing.T) {
+	db := testDB(t)
+	server := testServer(t, db)
+
+	sonarr, err := db.AddServer("Sonarr", "http://localhost:8989", "key", database.ServerTypeSonarr)
+	if err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	if _, err := db.AddServer("Radarr", "http://localhost:7878", "key", database.ServerTypeRadarr); err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	openUntil := time.Now().Add(time.Hour)
+	if err := db.SetServerHealth(sonarr.ID, database.ServerHealth{
+		State:               database.ServerCircuitOpen,
+		ConsecutiveFailures: 3,
+		LastError:           "connection refused",
+		OpenUntil:           &openUntil,
+	}); err != nil {
+		t.Fatalf("setting server health: %v", err)
+	}
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/health", nil))
+
+	var resp api.HealthResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal /api/health response: %v\nBody: %s", err, rr.Body.String())
+	}
+	if resp.Status == "ok" {
+		t.Error("expected a skipped server to degrade the health status")
+	}
+
+	states := make(map[string]database.ServerHealthState)
+	for _, s := range resp.Servers {
+		states[s.Name] = s.State
+	}
+	if states["Sonarr"] != database.ServerCircuitOpen || states["Radarr"] != database.ServerHealthy {
+		t.Errorf("unexpected server states: %v", states)
+	}
+}
+
+// testServer builds a server with routes registered for request tests.
+func testServer(t *testing.T, db *database.DB) *Server {
+	t.Helper()
+	log := logger.NewLogger(db, logger.LevelInfo, false)
+	scheduler := services.NewScheduler(db, 6, func(ctx context.Context, isManual bool) error {
+		return nil
+	})
+	server := NewServer(ServerConfig{Port: 3434, Host: "localhost", DB: db, Logger: log, Scheduler: scheduler})
+	server.setupRoutes()
+	return server
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	db := testDB(t)
+	if err := db.SetAuthPassword("correct horse"); err != nil {
+		t.Fatalf("SetAuthPassword failed: %v", err)
+	}
+	config := db.GetAppConfig()
+	config.Auth.Mode = database.AuthEnabled
+	if err := db.SetAppConfig(config); err != nil {
+		t.Fatalf("SetAppConfig failed: %v", err)
+	}
+	server := testServer(t, db)
+
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// API requests without credentials are rejected
+	if rr := serve(httptest.NewRequest("GET", "/api/config", nil)); rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for /api/config, got %d", rr.Code)
+	}
+
+	// Pages redirect to the login form
+	rr := serve(httptest.NewRequest("GET", "/settings", nil))
+	if rr.Code != http.StatusSeeOther || rr.Header().Get("Location") != "/login?returnUrl=%2Fsettings" {
+		t.Errorf("expected redirect to login, got %d %q", rr.Code, rr.Header().Get("Location"))
+	}
+
+	// Health checks stay public
+	if rr := serve(httptest.NewRequest("GET", "/health", nil)); rr.Code == http.StatusUnauthorized {
+		t.Error("expected /health to be public")
+	}
+
+	// but only report server details to authenticated callers
+	if _, err := db.AddServer("Sonarr", "http://sonarr.internal:8989", "key", database.ServerTypeSonarr); err != nil {
+		t.Fatalf("adding server: %v", err)
+	}
+	rr = serve(httptest.NewRequest("GET", "/api/health", nil))
+	if rr.Code == http.StatusUnauthorized || strings.Contains(rr.Body.String(), "servers") {
+		t.Errorf("expected only the overall status without credentials, got %d %s", rr.Code, rr.Body.String())
+	}
+	req := httptest.NewRequest("GET", "/api/health", nil)
+	req.Header.Set("X-Api-Key", db.GetAPIKey())
+	if rr := serve(req); !strings.Contains(rr.Body.String(), `"name":"Sonarr"`) {
+		t.Errorf("expected server details with API key, got %s", rr.Body.String())
+	}
+
+	// The API key is accepted in the header
+	req = httptest.NewRequest("GET", "/api/config", nil)
+	req.Header.Set("X-Api-Key", db.GetAPIKey())
+	if rr := serve(req); rr.Code != http.StatusOK {
+		t.Errorf("expected 200 with API key, got %d", rr.Code)
+	}
+
+	// Wrong credentials re-render the login form
+	form := url.Values{"username": {"admin"}, "password": {"wrong password"}, "returnUrl": {"/settings"}}
+	req = httptest.NewRequest("POST", "/login", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if rr := serve(req); rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for bad login, got %d", rr.Code)
+	}
+
+	// A successful login sets a session cookie and redirects back
+	form.Set("password", "correct horse")
+	req = httptest.NewRequest("POST", "/login", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr = serve(req)
+	if rr.Code != http.StatusSeeOther || rr.Header().Get("Location") != "/settings" {
+		t.Fatalf("expected redirect to /settings, got %d %q", rr.Code, rr.Header().Get("Location"))
+	}
+	cookies := rr.Result().Cookies()
+	if len(cookies) != 1 || !cookies[0].HttpOnly {
+		t.Fatalf("expected one HttpOnly session cookie, got %v", cookies)
+	}
+
+	req = httptest.NewRequest("GET", "/api/config", nil)
+	req.AddCookie(cookies[0])
+	if rr := serve(req); rr.Code != http.StatusOK {
+		t.Errorf("expected 200 with session cookie, got %d", rr.Code)
+	}
+
+	// Off-site return URLs are ignored
+	form.Set("returnUrl", "//evil.example.com")
+	req = httptest.NewRequest("POST", "/login", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if rr := serve(req); rr.Header().Get("Location") != "/" {
+		t.Errorf("expected redirect to /, got %q", rr.Header().Get("Location"))
+	}
+}
+
+func TestAuthMiddleware_DisabledForLocal(t *testing.T) {
+	db := testDB(t)
+	if err := db.SetAuthPassword("correct horse"); err != nil {
+		t.Fatalf("SetAuthPassword failed: %v", err)
+	}
+	config := db.GetAppConfig()
+	config.Auth.Mode = database.AuthDisabledForLocal
+	if err := db.SetAppConfig(config); err != nil {
+		t.Fatalf("SetAppConfig failed: %v", err)
+	}
+	server := testServer(t, db)
+
+	tests := []struct {
+		remoteAddr string
+		expected   int
+	}{
+		{"127.0.0.1:5000", http.StatusOK},
+		{"192.168.1.20:5000", http.StatusOK},
+		{"[::1]:5000", http.StatusOK},
+		{"203.0.113.9:5000", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/api/config", nil)
+		req.RemoteAddr = tt.remoteAddr
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+		if rr.Code != tt.expected {
+			t.Errorf("%s: expected %d, got %d", tt.remoteAddr, tt.expected, rr.Code)
+		}
+	}
+
+	// Proxy headers can't make a remote client look local
+	for _, header := range []string{"X-Forwarded-For", "X-Real-IP"} {
+		req := httptest.NewRequest("GET", "/api/config", nil)
+		req.RemoteAddr = "203.0.113.9:5000"
+		req.Header.Set(header, "127.0.0.1")
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+		if rr.Code != http.StatusUnauthorized {
+			t.Errorf("%s spoofing localhost: expected 401, got %d", header, rr.Code)
+		}
+
+		req = httptest.NewRequest("GET", "/settings", nil)
+		req.RemoteAddr = "203.0.113.9:5000"
+		req.Header.Set(header, "127.0.0.1")
+		rr = httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+		if rr.Code != http.StatusSeeOther {
+			t.Errorf("%s spoofing localhost on a page: expected redirect, got %d", header, rr.Code)
+		}
+	}
+}